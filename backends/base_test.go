@@ -60,6 +60,29 @@ func runTest(t *testing.T, rec *agscheduler.Recorder) {
 	assert.Equal(t, 2, int(total))
 	assert.Equal(t, agscheduler.RECORD_STATUS_COMPLETED, records[0].Status)
 
+	p := agscheduler.Progress{Percent: 50, Message: "half", Fields: map[string]any{"offset": "10"}}
+	err = rec.RecordProgress(records[0].Id, p)
+	assert.NoError(t, err)
+	records, _, err = rec.GetRecords(job.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, p.Percent, records[0].Progress.Percent)
+	assert.Equal(t, p.Message, records[0].Progress.Message)
+	assert.Equal(t, p.Fields, records[0].Progress.Fields)
+
+	err = rec.RecordSoftTimeout(records[0].Id)
+	assert.NoError(t, err)
+	records, _, err = rec.GetRecords(job.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.True(t, records[0].SoftTimedOut)
+
+	err = rec.RecordLogs(records[0].Id, "a\nb\n")
+	assert.NoError(t, err)
+	logs, err := rec.GetRecordLogs(records[0].Id, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "b\n", logs)
+	_, err = rec.GetRecordLogs(0, 0)
+	assert.ErrorIs(t, err, agscheduler.RecordNotFoundError(0))
+
 	records, total, err = rec.GetRecords(job.Id, 2, 1)
 	assert.NoError(t, err)
 	assert.Len(t, records, 1)
@@ -1,6 +1,7 @@
 package backends
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,6 +21,12 @@ type Records struct {
 	Result  string    `gorm:"type:text"`
 	StartAt time.Time `gorm:"not null"`
 	EndAt   time.Time `gorm:"default:null"`
+	Attempt int       `gorm:"not null;default:1"`
+	// JSON of `agscheduler.Progress`.
+	Progress     string `gorm:"type:text"`
+	SoftTimedOut bool   `gorm:"not null;default:false"`
+	// Not loaded with the records, get them by `GetRecordLogs`.
+	Logs string `gorm:"type:text"`
 }
 
 // Store job records in a database table using GORM.
@@ -54,6 +61,7 @@ func (b *GormBackend) RecordMetadata(r agscheduler.Record) error {
 		Result:  r.Result,
 		StartAt: r.StartAt,
 		EndAt:   r.EndAt,
+		Attempt: r.Attempt,
 	}
 
 	return b.DB.Table(b.TableName).Create(&rs).Error
@@ -67,11 +75,45 @@ func (b *GormBackend) RecordResult(id uint64, status string, result string) erro
 		Error
 }
 
+func (b *GormBackend) RecordProgress(id uint64, p agscheduler.Progress) error {
+	progress, err := agscheduler.ProgressMarshal(p)
+	if err != nil {
+		return err
+	}
+
+	return b.DB.Table(b.TableName).Where("id = ?", id).
+		Update("progress", progress).
+		Error
+}
+
+func (b *GormBackend) RecordSoftTimeout(id uint64) error {
+	return b.DB.Table(b.TableName).Where("id = ?", id).
+		Update("soft_timed_out", true).
+		Error
+}
+
+func (b *GormBackend) RecordLogs(id uint64, logs string) error {
+	return b.DB.Table(b.TableName).Where("id = ?", id).
+		Update("logs", logs).
+		Error
+}
+
+func (b *GormBackend) GetRecordLogs(id uint64) (string, error) {
+	var rs Records
+	err := b.DB.Table(b.TableName).Select("logs").Where("id = ?", id).
+		Take(&rs).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", agscheduler.RecordNotFoundError(id)
+	}
+
+	return rs.Logs, err
+}
+
 func (b *GormBackend) _getRecords(page, pageSize int, query any, args ...any) ([]agscheduler.Record, int64, error) {
 	var rsList []*Records
 	total := int64(0)
 
-	err := b.DB.Table(b.TableName).Where(query, args...).
+	err := b.DB.Table(b.TableName).Omit("logs").Where(query, args...).
 		Order("start_at desc").
 		Limit(pageSize).Offset((page - 1) * pageSize).
 		Find(&rsList).Error
@@ -87,14 +129,22 @@ func (b *GormBackend) _getRecords(page, pageSize int, query any, args ...any) ([
 
 	recordList := []agscheduler.Record{}
 	for _, rs := range rsList {
+		progress, err := agscheduler.ProgressUnmarshal(rs.Progress)
+		if err != nil {
+			return nil, total, err
+		}
 		recordList = append(recordList, agscheduler.Record{
-			Id:      rs.ID,
-			JobId:   rs.JobId,
-			JobName: rs.JobName,
-			Status:  rs.Status,
-			Result:  rs.Result,
-			StartAt: rs.StartAt,
-			EndAt:   rs.EndAt,
+			Id:       rs.ID,
+			JobId:    rs.JobId,
+			JobName:  rs.JobName,
+			Status:   rs.Status,
+			Result:   rs.Result,
+			StartAt:  rs.StartAt,
+			EndAt:    rs.EndAt,
+			Attempt:  rs.Attempt,
+			Progress: progress,
+
+			SoftTimedOut: rs.SoftTimedOut,
 		})
 	}
 
@@ -13,6 +13,8 @@ import (
 // Cluster mode is not supported.
 type MemoryBackend struct {
 	records []agscheduler.Record
+	// Logs by record id.
+	logs map[uint64]string
 }
 
 func (b *MemoryBackend) Name() string {
@@ -41,6 +43,47 @@ func (b *MemoryBackend) RecordResult(id uint64, status string, result string) er
 	return nil
 }
 
+func (b *MemoryBackend) RecordProgress(id uint64, p agscheduler.Progress) error {
+	for i, r := range b.records {
+		if r.Id == id {
+			b.records[i].Progress = p
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (b *MemoryBackend) RecordSoftTimeout(id uint64) error {
+	for i, r := range b.records {
+		if r.Id == id {
+			b.records[i].SoftTimedOut = true
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (b *MemoryBackend) RecordLogs(id uint64, logs string) error {
+	if b.logs == nil {
+		b.logs = make(map[uint64]string)
+	}
+	b.logs[id] = logs
+
+	return nil
+}
+
+func (b *MemoryBackend) GetRecordLogs(id uint64) (string, error) {
+	for _, r := range b.records {
+		if r.Id == id {
+			return b.logs[id], nil
+		}
+	}
+
+	return "", agscheduler.RecordNotFoundError(id)
+}
+
 func (b *MemoryBackend) GetRecords(jId string, page, pageSize int) ([]agscheduler.Record, int64, error) {
 	rs := []agscheduler.Record{}
 	for _, r := range b.records {
@@ -73,6 +116,8 @@ func (b *MemoryBackend) DeleteRecords(jId string) error {
 		if r.JobId != jId {
 			b.records[j] = r
 			j++
+		} else {
+			delete(b.logs, r.Id)
 		}
 	}
 	b.records = b.records[:j]
@@ -82,6 +127,7 @@ func (b *MemoryBackend) DeleteRecords(jId string) error {
 
 func (b *MemoryBackend) DeleteAllRecords() error {
 	b.records = nil
+	b.logs = nil
 	return nil
 }
 
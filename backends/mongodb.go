@@ -1,6 +1,7 @@
 package backends
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -66,6 +67,7 @@ func (b *MongoDBBackend) RecordMetadata(r agscheduler.Record) error {
 			"result":   r.Result,
 			"start_at": r.StartAt.Unix(),
 			"end_at":   r.StartAt.Unix(),
+			"attempt":  int64(r.Attempt),
 		},
 	)
 
@@ -88,10 +90,69 @@ func (b *MongoDBBackend) RecordResult(id uint64, status string, result string) e
 	return err
 }
 
+func (b *MongoDBBackend) RecordProgress(id uint64, p agscheduler.Progress) error {
+	progress, err := agscheduler.ProgressMarshal(p)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.coll.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{
+				"progress": progress,
+			},
+		},
+	)
+
+	return err
+}
+
+func (b *MongoDBBackend) RecordSoftTimeout(id uint64) error {
+	_, err := b.coll.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{
+				"soft_timed_out": true,
+			},
+		},
+	)
+
+	return err
+}
+
+func (b *MongoDBBackend) RecordLogs(id uint64, logs string) error {
+	_, err := b.coll.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{
+				"logs": logs,
+			},
+		},
+	)
+
+	return err
+}
+
+func (b *MongoDBBackend) GetRecordLogs(id uint64) (string, error) {
+	var result bson.M
+	optsFind := options.FindOne().SetProjection(bson.M{"logs": 1})
+	err := b.coll.FindOne(ctx, bson.M{"_id": id}, optsFind).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", agscheduler.RecordNotFoundError(id)
+	}
+	if err != nil {
+		return "", err
+	}
+	logs, _ := result["logs"].(string)
+
+	return logs, nil
+}
+
 func (b *MongoDBBackend) _getRecords(page, pageSize int, filter any) ([]agscheduler.Record, int64, error) {
 	total := int64(0)
 
-	optsFind := options.Find().SetSort(bson.M{"start_at": -1}).
+	optsFind := options.Find().SetSort(bson.M{"start_at": -1}).SetProjection(bson.M{"logs": 0}).
 		SetLimit(int64(pageSize)).SetSkip(int64((page - 1) * pageSize))
 	cursor, err := b.coll.Find(ctx, filter, optsFind)
 	if err != nil {
@@ -111,14 +172,30 @@ func (b *MongoDBBackend) _getRecords(page, pageSize int, filter any) ([]agschedu
 		if err != nil {
 			return nil, total, err
 		}
+		// Records created before `attempt` was added are the first attempt.
+		attempt, ok := result["attempt"].(int64)
+		if !ok {
+			attempt = 1
+		}
+		softTimedOut, _ := result["soft_timed_out"].(bool)
+		// Records without progress are the zero Progress.
+		sProgress, _ := result["progress"].(string)
+		progress, err := agscheduler.ProgressUnmarshal(sProgress)
+		if err != nil {
+			return nil, total, err
+		}
 		recordList = append(recordList, agscheduler.Record{
-			Id:      uint64(result["_id"].(int64)),
-			JobId:   result["job_id"].(string),
-			JobName: result["job_name"].(string),
-			Status:  result["status"].(string),
-			Result:  result["result"].(string),
-			StartAt: time.Unix(result["start_at"].(int64), 0),
-			EndAt:   time.Unix(result["end_at"].(int64), 0),
+			Id:       uint64(result["_id"].(int64)),
+			JobId:    result["job_id"].(string),
+			JobName:  result["job_name"].(string),
+			Status:   result["status"].(string),
+			Result:   result["result"].(string),
+			StartAt:  time.Unix(result["start_at"].(int64), 0),
+			EndAt:    time.Unix(result["end_at"].(int64), 0),
+			Attempt:  int(attempt),
+			Progress: progress,
+
+			SoftTimedOut: softTimedOut,
 		})
 	}
 
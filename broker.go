@@ -24,6 +24,8 @@ type Broker struct {
 type QueuePkg struct {
 	Queue Queue
 	// Number of workers.
+	// A worker is held by the run it pulls until the attempt ends,
+	// including the wait for a token of `Job.RateLimiter` and the retries to acquire the lease.
 	// Default: `2`
 	Workers int
 }
@@ -34,7 +36,7 @@ func (b *Broker) init(ctx context.Context) error {
 	slog.Info("Broker init...")
 
 	slog.Info("Broker worker start.")
-	for _, qPkg := range b.Queues {
+	for name, qPkg := range b.Queues {
 		if err := qPkg.Queue.Init(ctx); err != nil {
 			return err
 		}
@@ -42,7 +44,10 @@ func (b *Broker) init(ctx context.Context) error {
 			qPkg.Workers = 2
 		}
 		for range qPkg.Workers {
-			go b.worker(ctx, qPkg.Queue)
+			go b.worker(ctx, name, qPkg.Queue)
+		}
+		if cq, ok := qPkg.Queue.(CancelQueue); ok {
+			go b.cancelWorker(ctx, cq)
 		}
 	}
 
@@ -50,19 +55,38 @@ func (b *Broker) init(ctx context.Context) error {
 }
 
 // Job worker, receiving jobs from the queue.
-func (b *Broker) worker(ctx context.Context, q Queue) {
+func (b *Broker) worker(ctx context.Context, name string, q Queue) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case bJ := <-q.PullJob():
-			j, err := JobUnmarshal(bJ)
+		case bJr := <-q.PullJob():
+			jr, err := JobRunUnmarshal(bJr)
 			if err != nil {
-				slog.Error(fmt.Sprintf("Job `%s` JobUnmarshal error: `%s`", bJ, err))
+				slog.Error(fmt.Sprintf("Job `%s` JobRunUnmarshal error: `%s`", bJr, err))
 				continue
 			}
 
-			b.scheduler._runJob(j)
+			// Wait for the attempt, so the number of runs of the queue on this node is limited by `Workers`.
+			<-b.scheduler._runJob(jr, name)
+		}
+	}
+}
+
+// Cancel worker, receiving the record ids of the runs to cancel from the queue,
+// the runs not on this scheduler are ignored.
+func (b *Broker) cancelWorker(ctx context.Context, cq CancelQueue) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case recordId, ok := <-cq.PullCancel():
+			if !ok {
+				return
+			}
+			if err := b.scheduler.CancelLocalRun(recordId); err == nil {
+				slog.Info(fmt.Sprintf("Broker cancel run of recordId `%d`.", recordId))
+			}
 		}
 	}
 }
@@ -88,8 +112,35 @@ func (b *Broker) choiceQueue(queues []string) (string, error) {
 	return "", fmt.Errorf("queue not found")
 }
 
-func (b *Broker) pushJob(queue string, bJ []byte) error {
-	return b.Queues[queue].Queue.PushJob(bJ)
+func (b *Broker) pushJob(queue string, bJ []byte, priority int) error {
+	q := b.Queues[queue].Queue
+	if pq, ok := q.(PriorityQueue); ok {
+		return pq.PushPriorityJob(bJ, priority)
+	}
+	if priority != 0 {
+		slog.Warn(fmt.Sprintf("Broker queue `%s` of type `%s` does not support priority, priority `%d` is ignored", queue, q.Name(), priority))
+	}
+
+	return q.PushJob(bJ)
+}
+
+// Push the record id of the run to cancel to the queues that implement `CancelQueue`.
+//
+//	@return false, if no queue implements `CancelQueue`.
+func (b *Broker) pushCancel(recordId uint64) (bool, error) {
+	pushed := false
+	for name, qPkg := range b.Queues {
+		cq, ok := qPkg.Queue.(CancelQueue)
+		if !ok {
+			continue
+		}
+		if err := cq.PushCancel(recordId); err != nil {
+			return pushed, fmt.Errorf("push cancel to queue `%s` error: %s", name, err)
+		}
+		pushed = true
+	}
+
+	return pushed, nil
 }
 
 // func (b *Broker) pullJob(queue string) <-chan []byte {
@@ -71,3 +71,45 @@ func TestQueuesToPbQueuesPtr(t *testing.T) {
 	assert.IsType(t, []*pb.Queue{}, pbQs)
 	assert.Len(t, pbQs, 2)
 }
+
+type priorityQueue struct {
+	Queue
+	priorities []int
+}
+
+func (q *priorityQueue) PushPriorityJob(bJ []byte, priority int) error {
+	q.priorities = append(q.priorities, priority)
+	return nil
+}
+
+type fifoQueue struct {
+	Queue
+	count int
+}
+
+func (q *fifoQueue) Name() string { return "FIFO" }
+
+func (q *fifoQueue) PushJob(bJ []byte) error {
+	q.count++
+	return nil
+}
+
+func TestBrokerPushJobPriority(t *testing.T) {
+	pq := &priorityQueue{}
+	fq := &fifoQueue{}
+	brk := &Broker{
+		Queues: map[string]QueuePkg{
+			"priority": {Queue: pq},
+			"fifo":     {Queue: fq},
+		},
+	}
+
+	err := brk.pushJob("priority", []byte{}, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5}, pq.priorities)
+
+	// The priority is ignored.
+	err = brk.pushJob("fifo", []byte{}, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fq.count)
+}
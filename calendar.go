@@ -0,0 +1,395 @@
+package agscheduler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pb "github.com/agscheduler/agscheduler/services/proto"
+)
+
+// Maximum number of excluded ranges skipped when calculating the next run time.
+const calendarSkipMax = 1000
+
+const calendarDateLayout = time.DateOnly
+
+// A range of dates excluded by the calendar, both ends are included.
+type CalendarRange struct {
+	// e.g. `2023-12-25`
+	Start string `json:"start"`
+	// If empty, it is the same as `Start`.
+	// e.g. `2023-12-26`
+	End string `json:"end"`
+}
+
+// A named set of dates on which the jobs referencing it do not run,
+// the dates are in the `Timezone` of each job.
+type Calendar struct {
+	// The unique identifier of this calendar.
+	Name string `json:"name"`
+	// User defined.
+	Description string `json:"description"`
+	// The excluded dates, such as holidays.
+	Excludes []CalendarRange `json:"excludes"`
+}
+
+// Called when the scheduler run `AddCalendar`.
+func (c *Calendar) check() error {
+	if c.Name == "" {
+		return fmt.Errorf("calendar Name cannot be empty")
+	}
+
+	for i, cr := range c.Excludes {
+		start, err := time.Parse(calendarDateLayout, cr.Start)
+		if err != nil {
+			return fmt.Errorf("calendar `%s` Excludes[%d] Start `%s` error: %s", c.Name, i, cr.Start, err)
+		}
+		if cr.End == "" {
+			continue
+		}
+		end, err := time.Parse(calendarDateLayout, cr.End)
+		if err != nil {
+			return fmt.Errorf("calendar `%s` Excludes[%d] End `%s` error: %s", c.Name, i, cr.End, err)
+		}
+		if end.Before(start) {
+			return fmt.Errorf("calendar `%s` Excludes[%d] End `%s` is before Start `%s`", c.Name, i, cr.End, cr.Start)
+		}
+	}
+
+	return nil
+}
+
+// If the date of `t` is excluded, return the start of the day after the excluded range.
+func (c *Calendar) excludedUntil(t time.Time) (time.Time, bool) {
+	date := t.Format(calendarDateLayout)
+	for _, cr := range c.Excludes {
+		end := cr.End
+		if end == "" {
+			end = cr.Start
+		}
+		// Dates in this layout can be compared as strings.
+		if cr.Start <= date && date <= end {
+			endDate, err := time.ParseInLocation(calendarDateLayout, end, t.Location())
+			if err != nil {
+				continue
+			}
+			return endDate.AddDate(0, 0, 1), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (c Calendar) String() string {
+	return fmt.Sprintf("Calendar{'Name':'%s', 'Description':'%s', 'Excludes':'%v'}", c.Name, c.Description, c.Excludes)
+}
+
+// Serialize Calendar and convert to Bytes
+func CalendarMarshal(c Calendar) ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// Deserialize Bytes and convert to Calendar
+func CalendarUnmarshal(bC []byte) (Calendar, error) {
+	var c Calendar
+	err := json.Unmarshal(bC, &c)
+	if err != nil {
+		return Calendar{}, err
+	}
+	return c, nil
+}
+
+// Load the calendar from a `.json` file in the format of `Calendar`,
+// or from a `.ics` file, where each `VEVENT` is an excluded range.
+// If `name` is not empty, it overrides the name in the file.
+func LoadCalendarFile(name string, path string) (Calendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	var c Calendar
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		c, err = CalendarUnmarshal(data)
+	case ".ics":
+		c, err = ParseCalendarICS(data)
+	default:
+		return Calendar{}, fmt.Errorf("calendar file `%s` format unsupported", path)
+	}
+	if err != nil {
+		return Calendar{}, fmt.Errorf("calendar file `%s` error: %s", path, err)
+	}
+
+	if name != "" {
+		c.Name = name
+	}
+	if c.Name == "" {
+		c.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return c, nil
+}
+
+// Parse the `VEVENT`s of an iCalendar file into excluded ranges,
+// `DTEND` of a whole day event is exclusive.
+// See `https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.1`.
+func ParseCalendarICS(data []byte) (Calendar, error) {
+	// Unfold the long content lines.
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	data = bytes.ReplaceAll(data, []byte("\n "), nil)
+	data = bytes.ReplaceAll(data, []byte("\n\t"), nil)
+
+	c := Calendar{Excludes: []CalendarRange{}}
+	var start, end string
+	inEvent := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		nameParams, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(strings.ToUpper(nameParams), ";")
+
+		switch {
+		case name == "X-WR-CALNAME" && !inEvent:
+			c.Name = value
+		case name == "BEGIN" && strings.ToUpper(value) == "VEVENT":
+			inEvent = true
+			start, end = "", ""
+		case name == "DTSTART" && inEvent:
+			start = value
+		case name == "DTEND" && inEvent:
+			end = value
+			// Whole day events end on the next day.
+			if strings.Contains(params, "VALUE=DATE") || len(value) == len("20060102") {
+				endDate, err := time.Parse("20060102", value[:min(len(value), 8)])
+				if err != nil {
+					return Calendar{}, fmt.Errorf("DTEND `%s` error: %s", value, err)
+				}
+				end = endDate.AddDate(0, 0, -1).Format("20060102")
+			}
+		case name == "END" && strings.ToUpper(value) == "VEVENT":
+			inEvent = false
+			if len(start) < 8 {
+				slog.Warn(fmt.Sprintf("Calendar skip VEVENT without DTSTART `%s`.", start))
+				continue
+			}
+			startDate, err := time.Parse("20060102", start[:8])
+			if err != nil {
+				return Calendar{}, fmt.Errorf("DTSTART `%s` error: %s", start, err)
+			}
+			cr := CalendarRange{Start: startDate.Format(calendarDateLayout)}
+			if len(end) >= 8 {
+				endDate, err := time.Parse("20060102", end[:8])
+				if err != nil {
+					return Calendar{}, fmt.Errorf("DTEND `%s` error: %s", end, err)
+				}
+				if endDate.After(startDate) {
+					cr.End = endDate.Format(calendarDateLayout)
+				}
+			}
+			c.Excludes = append(c.Excludes, cr)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Calendar{}, err
+	}
+
+	return c, nil
+}
+
+// Used to gRPC Protobuf
+func CalendarToPbCalendarPtr(c Calendar) *pb.Calendar {
+	pbCrs := make([]*pb.CalendarRange, 0, len(c.Excludes))
+	for _, cr := range c.Excludes {
+		pbCrs = append(pbCrs, &pb.CalendarRange{Start: cr.Start, End: cr.End})
+	}
+
+	return &pb.Calendar{
+		Name:        c.Name,
+		Description: c.Description,
+		Excludes:    pbCrs,
+	}
+}
+
+// Used to gRPC Protobuf
+func PbCalendarPtrToCalendar(pbC *pb.Calendar) Calendar {
+	crs := make([]CalendarRange, 0, len(pbC.GetExcludes()))
+	for _, pbCr := range pbC.GetExcludes() {
+		crs = append(crs, CalendarRange{Start: pbCr.GetStart(), End: pbCr.GetEnd()})
+	}
+
+	return Calendar{
+		Name:        pbC.GetName(),
+		Description: pbC.GetDescription(),
+		Excludes:    crs,
+	}
+}
+
+// Used to gRPC Protobuf
+func CalendarsToPbCalendarsPtr(cs []Calendar) []*pb.Calendar {
+	pbCs := make([]*pb.Calendar, 0, len(cs))
+	for _, c := range cs {
+		pbCs = append(pbCs, CalendarToPbCalendarPtr(c))
+	}
+
+	return pbCs
+}
+
+// Calculate the next run time after `t`, and skip the dates excluded by the job `Calendar`.
+// The job whose Type is `JOB_TYPE_DATETIME` is not affected by the calendar.
+func (s *Scheduler) calcNextRunTime(j Job, t time.Time) (time.Time, error) {
+	nextRunTime, err := calcNextRunTime(j, t)
+	if err != nil || j.Calendar == "" || strings.ToLower(j.Type) == JOB_TYPE_DATETIME {
+		return nextRunTime, err
+	}
+
+	// The job is paused or run by its upstream jobs.
+	nextRunTimeMax, _ := GetNextRunTimeMax()
+	if !nextRunTime.Before(nextRunTimeMax) {
+		return nextRunTime, nil
+	}
+
+	c, err := s.getCalendar(j.Calendar)
+	if err != nil {
+		return time.Time{}, err
+	}
+	timezone, err := time.LoadLocation(j.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for range calendarSkipMax {
+		excludedUntil, ok := c.excludedUntil(nextRunTime.In(timezone))
+		if !ok {
+			return nextRunTime, nil
+		}
+		nextRunTime, err = calcNextRunTime(j, excludedUntil.Add(-time.Second))
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("job `%s` Calendar `%s` excludes too many run times", j.FullName(), j.Calendar)
+}
+
+func (s *Scheduler) getCalendar(name string) (Calendar, error) {
+	if cs, ok := s.store.(CalendarStore); ok {
+		return cs.GetCalendar(name)
+	}
+
+	s.calendarM.RLock()
+	defer s.calendarM.RUnlock()
+
+	c, ok := s.calendars[name]
+	if !ok {
+		return Calendar{}, CalendarNotFoundError(name)
+	}
+	return c, nil
+}
+
+// Add the calendar, it replaces the calendar with the same name,
+// and the next run times of the jobs referencing it are recalculated.
+// If the store implements `CalendarStore`, the calendar is persisted in it.
+func (s *Scheduler) AddCalendar(c Calendar) (Calendar, error) {
+	if err := c.check(); err != nil {
+		return Calendar{}, err
+	}
+	if c.Excludes == nil {
+		c.Excludes = []CalendarRange{}
+	}
+
+	s.storeM.Lock()
+	defer s.storeM.Unlock()
+
+	slog.Info(fmt.Sprintf("Scheduler add calendar `%s`.", c.Name))
+
+	if cs, ok := s.store.(CalendarStore); ok {
+		if err := cs.AddCalendar(c); err != nil {
+			return Calendar{}, err
+		}
+	} else {
+		s.calendarM.Lock()
+		s.calendars[c.Name] = c
+		s.calendarM.Unlock()
+	}
+
+	js, err := s.store.GetAllJobs()
+	if err != nil {
+		return Calendar{}, err
+	}
+	for _, j := range js {
+		if j.Calendar != c.Name {
+			continue
+		}
+		if _, err := s._updateJob(j); err != nil {
+			slog.Error(fmt.Sprintf("Scheduler update job `%s` with calendar `%s` error: %s", j.FullName(), c.Name, err))
+		}
+	}
+
+	return c, nil
+}
+
+func (s *Scheduler) GetCalendar(name string) (Calendar, error) {
+	s.storeM.RLock()
+	defer s.storeM.RUnlock()
+
+	return s.getCalendar(name)
+}
+
+func (s *Scheduler) GetAllCalendars() ([]Calendar, error) {
+	s.storeM.RLock()
+	defer s.storeM.RUnlock()
+
+	if cs, ok := s.store.(CalendarStore); ok {
+		return cs.GetAllCalendars()
+	}
+
+	s.calendarM.RLock()
+	defer s.calendarM.RUnlock()
+
+	calendars := make([]Calendar, 0, len(s.calendars))
+	for _, c := range s.calendars {
+		calendars = append(calendars, c)
+	}
+	return calendars, nil
+}
+
+// The calendar cannot be deleted while jobs reference it.
+func (s *Scheduler) DeleteCalendar(name string) error {
+	s.storeM.Lock()
+	defer s.storeM.Unlock()
+
+	slog.Info(fmt.Sprintf("Scheduler delete calendar `%s`.", name))
+
+	if _, err := s.getCalendar(name); err != nil {
+		return err
+	}
+
+	js, err := s.store.GetAllJobs()
+	if err != nil {
+		return err
+	}
+	for _, j := range js {
+		if j.Calendar == name {
+			return fmt.Errorf("calendar `%s` is used by job `%s`", name, j.FullName())
+		}
+	}
+
+	if cs, ok := s.store.(CalendarStore); ok {
+		return cs.DeleteCalendar(name)
+	}
+
+	s.calendarM.Lock()
+	defer s.calendarM.Unlock()
+
+	delete(s.calendars, name)
+	return nil
+}
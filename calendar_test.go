@@ -0,0 +1,89 @@
+package agscheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarCheck(t *testing.T) {
+	c := Calendar{Name: "holidays", Excludes: []CalendarRange{{Start: "2023-12-25", End: "2023-12-26"}}}
+	assert.NoError(t, c.check())
+
+	for _, c := range []Calendar{
+		{Name: ""},
+		{Name: "holidays", Excludes: []CalendarRange{{Start: "2023/12/25"}}},
+		{Name: "holidays", Excludes: []CalendarRange{{Start: "2023-12-25", End: "2023-12-24"}}},
+	} {
+		assert.Error(t, c.check())
+	}
+}
+
+func TestCalendarExcludedUntil(t *testing.T) {
+	c := Calendar{Name: "holidays", Excludes: []CalendarRange{
+		{Start: "2023-12-25", End: "2023-12-26"},
+		{Start: "2024-01-01"},
+	}}
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+
+	until, ok := c.excludedUntil(time.Date(2023, 12, 26, 9, 0, 0, 0, loc))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 12, 27, 0, 0, 0, 0, loc), until)
+
+	until, ok = c.excludedUntil(time.Date(2024, 1, 1, 23, 59, 59, 0, loc))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, loc), until)
+
+	_, ok = c.excludedUntil(time.Date(2023, 12, 27, 0, 0, 0, 0, loc))
+	assert.False(t, ok)
+}
+
+func TestParseCalendarICS(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"X-WR-CALNAME:holidays\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20231225\r\n" +
+		"DTEND;VALUE=DATE:20231227\r\n" +
+		"SUMMARY:Christmas\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20240101\r\n" +
+		"DTEND;VALUE=DATE:20240102\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	c, err := ParseCalendarICS([]byte(ics))
+	assert.NoError(t, err)
+	assert.Equal(t, "holidays", c.Name)
+	assert.Equal(t, []CalendarRange{
+		{Start: "2023-12-25", End: "2023-12-26"},
+		{Start: "2024-01-01"},
+	}, c.Excludes)
+}
+
+func TestLoadCalendarFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "holidays.json")
+	err := os.WriteFile(path, []byte(`{"excludes":[{"start":"2023-12-25"}]}`), 0o644)
+	assert.NoError(t, err)
+	c, err := LoadCalendarFile("", path)
+	assert.NoError(t, err)
+	assert.Equal(t, "holidays", c.Name)
+	assert.Len(t, c.Excludes, 1)
+
+	path = filepath.Join(dir, "holidays.ics")
+	err = os.WriteFile(path, []byte("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20231225\nEND:VEVENT\n"), 0o644)
+	assert.NoError(t, err)
+	c, err = LoadCalendarFile("exchange", path)
+	assert.NoError(t, err)
+	assert.Equal(t, "exchange", c.Name)
+	assert.Equal(t, []CalendarRange{{Start: "2023-12-25"}}, c.Excludes)
+
+	_, err = LoadCalendarFile("", filepath.Join(dir, "holidays.txt"))
+	assert.Error(t, err)
+}
@@ -442,3 +442,145 @@ func (cn *ClusterNode) heartbeatRemote(ctx context.Context) error {
 
 	return nil
 }
+
+// Used for worker node
+//
+// Report the completion of a job run to the main node.
+func (cn *ClusterNode) completeJobRemote(r JobResult) error {
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+
+	rClient, err := rpc.DialHTTP("tcp", cn.GetEndpointMain())
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster main node: `%s`, error: %s", cn.GetEndpointMain(), err)
+	}
+	defer func() {
+		_ = rClient.Close()
+	}()
+
+	var reply any
+	ch := make(chan error, 1)
+	go func() { ch <- rClient.Call("CRPCService.CompleteJob", r, &reply) }()
+	select {
+	case err := <-ch:
+		if err != nil {
+			return fmt.Errorf("failed to complete job to cluster main node, error: %s", err)
+		}
+	case <-time.After(3 * time.Second):
+		return fmt.Errorf("complete job to cluster main node `%s` timeout", cn.GetEndpointMain())
+	}
+
+	return nil
+}
+
+// RPC API
+func (cn *ClusterNode) RPCRunJob(args JobRun) error {
+	slog.Info(fmt.Sprintf("Cluster node run job `%s`.", args.Job.FullName()))
+
+	return cn.Scheduler.runJob(args)
+}
+
+// RPC API
+func (cn *ClusterNode) RPCAcquireLease(args Lease, reply *bool) {
+	*reply = cn.Scheduler.leases.acquire(args)
+}
+
+// RPC API
+func (cn *ClusterNode) RPCRenewLease(args Lease) {
+	cn.Scheduler.leases.renew(args)
+}
+
+// RPC API
+func (cn *ClusterNode) RPCReleaseLease(args Lease) {
+	cn.Scheduler.leases.release(args)
+}
+
+// Used for worker node
+//
+// Acquire a lease from the main node, when the store does not implement `LeaseStore`.
+func (cn *ClusterNode) acquireLeaseRemote(l Lease) (bool, error) {
+	rClient, err := rpc.DialHTTP("tcp", cn.GetEndpointMain())
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to cluster main node: `%s`, error: %s", cn.GetEndpointMain(), err)
+	}
+	defer func() {
+		_ = rClient.Close()
+	}()
+
+	var ok bool
+	ch := make(chan error, 1)
+	go func() { ch <- rClient.Call("CRPCService.AcquireLease", l, &ok) }()
+	select {
+	case err := <-ch:
+		if err != nil {
+			return false, fmt.Errorf("failed to acquire lease from cluster main node, error: %s", err)
+		}
+	case <-time.After(3 * time.Second):
+		return false, fmt.Errorf("acquire lease from cluster main node `%s` timeout", cn.GetEndpointMain())
+	}
+
+	return ok, nil
+}
+
+// Used for worker node
+//
+// Renew or release a lease on the main node.
+func (cn *ClusterNode) leaseRemote(serviceMethod string, l Lease) error {
+	rClient, err := rpc.DialHTTP("tcp", cn.GetEndpointMain())
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster main node: `%s`, error: %s", cn.GetEndpointMain(), err)
+	}
+	defer func() {
+		_ = rClient.Close()
+	}()
+
+	var reply any
+	ch := make(chan error, 1)
+	go func() { ch <- rClient.Call(serviceMethod, l, &reply) }()
+	select {
+	case err := <-ch:
+		if err != nil {
+			return fmt.Errorf("failed to call `%s` on cluster main node, error: %s", serviceMethod, err)
+		}
+	case <-time.After(3 * time.Second):
+		return fmt.Errorf("call `%s` on cluster main node `%s` timeout", serviceMethod, cn.GetEndpointMain())
+	}
+
+	return nil
+}
+
+// Ask the other nodes to cancel the run, only the node running it succeeds.
+func (cn *ClusterNode) cancelRunRemote(recordId uint64) error {
+	for endpoint := range cn.NodeMapCopy() {
+		if endpoint == cn.Endpoint {
+			continue
+		}
+		if err := cn.cancelRunOnNode(endpoint, recordId); err != nil {
+			slog.Debug(fmt.Sprintf("Cancel run on cluster node `%s` error: %s", endpoint, err))
+			continue
+		}
+		return nil
+	}
+
+	return RunNotFoundError(recordId)
+}
+
+func (cn *ClusterNode) cancelRunOnNode(endpoint string, recordId uint64) error {
+	rClient, err := rpc.DialHTTP("tcp", endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster node: `%s`, error: %s", endpoint, err)
+	}
+	defer func() {
+		_ = rClient.Close()
+	}()
+
+	var reply any
+	ch := make(chan error, 1)
+	go func() { ch <- rClient.Call("CRPCService.CancelRun", recordId, &reply) }()
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(3 * time.Second):
+		return fmt.Errorf("cancel run on cluster node `%s` timeout", endpoint)
+	}
+}
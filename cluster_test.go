@@ -210,6 +210,23 @@ func TestClusterRPCHeartbeat(t *testing.T) {
 	assert.Len(t, cn.NodeMapCopy(), 1)
 }
 
+func TestClusterRPCLease(t *testing.T) {
+	cn := getClusterNode()
+	cn.Scheduler = &Scheduler{leases: newLeaseTable()}
+
+	l := Lease{JobId: "1", LeaseId: "1", Max: 1, TTL: time.Minute}
+	var ok bool
+	cn.RPCAcquireLease(l, &ok)
+	assert.True(t, ok)
+	cn.RPCAcquireLease(Lease{JobId: "1", LeaseId: "2", Max: 1, TTL: time.Minute}, &ok)
+	assert.False(t, ok)
+
+	cn.RPCRenewLease(l)
+	cn.RPCReleaseLease(l)
+	cn.RPCAcquireLease(Lease{JobId: "1", LeaseId: "2", Max: 1, TTL: time.Minute}, &ok)
+	assert.True(t, ok)
+}
+
 func TestClusterRegisterNodeRemote(t *testing.T) {
 	gob.Register(time.Time{})
 
@@ -233,3 +250,12 @@ func TestClusterHeartbeatRemote(t *testing.T) {
 	err := cn.heartbeatRemote(context.TODO())
 	assert.NoError(t, err)
 }
+
+func TestClusterCancelRunRemote(t *testing.T) {
+	cn := getClusterNode()
+	cn.registerNode(&ClusterNode{Endpoint: "127.0.0.1:36680", Queue: "default", Mode: "HA"})
+	cn.registerNode(cn)
+
+	err := cn.cancelRunRemote(1)
+	assert.ErrorIs(t, err, RunNotFoundError(1))
+}
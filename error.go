@@ -1,9 +1,49 @@
 package agscheduler
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// The cause of the context passed to `Func` when the run is cancelled by `CancelRun`.
+var errRunCancelled = errors.New("run cancelled")
+
+// The cause of the pending retry, the rate limiter wait or the lease retry when it is interrupted by `Stop`.
+var errRetryCancelled = errors.New("retry cancelled")
+
+// Returned by `CancelRun` when the scheduler has no recorder, the runs are identified by their record ids.
+var ErrNoRecorder = errors.New("scheduler has no recorder")
+
+// Returned by `ReportProgress` and `Heartbeat` when the context is not passed to `Func`.
+var ErrNotInRun = errors.New("context is not from a job run")
 
 type JobNotFoundError string
 type FuncUnregisteredError string
+type JobEndedError string
+type WorkflowRunNotFoundError string
+type CalendarNotFoundError string
+type RunNotFoundError uint64
+type RecordNotFoundError uint64
+type RateLimiterNotFoundError string
+
+// Returned by `TypedFunc` to mark the run as `RECORD_STATUS_SKIPPED`, e.g. there is nothing to do.
+type SkipError string
+
+// Returned by `TypedFunc` to mark the run as `RECORD_STATUS_ERROR` without retrying.
+type NonRetryableError struct {
+	Err error
+}
+
+// Returned by `RunShell` when the command fails, the message is the JSON of the result.
+type ShellError struct {
+	Result ShellResult
+}
+
+// Returned by `RunWebhook` when the request fails, the message is the JSON of the result.
+type WebhookError struct {
+	Result WebhookResult
+}
 
 type JobTimeoutError struct {
 	FullName string
@@ -19,6 +59,52 @@ func (e FuncUnregisteredError) Error() string {
 	return fmt.Sprintf("function `%s` unregistered!", string(e))
 }
 
+func (e JobEndedError) Error() string {
+	return fmt.Sprintf("job `%s` has ended!", string(e))
+}
+
+func (e WorkflowRunNotFoundError) Error() string {
+	return fmt.Sprintf("workflowRunId `%s` not found!", string(e))
+}
+
+func (e CalendarNotFoundError) Error() string {
+	return fmt.Sprintf("calendar `%s` not found!", string(e))
+}
+
+func (e RunNotFoundError) Error() string {
+	return fmt.Sprintf("run of recordId `%d` not found!", uint64(e))
+}
+
+func (e RecordNotFoundError) Error() string {
+	return fmt.Sprintf("recordId `%d` not found!", uint64(e))
+}
+
+func (e RateLimiterNotFoundError) Error() string {
+	return fmt.Sprintf("rate limiter `%s` not found!", string(e))
+}
+
+func (e SkipError) Error() string {
+	return fmt.Sprintf("skipped: %s", string(e))
+}
+
+func (e *NonRetryableError) Error() string {
+	return fmt.Sprintf("non-retryable error: %s", e.Err)
+}
+
+func (e *NonRetryableError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ShellError) Error() string {
+	bR, _ := json.Marshal(e.Result)
+	return string(bR)
+}
+
+func (e *WebhookError) Error() string {
+	bR, _ := json.Marshal(e.Result)
+	return string(bR)
+}
+
 func (e *JobTimeoutError) Error() string {
 	return fmt.Sprintf("job `%s` Timeout `%s` error: %s!", e.FullName, e.Timeout, e.Err)
 }
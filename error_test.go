@@ -19,6 +19,68 @@ func TestFuncUnregisteredError(t *testing.T) {
 	assert.Equal(t, "function `func` unregistered!", err.Error())
 }
 
+func TestJobEndedError(t *testing.T) {
+	err := JobEndedError("1:job")
+
+	assert.Equal(t, "job `1:job` has ended!", err.Error())
+}
+
+func TestWorkflowRunNotFoundError(t *testing.T) {
+	err := WorkflowRunNotFoundError("1")
+
+	assert.Equal(t, "workflowRunId `1` not found!", err.Error())
+}
+
+func TestCalendarNotFoundError(t *testing.T) {
+	err := CalendarNotFoundError("1")
+
+	assert.Equal(t, "calendar `1` not found!", err.Error())
+}
+
+func TestRunNotFoundError(t *testing.T) {
+	err := RunNotFoundError(1)
+
+	assert.Equal(t, "run of recordId `1` not found!", err.Error())
+}
+
+func TestRecordNotFoundError(t *testing.T) {
+	err := RecordNotFoundError(1)
+
+	assert.Equal(t, "recordId `1` not found!", err.Error())
+}
+
+func TestRateLimiterNotFoundError(t *testing.T) {
+	err := RateLimiterNotFoundError("api")
+
+	assert.Equal(t, "rate limiter `api` not found!", err.Error())
+}
+
+func TestSkipError(t *testing.T) {
+	err := SkipError("nothing to do")
+
+	assert.Equal(t, "skipped: nothing to do", err.Error())
+}
+
+func TestNonRetryableError(t *testing.T) {
+	errBase := errors.New("err")
+	err := &NonRetryableError{Err: errBase}
+
+	assert.Equal(t, "non-retryable error: err", err.Error())
+	assert.ErrorIs(t, err, errBase)
+}
+
+func TestShellError(t *testing.T) {
+	err := &ShellError{Result: ShellResult{ExitCode: 1, Stdout: "out"}}
+
+	assert.JSONEq(t, `{"exit_code":1,"stdout":"out","stderr":""}`, err.Error())
+}
+
+func TestWebhookError(t *testing.T) {
+	err := &WebhookError{Result: WebhookResult{StatusCode: 404, Error: "err"}}
+
+	assert.JSONEq(t, `{"status_code":404,"body":"","error":"err"}`, err.Error())
+}
+
 func TestJobTimeoutError(t *testing.T) {
 	err := &JobTimeoutError{FullName: "1:job", Timeout: "1s", Err: errors.New("err")}
 
@@ -15,12 +15,18 @@ class InfoResp(_message.Message):
     def __init__(self, info: _Optional[_Union[_struct_pb2.Struct, _Mapping]] = ...) -> None: ...
 
 class Func(_message.Message):
-    __slots__ = ("name", "info")
+    __slots__ = ("name", "info", "args_schema", "version", "aliases")
     NAME_FIELD_NUMBER: _ClassVar[int]
     INFO_FIELD_NUMBER: _ClassVar[int]
+    ARGS_SCHEMA_FIELD_NUMBER: _ClassVar[int]
+    VERSION_FIELD_NUMBER: _ClassVar[int]
+    ALIASES_FIELD_NUMBER: _ClassVar[int]
     name: str
     info: str
-    def __init__(self, name: _Optional[str] = ..., info: _Optional[str] = ...) -> None: ...
+    args_schema: str
+    version: str
+    aliases: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, name: _Optional[str] = ..., info: _Optional[str] = ..., args_schema: _Optional[str] = ..., version: _Optional[str] = ..., aliases: _Optional[_Iterable[str]] = ...) -> None: ...
 
 class FuncsResp(_message.Message):
     __slots__ = ("funcs",)
@@ -1,6 +1,7 @@
 import datetime
 
 from google.protobuf import empty_pb2 as _empty_pb2
+from google.protobuf import struct_pb2 as _struct_pb2
 from google.protobuf import timestamp_pb2 as _timestamp_pb2
 import scheduler_pb2 as _scheduler_pb2
 from google.protobuf.internal import containers as _containers
@@ -30,7 +31,7 @@ class RecordsAllReq(_message.Message):
     def __init__(self, page: _Optional[int] = ..., page_size: _Optional[int] = ...) -> None: ...
 
 class Record(_message.Message):
-    __slots__ = ("id", "job_id", "job_name", "status", "result", "start_at", "end_at")
+    __slots__ = ("id", "job_id", "job_name", "status", "result", "start_at", "end_at", "attempt", "progress", "soft_timed_out")
     ID_FIELD_NUMBER: _ClassVar[int]
     JOB_ID_FIELD_NUMBER: _ClassVar[int]
     JOB_NAME_FIELD_NUMBER: _ClassVar[int]
@@ -38,6 +39,9 @@ class Record(_message.Message):
     RESULT_FIELD_NUMBER: _ClassVar[int]
     START_AT_FIELD_NUMBER: _ClassVar[int]
     END_AT_FIELD_NUMBER: _ClassVar[int]
+    ATTEMPT_FIELD_NUMBER: _ClassVar[int]
+    PROGRESS_FIELD_NUMBER: _ClassVar[int]
+    SOFT_TIMED_OUT_FIELD_NUMBER: _ClassVar[int]
     id: int
     job_id: str
     job_name: str
@@ -45,7 +49,38 @@ class Record(_message.Message):
     result: str
     start_at: _timestamp_pb2.Timestamp
     end_at: _timestamp_pb2.Timestamp
-    def __init__(self, id: _Optional[int] = ..., job_id: _Optional[str] = ..., job_name: _Optional[str] = ..., status: _Optional[str] = ..., result: _Optional[str] = ..., start_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., end_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+    attempt: int
+    progress: Progress
+    soft_timed_out: bool
+    def __init__(self, id: _Optional[int] = ..., job_id: _Optional[str] = ..., job_name: _Optional[str] = ..., status: _Optional[str] = ..., result: _Optional[str] = ..., start_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., end_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., attempt: _Optional[int] = ..., progress: _Optional[_Union[Progress, _Mapping]] = ..., soft_timed_out: bool = ...) -> None: ...
+
+class Progress(_message.Message):
+    __slots__ = ("percent", "message", "fields", "heartbeat_at", "stalled")
+    PERCENT_FIELD_NUMBER: _ClassVar[int]
+    MESSAGE_FIELD_NUMBER: _ClassVar[int]
+    FIELDS_FIELD_NUMBER: _ClassVar[int]
+    HEARTBEAT_AT_FIELD_NUMBER: _ClassVar[int]
+    STALLED_FIELD_NUMBER: _ClassVar[int]
+    percent: float
+    message: str
+    fields: _struct_pb2.Struct
+    heartbeat_at: _timestamp_pb2.Timestamp
+    stalled: bool
+    def __init__(self, percent: _Optional[float] = ..., message: _Optional[str] = ..., fields: _Optional[_Union[_struct_pb2.Struct, _Mapping]] = ..., heartbeat_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., stalled: bool = ...) -> None: ...
+
+class RecordLogsReq(_message.Message):
+    __slots__ = ("id", "tail")
+    ID_FIELD_NUMBER: _ClassVar[int]
+    TAIL_FIELD_NUMBER: _ClassVar[int]
+    id: int
+    tail: int
+    def __init__(self, id: _Optional[int] = ..., tail: _Optional[int] = ...) -> None: ...
+
+class RecordLogsResp(_message.Message):
+    __slots__ = ("logs",)
+    LOGS_FIELD_NUMBER: _ClassVar[int]
+    logs: str
+    def __init__(self, logs: _Optional[str] = ...) -> None: ...
 
 class RecordsResp(_message.Message):
     __slots__ = ("records", "page", "page_size", "total")
@@ -46,6 +46,11 @@ class RecorderStub(object):
                 request_serializer=recorder__pb2.RecordsAllReq.SerializeToString,
                 response_deserializer=recorder__pb2.RecordsResp.FromString,
                 _registered_method=True)
+        self.GetRecordLogs = channel.unary_unary(
+                '/services.Recorder/GetRecordLogs',
+                request_serializer=recorder__pb2.RecordLogsReq.SerializeToString,
+                response_deserializer=recorder__pb2.RecordLogsResp.FromString,
+                _registered_method=True)
         self.DeleteRecords = channel.unary_unary(
                 '/services.Recorder/DeleteRecords',
                 request_serializer=scheduler__pb2.JobReq.SerializeToString,
@@ -73,6 +78,12 @@ class RecorderServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def GetRecordLogs(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def DeleteRecords(self, request, context):
         """Missing associated documentation comment in .proto file."""
         context.set_code(grpc.StatusCode.UNIMPLEMENTED)
@@ -98,6 +109,11 @@ def add_RecorderServicer_to_server(servicer, server):
                     request_deserializer=recorder__pb2.RecordsAllReq.FromString,
                     response_serializer=recorder__pb2.RecordsResp.SerializeToString,
             ),
+            'GetRecordLogs': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetRecordLogs,
+                    request_deserializer=recorder__pb2.RecordLogsReq.FromString,
+                    response_serializer=recorder__pb2.RecordLogsResp.SerializeToString,
+            ),
             'DeleteRecords': grpc.unary_unary_rpc_method_handler(
                     servicer.DeleteRecords,
                     request_deserializer=scheduler__pb2.JobReq.FromString,
@@ -173,6 +189,33 @@ class Recorder(object):
             metadata,
             _registered_method=True)
 
+    @staticmethod
+    def GetRecordLogs(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/services.Recorder/GetRecordLogs',
+            recorder__pb2.RecordLogsReq.SerializeToString,
+            recorder__pb2.RecordLogsResp.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
     @staticmethod
     def DeleteRecords(request,
             target,
@@ -27,7 +27,7 @@ from google.protobuf import struct_pb2 as google_dot_protobuf_dot_struct__pb2
 from google.protobuf import timestamp_pb2 as google_dot_protobuf_dot_timestamp__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0fscheduler.proto\x12\x08services\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x14\n\x06JobReq\x12\n\n\x02id\x18\x01 \x01(\t\"\xee\x02\n\x03Job\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0c\n\x04type\x18\x03 \x01(\t\x12\x10\n\x08start_at\x18\x04 \x01(\t\x12\x0e\n\x06\x65nd_at\x18\x05 \x01(\t\x12\x10\n\x08interval\x18\x06 \x01(\t\x12\x11\n\tcron_expr\x18\x07 \x01(\t\x12\x10\n\x08timezone\x18\x08 \x01(\t\x12\x11\n\tfunc_name\x18\t \x01(\t\x12%\n\x04\x61rgs\x18\n \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0f\n\x07timeout\x18\x0b \x01(\t\x12\x0e\n\x06queues\x18\x0c \x03(\t\x12\x15\n\rmax_instances\x18\r \x01(\x05\x12\x31\n\rlast_run_time\x18\x0e \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x31\n\rnext_run_time\x18\x0f \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06status\x18\x10 \x01(\t\"\'\n\x08JobsResp\x12\x1b\n\x04jobs\x18\x01 \x03(\x0b\x32\r.services.Job2\x86\x05\n\tScheduler\x12(\n\x06\x41\x64\x64Job\x12\r.services.Job\x1a\r.services.Job\"\x00\x12+\n\x06GetJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x12:\n\nGetAllJobs\x12\x16.google.protobuf.Empty\x1a\x12.services.JobsResp\"\x00\x12+\n\tUpdateJob\x12\r.services.Job\x1a\r.services.Job\"\x00\x12\x37\n\tDeleteJob\x12\x10.services.JobReq\x1a\x16.google.protobuf.Empty\"\x00\x12\x41\n\rDeleteAllJobs\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x12-\n\x08PauseJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x12.\n\tResumeJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x12\x31\n\x06RunJob\x12\r.services.Job\x1a\x16.google.protobuf.Empty\"\x00\x12\x36\n\x0bScheduleJob\x12\r.services.Job\x1a\x16.google.protobuf.Empty\"\x00\x12\x39\n\x05Start\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x12\x38\n\x04Stop\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x42\rZ\x0b./;servicesb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0fscheduler.proto\x12\x08services\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x14\n\x06JobReq\x12\n\n\x02id\x18\x01 \x01(\t\"]\n\x07Trigger\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x10\n\x08start_at\x18\x02 \x01(\t\x12\x10\n\x08interval\x18\x03 \x01(\t\x12\x11\n\tcron_expr\x18\x04 \x01(\t\x12\r\n\x05rrule\x18\x05 \x01(\t\"\xe4\x06\n\x03Job\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0c\n\x04type\x18\x03 \x01(\t\x12\x10\n\x08start_at\x18\x04 \x01(\t\x12\x0e\n\x06\x65nd_at\x18\x05 \x01(\t\x12\x10\n\x08interval\x18\x06 \x01(\t\x12\x15\n\rinterval_mode\x18\x13 \x01(\t\x12\x11\n\tcron_expr\x18\x07 \x01(\t\x12\r\n\x05rrule\x18\x1d \x01(\t\x12#\n\x08triggers\x18\x1b \x03(\x0b\x32\x11.services.Trigger\x12\x14\n\x0ctrigger_mode\x18\x1c \x01(\t\x12\x10\n\x08\x63\x61lendar\x18\x1e \x01(\t\x12\x10\n\x08timezone\x18\x08 \x01(\t\x12\x11\n\tfunc_name\x18\t \x01(\t\x12%\n\x04\x61rgs\x18\n \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0f\n\x07timeout\x18\x0b \x01(\t\x12\x0e\n\x06queues\x18\x0c \x03(\t\x12\x15\n\rmax_instances\x18\r \x01(\x05\x12\x1a\n\x12misfire_grace_time\x18\x11 \x01(\t\x12\x15\n\x08\x63oalesce\x18\x12 \x01(\x08H\x00\x88\x01\x01\x12\x14\n\x0cmax_attempts\x18\x14 \x01(\x05\x12\x15\n\rretry_backoff\x18\x15 \x01(\t\x12\x19\n\x11retry_backoff_max\x18& \x01(\t\x12\x18\n\x10retry_on_timeout\x18\x16 \x01(\x08\x12\x11\n\tupstreams\x18\x17 \x03(\t\x12\x17\n\x0fworkflow_run_id\x18\x18 \x01(\t\x12\x19\n\x11heartbeat_timeout\x18  \x01(\t\x12\x14\n\x0csoft_timeout\x18! \x01(\t\x12\x0b\n\x03sla\x18\" \x01(\t\x12\x10\n\x08priority\x18# \x01(\x05\x12\x14\n\x0crate_limiter\x18$ \x01(\t\x12\x19\n\x11rate_limit_policy\x18% \x01(\t\x12\x10\n\x08max_runs\x18\x19 \x01(\x05\x12\x0c\n\x04runs\x18\x1a \x01(\x05\x12\x31\n\rlast_run_time\x18\x0e \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x31\n\rnext_run_time\x18\x0f \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06status\x18\x10 \x01(\tB\x0b\n\t_coalesceJ\x04\x08\x1f\x10 R\x0cscheduled_at\"\'\n\x08JobsResp\x12\x1b\n\x04jobs\x18\x01 \x03(\x0b\x32\r.services.Job\"\x1b\n\x0b\x43\x61lendarReq\x12\x0c\n\x04name\x18\x01 \x01(\t\"+\n\rCalendarRange\x12\r\n\x05start\x18\x01 \x01(\t\x12\x0b\n\x03\x65nd\x18\x02 \x01(\t\"X\n\x08\x43\x61lendar\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x02 \x01(\t\x12)\n\x08\x65xcludes\x18\x03 \x03(\x0b\x32\x17.services.CalendarRange\"6\n\rCalendarsResp\x12%\n\tcalendars\x18\x01 \x03(\x0b\x32\x12.services.Calendar\"\x1b\n\x06RunReq\x12\x11\n\trecord_id\x18\x01 \x01(\x04\"C\n\x0cWorkflowStep\x12\x0e\n\x06job_id\x18\x01 \x01(\t\x12\x10\n\x08job_name\x18\x02 \x01(\t\x12\x11\n\tupstreams\x18\x03 \x03(\t\"G\n\x08Workflow\x12\x14\n\x0croot_job_ids\x18\x01 \x03(\t\x12%\n\x05steps\x18\x02 \x03(\x0b\x32\x16.services.WorkflowStep\"6\n\rWorkflowsResp\x12%\n\tworkflows\x18\x01 \x03(\x0b\x32\x12.services.Workflow\"\x1c\n\x0eWorkflowRunReq\x12\n\n\x02id\x18\x01 \x01(\t\"S\n\x0fWorkflowRunStep\x12\x0e\n\x06job_id\x18\x01 \x01(\t\x12\x10\n\x08job_name\x18\x02 \x01(\t\x12\x0e\n\x06status\x18\x03 \x01(\t\x12\x0e\n\x06result\x18\x04 \x01(\t\"\x93\x02\n\x0bWorkflowRun\x12\n\n\x02id\x18\x01 \x01(\t\x12\x14\n\x0croot_job_ids\x18\x02 \x03(\t\x12\x0e\n\x06status\x18\x03 \x01(\t\x12/\n\x05steps\x18\x04 \x03(\x0b\x32 .services.WorkflowRun.StepsEntry\x12,\n\x08start_at\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12*\n\x06\x65nd_at\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x1aG\n\nStepsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12(\n\x05value\x18\x02 \x01(\x0b\x32\x19.services.WorkflowRunStep:\x02\x38\x01\"@\n\x10WorkflowRunsResp\x12,\n\rworkflow_runs\x18\x01 \x03(\x0b\x32\x15.services.WorkflowRun2\x94\t\n\tScheduler\x12(\n\x06\x41\x64\x64Job\x12\r.services.Job\x1a\r.services.Job\"\x00\x12+\n\x06GetJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x12:\n\nGetAllJobs\x12\x16.google.protobuf.Empty\x1a\x12.services.JobsResp\"\x00\x12+\n\tUpdateJob\x12\r.services.Job\x1a\r.services.Job\"\x00\x12\x37\n\tDeleteJob\x12\x10.services.JobReq\x1a\x16.google.protobuf.Empty\"\x00\x12\x41\n\rDeleteAllJobs\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x12-\n\x08PauseJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x12.\n\tResumeJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x12\x31\n\x06RunJob\x12\r.services.Job\x1a\x16.google.protobuf.Empty\"\x00\x12\x36\n\x0bScheduleJob\x12\r.services.Job\x1a\x16.google.protobuf.Empty\"\x00\x12\x37\n\tCancelRun\x12\x10.services.RunReq\x1a\x16.google.protobuf.Empty\"\x00\x12\x44\n\x0fGetAllWorkflows\x12\x16.google.protobuf.Empty\x1a\x17.services.WorkflowsResp\"\x00\x12\x43\n\x0eGetWorkflowRun\x12\x18.services.WorkflowRunReq\x1a\x15.services.WorkflowRun\"\x00\x12J\n\x12GetAllWorkflowRuns\x12\x16.google.protobuf.Empty\x1a\x1a.services.WorkflowRunsResp\"\x00\x12\x37\n\x0b\x41\x64\x64\x43\x61lendar\x12\x12.services.Calendar\x1a\x12.services.Calendar\"\x00\x12:\n\x0bGetCalendar\x12\x15.services.CalendarReq\x1a\x12.services.Calendar\"\x00\x12\x44\n\x0fGetAllCalendars\x12\x16.google.protobuf.Empty\x1a\x17.services.CalendarsResp\"\x00\x12\x41\n\x0e\x44\x65leteCalendar\x12\x15.services.CalendarReq\x1a\x16.google.protobuf.Empty\"\x00\x12\x39\n\x05Start\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x12\x38\n\x04Stop\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x42\rZ\x0b./;servicesb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -35,12 +35,42 @@ _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'scheduler_pb2', _globals)
 if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._loaded_options = None
   _globals['DESCRIPTOR']._serialized_options = b'Z\013./;services'
+  _globals['_WORKFLOWRUN_STEPSENTRY']._loaded_options = None
+  _globals['_WORKFLOWRUN_STEPSENTRY']._serialized_options = b'8\001'
   _globals['_JOBREQ']._serialized_start=121
   _globals['_JOBREQ']._serialized_end=141
-  _globals['_JOB']._serialized_start=144
-  _globals['_JOB']._serialized_end=510
-  _globals['_JOBSRESP']._serialized_start=512
-  _globals['_JOBSRESP']._serialized_end=551
-  _globals['_SCHEDULER']._serialized_start=554
-  _globals['_SCHEDULER']._serialized_end=1200
+  _globals['_TRIGGER']._serialized_start=143
+  _globals['_TRIGGER']._serialized_end=236
+  _globals['_JOB']._serialized_start=239
+  _globals['_JOB']._serialized_end=1107
+  _globals['_JOBSRESP']._serialized_start=1109
+  _globals['_JOBSRESP']._serialized_end=1148
+  _globals['_CALENDARREQ']._serialized_start=1150
+  _globals['_CALENDARREQ']._serialized_end=1177
+  _globals['_CALENDARRANGE']._serialized_start=1179
+  _globals['_CALENDARRANGE']._serialized_end=1222
+  _globals['_CALENDAR']._serialized_start=1224
+  _globals['_CALENDAR']._serialized_end=1312
+  _globals['_CALENDARSRESP']._serialized_start=1314
+  _globals['_CALENDARSRESP']._serialized_end=1368
+  _globals['_RUNREQ']._serialized_start=1370
+  _globals['_RUNREQ']._serialized_end=1397
+  _globals['_WORKFLOWSTEP']._serialized_start=1399
+  _globals['_WORKFLOWSTEP']._serialized_end=1466
+  _globals['_WORKFLOW']._serialized_start=1468
+  _globals['_WORKFLOW']._serialized_end=1539
+  _globals['_WORKFLOWSRESP']._serialized_start=1541
+  _globals['_WORKFLOWSRESP']._serialized_end=1595
+  _globals['_WORKFLOWRUNREQ']._serialized_start=1597
+  _globals['_WORKFLOWRUNREQ']._serialized_end=1625
+  _globals['_WORKFLOWRUNSTEP']._serialized_start=1627
+  _globals['_WORKFLOWRUNSTEP']._serialized_end=1710
+  _globals['_WORKFLOWRUN']._serialized_start=1713
+  _globals['_WORKFLOWRUN']._serialized_end=1988
+  _globals['_WORKFLOWRUN_STEPSENTRY']._serialized_start=1917
+  _globals['_WORKFLOWRUN_STEPSENTRY']._serialized_end=1988
+  _globals['_WORKFLOWRUNSRESP']._serialized_start=1990
+  _globals['_WORKFLOWRUNSRESP']._serialized_end=2054
+  _globals['_SCHEDULER']._serialized_start=2057
+  _globals['_SCHEDULER']._serialized_end=3229
 # @@protoc_insertion_point(module_scope)
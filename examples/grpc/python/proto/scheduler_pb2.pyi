@@ -17,21 +17,56 @@ class JobReq(_message.Message):
     id: str
     def __init__(self, id: _Optional[str] = ...) -> None: ...
 
+class Trigger(_message.Message):
+    __slots__ = ("type", "start_at", "interval", "cron_expr", "rrule")
+    TYPE_FIELD_NUMBER: _ClassVar[int]
+    START_AT_FIELD_NUMBER: _ClassVar[int]
+    INTERVAL_FIELD_NUMBER: _ClassVar[int]
+    CRON_EXPR_FIELD_NUMBER: _ClassVar[int]
+    RRULE_FIELD_NUMBER: _ClassVar[int]
+    type: str
+    start_at: str
+    interval: str
+    cron_expr: str
+    rrule: str
+    def __init__(self, type: _Optional[str] = ..., start_at: _Optional[str] = ..., interval: _Optional[str] = ..., cron_expr: _Optional[str] = ..., rrule: _Optional[str] = ...) -> None: ...
+
 class Job(_message.Message):
-    __slots__ = ("id", "name", "type", "start_at", "end_at", "interval", "cron_expr", "timezone", "func_name", "args", "timeout", "queues", "max_instances", "last_run_time", "next_run_time", "status")
+    __slots__ = ("id", "name", "type", "start_at", "end_at", "interval", "interval_mode", "cron_expr", "rrule", "triggers", "trigger_mode", "calendar", "timezone", "func_name", "args", "timeout", "queues", "max_instances", "misfire_grace_time", "coalesce", "max_attempts", "retry_backoff", "retry_backoff_max", "retry_on_timeout", "upstreams", "workflow_run_id", "heartbeat_timeout", "soft_timeout", "sla", "priority", "rate_limiter", "rate_limit_policy", "max_runs", "runs", "last_run_time", "next_run_time", "status")
     ID_FIELD_NUMBER: _ClassVar[int]
     NAME_FIELD_NUMBER: _ClassVar[int]
     TYPE_FIELD_NUMBER: _ClassVar[int]
     START_AT_FIELD_NUMBER: _ClassVar[int]
     END_AT_FIELD_NUMBER: _ClassVar[int]
     INTERVAL_FIELD_NUMBER: _ClassVar[int]
+    INTERVAL_MODE_FIELD_NUMBER: _ClassVar[int]
     CRON_EXPR_FIELD_NUMBER: _ClassVar[int]
+    RRULE_FIELD_NUMBER: _ClassVar[int]
+    TRIGGERS_FIELD_NUMBER: _ClassVar[int]
+    TRIGGER_MODE_FIELD_NUMBER: _ClassVar[int]
+    CALENDAR_FIELD_NUMBER: _ClassVar[int]
     TIMEZONE_FIELD_NUMBER: _ClassVar[int]
     FUNC_NAME_FIELD_NUMBER: _ClassVar[int]
     ARGS_FIELD_NUMBER: _ClassVar[int]
     TIMEOUT_FIELD_NUMBER: _ClassVar[int]
     QUEUES_FIELD_NUMBER: _ClassVar[int]
     MAX_INSTANCES_FIELD_NUMBER: _ClassVar[int]
+    MISFIRE_GRACE_TIME_FIELD_NUMBER: _ClassVar[int]
+    COALESCE_FIELD_NUMBER: _ClassVar[int]
+    MAX_ATTEMPTS_FIELD_NUMBER: _ClassVar[int]
+    RETRY_BACKOFF_FIELD_NUMBER: _ClassVar[int]
+    RETRY_BACKOFF_MAX_FIELD_NUMBER: _ClassVar[int]
+    RETRY_ON_TIMEOUT_FIELD_NUMBER: _ClassVar[int]
+    UPSTREAMS_FIELD_NUMBER: _ClassVar[int]
+    WORKFLOW_RUN_ID_FIELD_NUMBER: _ClassVar[int]
+    HEARTBEAT_TIMEOUT_FIELD_NUMBER: _ClassVar[int]
+    SOFT_TIMEOUT_FIELD_NUMBER: _ClassVar[int]
+    SLA_FIELD_NUMBER: _ClassVar[int]
+    PRIORITY_FIELD_NUMBER: _ClassVar[int]
+    RATE_LIMITER_FIELD_NUMBER: _ClassVar[int]
+    RATE_LIMIT_POLICY_FIELD_NUMBER: _ClassVar[int]
+    MAX_RUNS_FIELD_NUMBER: _ClassVar[int]
+    RUNS_FIELD_NUMBER: _ClassVar[int]
     LAST_RUN_TIME_FIELD_NUMBER: _ClassVar[int]
     NEXT_RUN_TIME_FIELD_NUMBER: _ClassVar[int]
     STATUS_FIELD_NUMBER: _ClassVar[int]
@@ -41,20 +76,148 @@ class Job(_message.Message):
     start_at: str
     end_at: str
     interval: str
+    interval_mode: str
     cron_expr: str
+    rrule: str
+    triggers: _containers.RepeatedCompositeFieldContainer[Trigger]
+    trigger_mode: str
+    calendar: str
     timezone: str
     func_name: str
     args: _struct_pb2.Struct
     timeout: str
     queues: _containers.RepeatedScalarFieldContainer[str]
     max_instances: int
+    misfire_grace_time: str
+    coalesce: bool
+    max_attempts: int
+    retry_backoff: str
+    retry_backoff_max: str
+    retry_on_timeout: bool
+    upstreams: _containers.RepeatedScalarFieldContainer[str]
+    workflow_run_id: str
+    heartbeat_timeout: str
+    soft_timeout: str
+    sla: str
+    priority: int
+    rate_limiter: str
+    rate_limit_policy: str
+    max_runs: int
+    runs: int
     last_run_time: _timestamp_pb2.Timestamp
     next_run_time: _timestamp_pb2.Timestamp
     status: str
-    def __init__(self, id: _Optional[str] = ..., name: _Optional[str] = ..., type: _Optional[str] = ..., start_at: _Optional[str] = ..., end_at: _Optional[str] = ..., interval: _Optional[str] = ..., cron_expr: _Optional[str] = ..., timezone: _Optional[str] = ..., func_name: _Optional[str] = ..., args: _Optional[_Union[_struct_pb2.Struct, _Mapping]] = ..., timeout: _Optional[str] = ..., queues: _Optional[_Iterable[str]] = ..., max_instances: _Optional[int] = ..., last_run_time: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., next_run_time: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., status: _Optional[str] = ...) -> None: ...
+    def __init__(self, id: _Optional[str] = ..., name: _Optional[str] = ..., type: _Optional[str] = ..., start_at: _Optional[str] = ..., end_at: _Optional[str] = ..., interval: _Optional[str] = ..., interval_mode: _Optional[str] = ..., cron_expr: _Optional[str] = ..., rrule: _Optional[str] = ..., triggers: _Optional[_Iterable[_Union[Trigger, _Mapping]]] = ..., trigger_mode: _Optional[str] = ..., calendar: _Optional[str] = ..., timezone: _Optional[str] = ..., func_name: _Optional[str] = ..., args: _Optional[_Union[_struct_pb2.Struct, _Mapping]] = ..., timeout: _Optional[str] = ..., queues: _Optional[_Iterable[str]] = ..., max_instances: _Optional[int] = ..., misfire_grace_time: _Optional[str] = ..., coalesce: bool = ..., max_attempts: _Optional[int] = ..., retry_backoff: _Optional[str] = ..., retry_backoff_max: _Optional[str] = ..., retry_on_timeout: bool = ..., upstreams: _Optional[_Iterable[str]] = ..., workflow_run_id: _Optional[str] = ..., heartbeat_timeout: _Optional[str] = ..., soft_timeout: _Optional[str] = ..., sla: _Optional[str] = ..., priority: _Optional[int] = ..., rate_limiter: _Optional[str] = ..., rate_limit_policy: _Optional[str] = ..., max_runs: _Optional[int] = ..., runs: _Optional[int] = ..., last_run_time: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., next_run_time: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., status: _Optional[str] = ...) -> None: ...
 
 class JobsResp(_message.Message):
     __slots__ = ("jobs",)
     JOBS_FIELD_NUMBER: _ClassVar[int]
     jobs: _containers.RepeatedCompositeFieldContainer[Job]
     def __init__(self, jobs: _Optional[_Iterable[_Union[Job, _Mapping]]] = ...) -> None: ...
+
+class CalendarReq(_message.Message):
+    __slots__ = ("name",)
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    name: str
+    def __init__(self, name: _Optional[str] = ...) -> None: ...
+
+class CalendarRange(_message.Message):
+    __slots__ = ("start", "end")
+    START_FIELD_NUMBER: _ClassVar[int]
+    END_FIELD_NUMBER: _ClassVar[int]
+    start: str
+    end: str
+    def __init__(self, start: _Optional[str] = ..., end: _Optional[str] = ...) -> None: ...
+
+class Calendar(_message.Message):
+    __slots__ = ("name", "description", "excludes")
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    DESCRIPTION_FIELD_NUMBER: _ClassVar[int]
+    EXCLUDES_FIELD_NUMBER: _ClassVar[int]
+    name: str
+    description: str
+    excludes: _containers.RepeatedCompositeFieldContainer[CalendarRange]
+    def __init__(self, name: _Optional[str] = ..., description: _Optional[str] = ..., excludes: _Optional[_Iterable[_Union[CalendarRange, _Mapping]]] = ...) -> None: ...
+
+class CalendarsResp(_message.Message):
+    __slots__ = ("calendars",)
+    CALENDARS_FIELD_NUMBER: _ClassVar[int]
+    calendars: _containers.RepeatedCompositeFieldContainer[Calendar]
+    def __init__(self, calendars: _Optional[_Iterable[_Union[Calendar, _Mapping]]] = ...) -> None: ...
+
+class RunReq(_message.Message):
+    __slots__ = ("record_id",)
+    RECORD_ID_FIELD_NUMBER: _ClassVar[int]
+    record_id: int
+    def __init__(self, record_id: _Optional[int] = ...) -> None: ...
+
+class WorkflowStep(_message.Message):
+    __slots__ = ("job_id", "job_name", "upstreams")
+    JOB_ID_FIELD_NUMBER: _ClassVar[int]
+    JOB_NAME_FIELD_NUMBER: _ClassVar[int]
+    UPSTREAMS_FIELD_NUMBER: _ClassVar[int]
+    job_id: str
+    job_name: str
+    upstreams: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, job_id: _Optional[str] = ..., job_name: _Optional[str] = ..., upstreams: _Optional[_Iterable[str]] = ...) -> None: ...
+
+class Workflow(_message.Message):
+    __slots__ = ("root_job_ids", "steps")
+    ROOT_JOB_IDS_FIELD_NUMBER: _ClassVar[int]
+    STEPS_FIELD_NUMBER: _ClassVar[int]
+    root_job_ids: _containers.RepeatedScalarFieldContainer[str]
+    steps: _containers.RepeatedCompositeFieldContainer[WorkflowStep]
+    def __init__(self, root_job_ids: _Optional[_Iterable[str]] = ..., steps: _Optional[_Iterable[_Union[WorkflowStep, _Mapping]]] = ...) -> None: ...
+
+class WorkflowsResp(_message.Message):
+    __slots__ = ("workflows",)
+    WORKFLOWS_FIELD_NUMBER: _ClassVar[int]
+    workflows: _containers.RepeatedCompositeFieldContainer[Workflow]
+    def __init__(self, workflows: _Optional[_Iterable[_Union[Workflow, _Mapping]]] = ...) -> None: ...
+
+class WorkflowRunReq(_message.Message):
+    __slots__ = ("id",)
+    ID_FIELD_NUMBER: _ClassVar[int]
+    id: str
+    def __init__(self, id: _Optional[str] = ...) -> None: ...
+
+class WorkflowRunStep(_message.Message):
+    __slots__ = ("job_id", "job_name", "status", "result")
+    JOB_ID_FIELD_NUMBER: _ClassVar[int]
+    JOB_NAME_FIELD_NUMBER: _ClassVar[int]
+    STATUS_FIELD_NUMBER: _ClassVar[int]
+    RESULT_FIELD_NUMBER: _ClassVar[int]
+    job_id: str
+    job_name: str
+    status: str
+    result: str
+    def __init__(self, job_id: _Optional[str] = ..., job_name: _Optional[str] = ..., status: _Optional[str] = ..., result: _Optional[str] = ...) -> None: ...
+
+class WorkflowRun(_message.Message):
+    __slots__ = ("id", "root_job_ids", "status", "steps", "start_at", "end_at")
+    class StepsEntry(_message.Message):
+        __slots__ = ("key", "value")
+        KEY_FIELD_NUMBER: _ClassVar[int]
+        VALUE_FIELD_NUMBER: _ClassVar[int]
+        key: str
+        value: WorkflowRunStep
+        def __init__(self, key: _Optional[str] = ..., value: _Optional[_Union[WorkflowRunStep, _Mapping]] = ...) -> None: ...
+    ID_FIELD_NUMBER: _ClassVar[int]
+    ROOT_JOB_IDS_FIELD_NUMBER: _ClassVar[int]
+    STATUS_FIELD_NUMBER: _ClassVar[int]
+    STEPS_FIELD_NUMBER: _ClassVar[int]
+    START_AT_FIELD_NUMBER: _ClassVar[int]
+    END_AT_FIELD_NUMBER: _ClassVar[int]
+    id: str
+    root_job_ids: _containers.RepeatedScalarFieldContainer[str]
+    status: str
+    steps: _containers.MessageMap[str, WorkflowRunStep]
+    start_at: _timestamp_pb2.Timestamp
+    end_at: _timestamp_pb2.Timestamp
+    def __init__(self, id: _Optional[str] = ..., root_job_ids: _Optional[_Iterable[str]] = ..., status: _Optional[str] = ..., steps: _Optional[_Mapping[str, WorkflowRunStep]] = ..., start_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., end_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+
+class WorkflowRunsResp(_message.Message):
+    __slots__ = ("workflow_runs",)
+    WORKFLOW_RUNS_FIELD_NUMBER: _ClassVar[int]
+    workflow_runs: _containers.RepeatedCompositeFieldContainer[WorkflowRun]
+    def __init__(self, workflow_runs: _Optional[_Iterable[_Union[WorkflowRun, _Mapping]]] = ...) -> None: ...
@@ -0,0 +1,152 @@
+package agscheduler
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// When using an executor pool, the number of job runs executing at the same time on this node is limited,
+// the runs that do not fit wait in the pool without a goroutine, those with higher `Job.Priority` start first.
+// It applies to the runs in standalone, broker and cluster mode.
+type ExecutorPool struct {
+	// Maximum number of runs executing at the same time.
+	// If 0, the number is unlimited.
+	MaxWorkers int
+	// Maximum number of runs executing at the same time per queue,
+	// keyed by the name of the broker's queue or the cluster node's queue.
+	// If a queue is not set, the number of its runs is only limited by `MaxWorkers`.
+	// The runs in standalone mode have no queue, so it needs broker or cluster mode.
+	QueueMaxWorkers map[string]int
+
+	running      int
+	queueRunning map[string]int
+	// Sorted by priority descending, then by arrival.
+	waiting []*poolWaiter
+
+	m sync.Mutex
+}
+
+type poolWaiter struct {
+	queue    string
+	priority int
+	run      func()
+}
+
+// Initialization functions for each executor pool,
+// called when the scheduler run `SetExecutorPool`.
+func (ep *ExecutorPool) init() error {
+	slog.Info("ExecutorPool init...")
+
+	if ep.MaxWorkers < 0 {
+		return fmt.Errorf("executor pool MaxWorkers must be greater than or equal to 0, got %d", ep.MaxWorkers)
+	}
+	for q, n := range ep.QueueMaxWorkers {
+		if n <= 0 {
+			return fmt.Errorf("executor pool QueueMaxWorkers of queue `%s` must be greater than 0, got %d", q, n)
+		}
+	}
+	ep.queueRunning = make(map[string]int)
+
+	return nil
+}
+
+// Called with the lock held.
+func (ep *ExecutorPool) canRun(queue string) bool {
+	if ep.MaxWorkers > 0 && ep.running >= ep.MaxWorkers {
+		return false
+	}
+	if n, ok := ep.QueueMaxWorkers[queue]; ok && ep.queueRunning[queue] >= n {
+		return false
+	}
+
+	return true
+}
+
+// Run `run` in a new goroutine once it fits, until then it waits in the pool.
+func (ep *ExecutorPool) submit(queue string, priority int, run func()) {
+	ep.m.Lock()
+	defer ep.m.Unlock()
+
+	w := &poolWaiter{queue: queue, priority: priority, run: run}
+	// The waiters that fit are always started by `release`,
+	// so the run can start if it fits.
+	if ep.canRun(queue) {
+		ep.start(w)
+		return
+	}
+
+	i, _ := slices.BinarySearchFunc(ep.waiting, priority, func(e *poolWaiter, p int) int {
+		// Put after the waiters with the same priority.
+		if e.priority >= p {
+			return -1
+		}
+		return 1
+	})
+	ep.waiting = slices.Insert(ep.waiting, i, w)
+}
+
+// Called with the lock held.
+func (ep *ExecutorPool) start(w *poolWaiter) {
+	ep.running++
+	ep.queueRunning[w.queue]++
+	go func() {
+		defer ep.release(w.queue)
+		w.run()
+	}()
+}
+
+func (ep *ExecutorPool) release(queue string) {
+	ep.m.Lock()
+	defer ep.m.Unlock()
+
+	ep.running--
+	ep.queueRunning[queue]--
+
+	// Start the waiters with the highest priority that fit,
+	// a waiter of a full queue does not block the others.
+	for i := 0; i < len(ep.waiting); {
+		w := ep.waiting[i]
+		if !ep.canRun(w.queue) {
+			if ep.MaxWorkers > 0 && ep.running >= ep.MaxWorkers {
+				return
+			}
+			i++
+			continue
+		}
+		ep.waiting = slices.Delete(ep.waiting, i, i+1)
+		ep.start(w)
+	}
+}
+
+// The utilization and backlog of the pool, used by `Scheduler.Info`.
+func (ep *ExecutorPool) info() map[string]any {
+	ep.m.Lock()
+	defer ep.m.Unlock()
+
+	queueWaiting := map[string]int{}
+	for _, w := range ep.waiting {
+		queueWaiting[w.queue]++
+	}
+	queues := map[string]any{}
+	for q, n := range ep.QueueMaxWorkers {
+		queues[q] = map[string]any{
+			"max_workers": n,
+			"running":     ep.queueRunning[q],
+			"waiting":     queueWaiting[q],
+		}
+	}
+	utilization := 0.0
+	if ep.MaxWorkers > 0 {
+		utilization = float64(ep.running) / float64(ep.MaxWorkers)
+	}
+
+	return map[string]any{
+		"max_workers": ep.MaxWorkers,
+		"running":     ep.running,
+		"waiting":     len(ep.waiting),
+		"utilization": utilization,
+		"queues":      queues,
+	}
+}
@@ -0,0 +1,82 @@
+package agscheduler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutorPoolInitError(t *testing.T) {
+	ep := &ExecutorPool{MaxWorkers: -1}
+	assert.Error(t, ep.init())
+
+	ep = &ExecutorPool{QueueMaxWorkers: map[string]int{"default": 0}}
+	assert.Error(t, ep.init())
+}
+
+func TestExecutorPoolPriority(t *testing.T) {
+	ep := &ExecutorPool{MaxWorkers: 1}
+	assert.NoError(t, ep.init())
+
+	block := make(chan struct{})
+	ep.submit("", 0, func() { <-block })
+
+	var m sync.Mutex
+	order := []string{}
+	var wg sync.WaitGroup
+	for _, w := range []struct {
+		name     string
+		priority int
+	}{{"low", 0}, {"high", 5}, {"high2", 5}} {
+		wg.Add(1)
+		ep.submit("", w.priority, func() {
+			defer wg.Done()
+			m.Lock()
+			order = append(order, w.name)
+			m.Unlock()
+		})
+	}
+
+	info := ep.info()
+	assert.Equal(t, 1, info["running"])
+	assert.Equal(t, 3, info["waiting"])
+	assert.Equal(t, 1.0, info["utilization"])
+
+	close(block)
+	wg.Wait()
+
+	assert.Equal(t, []string{"high", "high2", "low"}, order)
+	assert.Eventually(t, func() bool { return ep.info()["running"] == 0 }, time.Second, time.Millisecond)
+}
+
+func TestExecutorPoolQueue(t *testing.T) {
+	ep := &ExecutorPool{QueueMaxWorkers: map[string]int{"mail": 1}}
+	assert.NoError(t, ep.init())
+
+	block := make(chan struct{})
+	ep.submit("mail", 0, func() { <-block })
+
+	started := make(chan struct{})
+	ep.submit("mail", 0, func() { close(started) })
+
+	// The other queues are not limited by a full queue.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	ep.submit("default", 0, func() { defer wg.Done(); <-block })
+	ep.submit("default", 0, func() { defer wg.Done(); <-block })
+
+	select {
+	case <-started:
+		assert.Fail(t, "started over the queue limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, 3, ep.info()["running"])
+	queues := ep.info()["queues"].(map[string]any)
+	assert.Equal(t, 1, queues["mail"].(map[string]any)["waiting"])
+
+	close(block)
+	<-started
+	wg.Wait()
+}
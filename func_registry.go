@@ -0,0 +1,270 @@
+package agscheduler
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type FuncPkg struct {
+	Func func(context.Context, Job) (result string)
+	// It can be used instead of `Func`.
+	TypedFunc func(context.Context, Job) (any, error)
+	// About this function.
+	Info string
+	// Optional, the zero value of the argument struct, e.g. `PrintArgs{}`.
+	// The JSON Schema of `Args` is derived from it, `Args` of the job is validated on add and update.
+	// Use `Job.DecodeArgs` to decode `Args` into the struct.
+	Args any
+	// Optional, the stable name used as `FuncName` of jobs, it does not change when the function is moved or renamed.
+	// If empty, the actual path of the function is used.
+	// e.g. `send_email`
+	Name string
+	// Optional, the function is registered as `Name@Version`,
+	// and `Name` refers to the highest version, compared as semantic versions.
+	// e.g. `v2`, `v1.2.0`
+	Version string
+	// Optional, other names resolved to this function, e.g. the old actual path of a moved function.
+	// Jobs stored with an alias keep running, and `MigrateFuncNames` rewrites them to the registered name.
+	Aliases []string
+
+	argsSchema map[string]any
+}
+
+// Record the actual path of function and the corresponding function.
+// It is a snapshot of the built-in functions taken when the package is initialized,
+// it is not updated by `RegisterFuncs`, and changing it does not register or unregister functions.
+//
+// Deprecated: Use `DefaultFuncRegistry` and `FuncMapReadable` to look up and list the functions.
+var FuncMap = func() map[string]FuncPkg {
+	fr := &FuncRegistry{}
+	fr.RegisterBuiltinFuncs()
+	return fr.cloneFuncs()
+}()
+
+// The registry used by the schedulers without `SetFuncRegistry`,
+// the package-level `RegisterFuncs` registers functions in it.
+var DefaultFuncRegistry = &FuncRegistry{}
+
+// Record the registered functions, it is safe for concurrent use.
+// Set it to the scheduler with `SetFuncRegistry`,
+// so that schedulers in one process can run different functions.
+type FuncRegistry struct {
+	funcs map[string]FuncPkg
+	// Record the aliases of the registered names.
+	aliases map[string]string
+
+	m sync.RWMutex
+}
+
+func (fr *FuncRegistry) RegisterFuncs(fps ...FuncPkg) {
+	fr.m.Lock()
+	defer fr.m.Unlock()
+
+	if fr.funcs == nil {
+		fr.funcs = make(map[string]FuncPkg)
+	}
+	if fr.aliases == nil {
+		fr.aliases = make(map[string]string)
+	}
+
+	for _, fp := range fps {
+		path := getFuncName(fp.funcValue().Interface())
+		fName := path
+		if fp.Name != "" {
+			fName = fp.Name
+		}
+		aliases := slices.Clone(fp.Aliases)
+		if fp.Version != "" {
+			name := fName
+			fName = fName + "@" + fp.Version
+			latest, ok := fr.funcs[fr.aliases[name]]
+			if !ok || compareVersions(fp.Version, latest.Version) >= 0 {
+				aliases = append(aliases, name)
+			}
+		}
+		if path != fName {
+			aliases = append(aliases, path)
+		}
+
+		if fp.Args != nil {
+			fp.argsSchema = argsSchemaOf(fp.Args)
+		}
+		fr.funcs[fName] = fp
+		for _, alias := range aliases {
+			fr.aliases[alias] = fName
+		}
+	}
+}
+
+// Register the built-in functions `RunShell` and `RunWebhook`,
+// e.g. in a registry set with `SetFuncRegistry`, or again after `UnregisterBuiltinFuncs`.
+func (fr *FuncRegistry) RegisterBuiltinFuncs() {
+	fr.RegisterFuncs(shellFuncPkg, webhookFuncPkg)
+}
+
+// Unregister the built-in functions and their aliases,
+// since they run any command or call any URL given by `Args`,
+// call it before the scheduler starts if not everyone who can add jobs is trusted.
+// The jobs already using them are recorded as `RECORD_STATUS_ERROR` with `FuncUnregisteredError` when they run.
+func (fr *FuncRegistry) UnregisterBuiltinFuncs() {
+	fr.m.Lock()
+	defer fr.m.Unlock()
+
+	for _, f := range []any{RunShell, RunWebhook} {
+		fName := getFuncName(f)
+		delete(fr.funcs, fName)
+		for alias, name := range fr.aliases {
+			if name == fName {
+				delete(fr.aliases, alias)
+			}
+		}
+	}
+}
+
+// List the registered functions, `args_schema` is the JSON Schema of `Args`, or nil if `FuncPkg.Args` is not set,
+// and `aliases` are the other names resolved to the function.
+func (fr *FuncRegistry) FuncMapReadable() []map[string]any {
+	fr.m.RLock()
+	defer fr.m.RUnlock()
+
+	funcs := []map[string]any{}
+
+	aliases := map[string][]string{}
+	for alias, fName := range fr.aliases {
+		if _, ok := fr.funcs[alias]; !ok {
+			aliases[fName] = append(aliases[fName], alias)
+		}
+	}
+
+	for fName, fPkg := range fr.funcs {
+		fAliases := append([]string{}, aliases[fName]...)
+		slices.Sort(fAliases)
+		funcs = append(funcs, map[string]any{
+			"name": fName, "info": fPkg.Info, "args_schema": fPkg.argsSchema,
+			"version": fPkg.Version, "aliases": fAliases,
+		})
+	}
+
+	return funcs
+}
+
+func (fr *FuncRegistry) cloneFuncs() map[string]FuncPkg {
+	fr.m.RLock()
+	defer fr.m.RUnlock()
+
+	return maps.Clone(fr.funcs)
+}
+
+// Return the registered name of the function name or alias.
+func (fr *FuncRegistry) resolveFuncName(name string) (string, bool) {
+	fr.m.RLock()
+	defer fr.m.RUnlock()
+
+	if _, ok := fr.funcs[name]; ok {
+		return name, true
+	}
+	fName, ok := fr.aliases[name]
+
+	return fName, ok
+}
+
+func (fr *FuncRegistry) lookupFunc(name string) (FuncPkg, bool) {
+	fName, ok := fr.resolveFuncName(name)
+	if !ok {
+		return FuncPkg{}, false
+	}
+
+	fr.m.RLock()
+	defer fr.m.RUnlock()
+
+	fp, ok := fr.funcs[fName]
+	return fp, ok
+}
+
+func (fp FuncPkg) funcValue() reflect.Value {
+	if fp.TypedFunc != nil {
+		return reflect.ValueOf(fp.TypedFunc)
+	}
+
+	return reflect.ValueOf(fp.Func)
+}
+
+// Compare the versions such as `v2` and `v1.10.0` part by part, numeric parts are compared as numbers.
+//
+//	@return -1, 0 or 1.
+func compareVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := range max(len(aParts), len(bParts)) {
+		aPart, bPart := "0", "0"
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		aN, aErr := strconv.Atoi(aPart)
+		bN, bErr := strconv.Atoi(bPart)
+		if aErr == nil && bErr == nil {
+			if c := cmp.Compare(aN, bN); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := strings.Compare(aPart, bPart); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func getFuncName(f any) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+// Register functions in `DefaultFuncRegistry`.
+func RegisterFuncs(fps ...FuncPkg) {
+	DefaultFuncRegistry.RegisterFuncs(fps...)
+}
+
+// Register the built-in functions in `DefaultFuncRegistry`.
+func RegisterBuiltinFuncs() {
+	DefaultFuncRegistry.RegisterBuiltinFuncs()
+}
+
+// Unregister the built-in functions from `DefaultFuncRegistry`.
+func UnregisterBuiltinFuncs() {
+	DefaultFuncRegistry.UnregisterBuiltinFuncs()
+}
+
+// List the functions of `DefaultFuncRegistry`.
+func FuncMapReadable() []map[string]any {
+	return DefaultFuncRegistry.FuncMapReadable()
+}
+
+// Called when the scheduler run `AddJob` or `UpdateJob`,
+// resolve the alias of `FuncName` and validate `Args`.
+func (j *Job) checkFunc(fr *FuncRegistry) error {
+	fName, ok := fr.resolveFuncName(j.FuncName)
+	if !ok {
+		return FuncUnregisteredError(j.FuncName)
+	}
+	j.FuncName = fName
+
+	fp, _ := fr.lookupFunc(fName)
+	if fp.argsSchema != nil {
+		if err := j.checkArgs(fp.argsSchema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
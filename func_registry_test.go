@@ -0,0 +1,98 @@
+package agscheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncRegistry(t *testing.T) {
+	f := func(ctx context.Context, j Job) (result string) { return }
+	fr := &FuncRegistry{}
+	fr.RegisterFuncs(FuncPkg{Func: f, Name: "registry_func", Aliases: []string{"main.registryFunc"}})
+
+	fName, ok := fr.resolveFuncName("main.registryFunc")
+	assert.True(t, ok)
+	assert.Equal(t, "registry_func", fName)
+	_, ok = fr.lookupFunc("registry_func")
+	assert.True(t, ok)
+	assert.Len(t, fr.FuncMapReadable(), 1)
+
+	fr.RegisterFuncs(FuncPkg{Func: f, Name: "registry_func_no_aliases"})
+	for _, fm := range fr.FuncMapReadable() {
+		if fm["name"] == "registry_func_no_aliases" {
+			assert.Equal(t, []string{getFuncName(f)}, fm["aliases"])
+		}
+	}
+	g := func(ctx context.Context, j Job) (result string) { return }
+	fr.RegisterFuncs(FuncPkg{Func: g})
+	for _, fm := range fr.FuncMapReadable() {
+		if fm["name"] == getFuncName(g) {
+			assert.Equal(t, []string{}, fm["aliases"])
+		}
+	}
+
+	_, ok = DefaultFuncRegistry.resolveFuncName("registry_func")
+	assert.False(t, ok)
+}
+
+func TestFuncRegistryConcurrent(t *testing.T) {
+	fr := &FuncRegistry{}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fr.RegisterFuncs(FuncPkg{
+				Func: func(ctx context.Context, j Job) (result string) { return },
+				Name: fmt.Sprintf("func_%d", i),
+			})
+			_, _ = fr.lookupFunc(fmt.Sprintf("func_%d", i))
+			_ = fr.FuncMapReadable()
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, fr.FuncMapReadable(), 10)
+}
+
+func TestFuncMapSnapshot(t *testing.T) {
+	assert.Contains(t, FuncMap, getFuncName(RunShell))
+	assert.Contains(t, FuncMap, getFuncName(RunWebhook))
+
+	f := func(ctx context.Context, j Job) (result string) { return }
+	RegisterFuncs(FuncPkg{Func: f})
+	assert.NotContains(t, FuncMap, getFuncName(f))
+
+	FuncMap["main.notRegistered"] = FuncPkg{Func: f}
+	_, ok := DefaultFuncRegistry.lookupFunc("main.notRegistered")
+	assert.False(t, ok)
+	delete(FuncMap, "main.notRegistered")
+}
+
+// Run with `-race`, the package-level functions do not write shared variables outside the registry.
+func TestRegisterFuncsConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			RegisterFuncs(FuncPkg{
+				Func: func(ctx context.Context, j Job) (result string) { return },
+				Name: fmt.Sprintf("concurrent_func_%d", i),
+			})
+			_ = FuncMapReadable()
+			_ = len(FuncMap)
+		}()
+	}
+	wg.Wait()
+
+	for i := range 10 {
+		_, ok := DefaultFuncRegistry.lookupFunc(fmt.Sprintf("concurrent_func_%d", i))
+		assert.True(t, ok)
+	}
+}
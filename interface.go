@@ -42,6 +42,70 @@ type Store interface {
 	Clear() error
 }
 
+// Defines the interface that a store can implement to persist calendars,
+// otherwise the calendars are only kept in the memory of the scheduler.
+type CalendarStore interface {
+	// Add calendar to this store, replace it if the name exists.
+	AddCalendar(c Calendar) error
+
+	// Get the calendar from this store.
+	//  @return error `CalendarNotFoundError` if there are no calendar.
+	GetCalendar(name string) (Calendar, error)
+
+	// Get all calendars from this store.
+	GetAllCalendars() ([]Calendar, error)
+
+	// Delete the calendar from this store.
+	DeleteCalendar(name string) error
+}
+
+// Defines the interface that a store can implement to persist workflow runs,
+// so that the scheduler completing a job of the workflow run can run the downstream jobs, e.g. a worker of the broker,
+// otherwise the workflow runs are only kept in the memory of the scheduler.
+type WorkflowRunStore interface {
+	// Add workflow run to this store.
+	AddWorkflowRun(wr WorkflowRun) error
+
+	// Get the workflow run from this store.
+	//  @return error `WorkflowRunNotFoundError` if there are no workflow run.
+	GetWorkflowRun(id string) (WorkflowRun, error)
+
+	// Get all workflow runs from this store.
+	GetAllWorkflowRuns() ([]WorkflowRun, error)
+
+	// Update the workflow run in this store if its `Version` in this store is `wr.Version - 1`.
+	//  @return false, nil, if the workflow run has been updated by others since it was got.
+	//  @return error `WorkflowRunNotFoundError` if there are no workflow run.
+	UpdateWorkflowRun(wr WorkflowRun) (bool, error)
+
+	// Delete the workflow run from this store.
+	DeleteWorkflowRun(id string) error
+}
+
+// Defines the interface that a store can implement to limit `Job.MaxInstances` across the cluster,
+// otherwise the leases are kept by the main node in cluster mode, or by the scheduler.
+type LeaseStore interface {
+	// Acquire a lease for a run of the job if fewer than `limit` leases of the job are held,
+	// the lease expires after `ttl` unless it is renewed.
+	//  @return false, nil, if the limit is reached.
+	AcquireLease(jId, leaseId string, limit int, ttl time.Duration) (bool, error)
+
+	// Extend the lease by `ttl`.
+	RenewLease(jId, leaseId string, ttl time.Duration) error
+
+	// Release the lease.
+	ReleaseLease(jId, leaseId string) error
+}
+
+// Defines the interface that a store can implement to share the tokens of the rate limiters across the cluster,
+// otherwise the tokens are only counted by the scheduler.
+type RateLimitStore interface {
+	// Take a token from the bucket of the rate limiter,
+	// the bucket holds at most `burst` tokens and a token is added every `interval`.
+	//  @return 0, nil, if a token is taken, otherwise the time until a token is available.
+	TakeToken(name string, burst int, interval time.Duration) (time.Duration, error)
+}
+
 // Defines the interface that each queue must implement.
 type Queue interface {
 	// Queue name.
@@ -65,6 +129,23 @@ type Queue interface {
 	Clear() error
 }
 
+// Defines the interface that a queue can implement to deliver the jobs with higher `Job.Priority` first,
+// otherwise the jobs are delivered in the order they are pushed and the priority is ignored.
+type PriorityQueue interface {
+	// Push job with its priority to this queue.
+	PushPriorityJob(bJ []byte, priority int) error
+}
+
+// Defines the interface that a queue can implement to broadcast the cancellations of runs to the workers of all schedulers,
+// otherwise `CancelRun` only cancels the runs on this scheduler, or on the cluster nodes in cluster mode.
+type CancelQueue interface {
+	// Push the record id of the run to cancel to the subscribers of this queue.
+	PushCancel(recordId uint64) error
+
+	// Pull the record ids of the runs to cancel from this queue.
+	PullCancel() <-chan uint64
+}
+
 // Defines the interface that each backend must implement.
 type Backend interface {
 	// Backend name.
@@ -80,6 +161,19 @@ type Backend interface {
 	// Record the result of the job run to this backend.
 	RecordResult(id uint64, status string, result string) error
 
+	// Record the progress of the job run to this backend.
+	RecordProgress(id uint64, p Progress) error
+
+	// Flag the job run as exceeding `Job.SoftTimeout` in this backend.
+	RecordSoftTimeout(id uint64) error
+
+	// Record the logs of the job run to this backend, replacing the previous logs.
+	RecordLogs(id uint64, logs string) error
+
+	// Get the logs of the job run from this backend.
+	//  @return "", RecordNotFoundError, if the record does not exist.
+	GetRecordLogs(id uint64) (string, error)
+
 	// Get records by job id from this backend.
 	//  @return records, total, error.
 	GetRecords(jId string, page, pageSize int) ([]Record, int64, error)
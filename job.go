@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
-	"runtime"
+	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,6 +21,20 @@ const (
 	JOB_TYPE_DATETIME = "datetime"
 	JOB_TYPE_INTERVAL = "interval"
 	JOB_TYPE_CRON     = "cron"
+	JOB_TYPE_COMBINED = "combined"
+	JOB_TYPE_RRULE    = "rrule"
+)
+
+// constant indicating an interval job's mode
+const (
+	INTERVAL_MODE_FIXED_RATE  = "fixed_rate"
+	INTERVAL_MODE_FIXED_DELAY = "fixed_delay"
+)
+
+// constant indicating what a job run does when its rate limiter has no token
+const (
+	RATE_LIMIT_POLICY_WAIT = "wait"
+	RATE_LIMIT_POLICY_SKIP = "skip"
 )
 
 // constant indicating a job's status
@@ -36,20 +50,52 @@ type Job struct {
 	Id string `json:"id"`
 	// User defined.
 	Name string `json:"name"`
-	// Optional: `JOB_TYPE_DATETIME` | `JOB_TYPE_INTERVAL` | `JOB_TYPE_CRON`
+	// Optional: `JOB_TYPE_DATETIME` | `JOB_TYPE_INTERVAL` | `JOB_TYPE_CRON` | `JOB_TYPE_COMBINED` | `JOB_TYPE_RRULE`
 	Type string `json:"type"`
-	// It can be used when Type is `JOB_TYPE_DATETIME`.
+	// It can be used when Type is `JOB_TYPE_DATETIME` | `JOB_TYPE_INTERVAL`.
+	// When Type is `JOB_TYPE_INTERVAL`, the first run is at it, and the runs are anchored to it.
+	// When Type is `JOB_TYPE_RRULE`, it is used as `DTSTART` if `RRule` has none.
 	// e.g. `2023-09-22 07:30:08`
 	StartAt string `json:"start_at"`
-	// This field is useless.
+	// It can be used when Type is `JOB_TYPE_INTERVAL` | `JOB_TYPE_CRON` | `JOB_TYPE_COMBINED` | `JOB_TYPE_RRULE`.
+	// When the next run time is after it, the job will be deleted.
+	// `AddJob` rejects the job that has passed it with `JobEndedError`,
+	// the paused job is deleted at it, and `PauseJob` and `ResumeJob` delete the job that has passed it.
+	// e.g. `2023-12-31 23:59:59`
 	EndAt string `json:"end_at"`
 	// It can be used when Type is `JOB_TYPE_INTERVAL`.
 	// e.g. `2s`
 	Interval string `json:"interval"`
+	// It can be used when Type is `JOB_TYPE_INTERVAL`.
+	// `INTERVAL_MODE_FIXED_RATE` runs are anchored to `StartAt` or `LastRunTime`,
+	// `INTERVAL_MODE_FIXED_DELAY` runs start `Interval` after the previous run completed.
+	// Optional: `INTERVAL_MODE_FIXED_RATE` | `INTERVAL_MODE_FIXED_DELAY`
+	// Default: `INTERVAL_MODE_FIXED_RATE`
+	IntervalMode string `json:"interval_mode"`
 	// It can be used when Type is `JOB_TYPE_CRON`.
 	// See `https://en.wikipedia.org/wiki/Cron`.
 	// e.g. `*/1 * * * *`
 	CronExpr string `json:"cron_expr"`
+	// It can be used when Type is `JOB_TYPE_RRULE`.
+	// See `https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.10`.
+	// Lines of `DTSTART`, `RRULE`, `RDATE` and `EXDATE`,
+	// times without `Z` or `TZID` are in `Timezone`.
+	// `BYYEARDAY`, `BYWEEKNO` and `FREQ=SECONDLY` are not supported.
+	// e.g. `DTSTART:20230922T090000\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1`
+	RRule string `json:"rrule"`
+	// It can be used when Type is `JOB_TYPE_COMBINED`.
+	// The sub-triggers of the job, their Type cannot be `JOB_TYPE_COMBINED`.
+	Triggers []Trigger `json:"triggers"`
+	// It can be used when Type is `JOB_TYPE_COMBINED`.
+	// `TRIGGER_MODE_OR` runs at the earliest run time of any trigger,
+	// `TRIGGER_MODE_AND` runs only when all triggers fire at the same time.
+	// Optional: `TRIGGER_MODE_OR` | `TRIGGER_MODE_AND`
+	// Default: `TRIGGER_MODE_OR`
+	TriggerMode string `json:"trigger_mode"`
+	// The name of the calendar added through `Scheduler.AddCalendar`,
+	// the dates excluded by it are skipped.
+	// It has no effect when Type is `JOB_TYPE_DATETIME`.
+	Calendar string `json:"calendar"`
 	// Refer to `time.LoadLocation`.
 	// See `https://en.wikipedia.org/wiki/List_of_tz_database_time_zones`
 	// Default: `UTC`
@@ -59,8 +105,12 @@ type Job struct {
 	// Since it cannot be stored by serialization,
 	// when using gRPC or HTTP calls, you should use `FuncName`.
 	Func func(context.Context, Job) (result string) `json:"-"`
-	// The actual path of `Func`.
-	// This field has a higher priority than `Func`
+	// It can be used instead of `Func`, the returned error marks the run as `RECORD_STATUS_ERROR`,
+	// the returned result is saved as JSON, unless it is a string.
+	// Return `NonRetryableError` to stop retrying, or `SkipError` to mark the run as `RECORD_STATUS_SKIPPED`.
+	TypedFunc func(context.Context, Job) (any, error) `json:"-"`
+	// The actual path of `Func` or `TypedFunc`.
+	// This field has a higher priority than `Func` and `TypedFunc`
 	// e.g. `main.xxxFunc`
 	//      `github.com/agscheduler/agscheduler/examples.PrintMsg`
 	FuncName string `json:"func_name"`
@@ -77,8 +127,82 @@ type Job struct {
 	// Default: 1
 	// Note: In protobuf, values ≤ 0 will be treated as 1.
 	MaxInstances int `json:"max_instances"`
+	// How late a run is still allowed to start after its scheduled time,
+	// if it is later than that, the run will be skipped and recorded as missed.
+	// If empty, the run is never considered missed.
+	// e.g. `30s`
+	MisfireGraceTime string `json:"misfire_grace_time"`
+	// Whether to merge several missed runs into one run,
+	// if false, each missed run will be replayed, up to the first 100 runs and the latest one,
+	// the runs between them are dropped.
+	// Replayed runs are still limited by `MaxInstances`.
+	// Default: true
+	Coalesce *bool `json:"coalesce"`
+	// Maximum number of attempts to run `Func`, including the first one.
+	// When `Func` panics, it will be retried until this number is reached.
+	// Default: 1
+	// Note: In protobuf, values ≤ 0 will be treated as 1.
+	MaxAttempts int `json:"max_attempts"`
+	// The delay before the first retry, it doubles after each retry,
+	// and a random jitter of up to half of it is subtracted.
+	// Default: `1s`
+	RetryBackoff string `json:"retry_backoff"`
+	// The upper limit of the delay before a retry.
+	// Default: `10m`
+	RetryBackoffMax string `json:"retry_backoff_max"`
+	// Whether to retry when `Func` runs timeout.
+	RetryOnTimeout bool `json:"retry_on_timeout"`
+	// If `Func` does not report progress or heartbeat within this duration,
+	// the run is flagged as stalled, it must be less than `Timeout`.
+	// If empty, the run is never flagged as stalled.
+	// e.g. `5m`
+	HeartbeatTimeout string `json:"heartbeat_timeout"`
+	// The expected duration of `Func`, if it is exceeded,
+	// the run is flagged in `Record.SoftTimedOut` and keeps running until `Timeout`.
+	// It must be less than `Timeout`.
+	// e.g. `10m`
+	SoftTimeout string `json:"soft_timeout"`
+	// The time of day in `Timezone` by which a successful run must be recorded every day,
+	// if not, `EVENT_JOB_SLA_MISSED` is dispatched.
+	// The runs completed within 24 hours before the deadline are counted, the recorder is required.
+	// e.g. `06:30`
+	SLA string `json:"sla"`
+	// Ids of the upstream jobs in the workflow.
+	// If not empty, the job is no longer run by `Type`,
+	// but runs after all upstream jobs have completed in the same workflow run.
+	// The results of upstream jobs are passed in `Args` with the key `WORKFLOW_ARG_UPSTREAM_RESULTS`.
+	Upstreams []string `json:"upstreams"`
+	// The workflow run that the job run belongs to.
+	// It should not be set manually.
+	WorkflowRunId string `json:"workflow_run_id"`
+	// The jobs with higher priority are dispatched first when they are due at the same time,
+	// delivered first by the queues implementing `PriorityQueue`,
+	// and start first when they wait for the executor pool.
+	// Default: 0
+	Priority int `json:"priority"`
+	// The name of the rate limiter added by `AddRateLimiter`,
+	// each run takes a token from it before running `Func`.
+	// The jobs calling the same resource can reference the same rate limiter.
+	RateLimiter string `json:"rate_limiter"`
+	// `RATE_LIMIT_POLICY_WAIT` runs wait for a token and are recorded as deferred,
+	// `RATE_LIMIT_POLICY_SKIP` runs are skipped and recorded as skipped.
+	// In broker mode, the waiting run holds its broker worker until it gets a token,
+	// so the other jobs of the queue, even those without a rate limiter, wait for a free worker,
+	// use `RATE_LIMIT_POLICY_SKIP` or a dedicated queue for the rate-limited jobs.
+	// Optional: `RATE_LIMIT_POLICY_WAIT` | `RATE_LIMIT_POLICY_SKIP`
+	// Default: `RATE_LIMIT_POLICY_WAIT`
+	RateLimitPolicy string `json:"rate_limit_policy"`
+	// Maximum number of runs for this job, when it is reached, the job will be deleted.
+	// If 0, the number of runs is unlimited.
+	// Only the runs scheduled by `Type` are counted,
+	// the runs by `RunJob` or by the upstream jobs of the workflow are not.
+	MaxRuns int `json:"max_runs"`
 
 	// Automatic update, not manual setting.
+	// The number of runs scheduled by `Type` for this job, see `MaxRuns`.
+	Runs int `json:"runs"`
+	// Automatic update, not manual setting.
+	// The scheduled time of the last run.
 	LastRunTime time.Time `json:"last_run_time"`
 	// Automatic update, not manual setting.
 	// When the job is paused, this field is set to `9999-09-09 09:09:09`.
@@ -95,6 +219,24 @@ func (js JobSlice) Len() int           { return len(js) }
 func (js JobSlice) Less(i, j int) bool { return js[i].NextRunTime.Before(js[j].NextRunTime) }
 func (js JobSlice) Swap(i, j int)      { js[i], js[j] = js[j], js[i] }
 
+// Carry the result of a job run,
+// reported to the scheduler when the run completes.
+type JobResult struct {
+	Job Job
+	// Optional: `RECORD_STATUS_COMPLETED` | `RECORD_STATUS_ERROR` | `RECORD_STATUS_TIMEOUT`
+	Status string
+	Result string
+}
+
+// Carry a run of the job to the queue or the cluster node that runs it.
+type JobRun struct {
+	Job Job `json:"job"`
+	// The attempt to run, starting from `1`, `0` is the same as `1`.
+	Attempt int `json:"attempt"`
+	// The time the run is scheduled at, zero if the run is not scheduled by `Type`, e.g. `RunJob`.
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
 func (j *Job) setId() {
 	j.Id = strings.ReplaceAll(uuid.New().String(), "-", "")[:16]
 }
@@ -105,13 +247,31 @@ func (j *Job) init() error {
 	j.setId()
 
 	j.Status = JOB_STATUS_RUNNING
+	j.Runs = 0
+	j.LastRunTime = time.Time{}
 
 	if j.Timezone == "" {
 		j.Timezone = "UTC"
 	}
 
 	if j.FuncName == "" {
-		j.FuncName = getFuncName(j.Func)
+		if j.TypedFunc != nil {
+			j.FuncName = getFuncName(j.TypedFunc)
+		} else {
+			j.FuncName = getFuncName(j.Func)
+		}
+	}
+
+	if j.IntervalMode == "" {
+		j.IntervalMode = INTERVAL_MODE_FIXED_RATE
+	}
+
+	if j.Triggers == nil {
+		j.Triggers = []Trigger{}
+	}
+
+	if j.TriggerMode == "" {
+		j.TriggerMode = TRIGGER_MODE_OR
 	}
 
 	if j.Args == nil {
@@ -126,10 +286,35 @@ func (j *Job) init() error {
 		j.Queues = []string{}
 	}
 
+	if j.Upstreams == nil {
+		j.Upstreams = []string{}
+	}
+
 	if j.MaxInstances <= 0 {
 		j.MaxInstances = 1
 	}
 
+	if j.Coalesce == nil {
+		coalesce := true
+		j.Coalesce = &coalesce
+	}
+
+	if j.MaxAttempts <= 0 {
+		j.MaxAttempts = 1
+	}
+
+	if j.RetryBackoff == "" {
+		j.RetryBackoff = "1s"
+	}
+
+	if j.RetryBackoffMax == "" {
+		j.RetryBackoffMax = "10m"
+	}
+
+	if j.RateLimitPolicy == "" {
+		j.RateLimitPolicy = RATE_LIMIT_POLICY_WAIT
+	}
+
 	nextRunTime, err := CalcNextRunTime(*j)
 	if err != nil {
 		return err
@@ -145,19 +330,104 @@ func (j *Job) init() error {
 
 // Called when the job run `init` or scheduler run `UpdateJob`.
 func (j *Job) check() error {
-	if _, ok := FuncMap[j.FuncName]; !ok {
-		return FuncUnregisteredError(j.FuncName)
-	}
-
-	_, err := time.ParseDuration(j.Timeout)
+	timeout, err := time.ParseDuration(j.Timeout)
 	if err != nil {
 		return &JobTimeoutError{FullName: j.FullName(), Timeout: j.Timeout, Err: err}
 	}
 
+	if j.HeartbeatTimeout != "" {
+		heartbeatTimeout, err := time.ParseDuration(j.HeartbeatTimeout)
+		if err != nil {
+			return fmt.Errorf("job `%s` HeartbeatTimeout `%s` error: %s", j.FullName(), j.HeartbeatTimeout, err)
+		}
+		if heartbeatTimeout <= 0 || heartbeatTimeout >= timeout {
+			return fmt.Errorf("job `%s` HeartbeatTimeout `%s` must be greater than 0 and less than Timeout `%s`", j.FullName(), j.HeartbeatTimeout, j.Timeout)
+		}
+	}
+
+	if j.SoftTimeout != "" {
+		softTimeout, err := time.ParseDuration(j.SoftTimeout)
+		if err != nil {
+			return fmt.Errorf("job `%s` SoftTimeout `%s` error: %s", j.FullName(), j.SoftTimeout, err)
+		}
+		if softTimeout <= 0 || softTimeout >= timeout {
+			return fmt.Errorf("job `%s` SoftTimeout `%s` must be greater than 0 and less than Timeout `%s`", j.FullName(), j.SoftTimeout, j.Timeout)
+		}
+	}
+
+	if j.SLA != "" {
+		if _, err := time.Parse(slaLayout, j.SLA); err != nil {
+			return fmt.Errorf("job `%s` SLA `%s` error: %s", j.FullName(), j.SLA, err)
+		}
+	}
+
+	if j.IntervalMode != "" &&
+		j.IntervalMode != INTERVAL_MODE_FIXED_RATE && j.IntervalMode != INTERVAL_MODE_FIXED_DELAY {
+		return fmt.Errorf("job `%s` IntervalMode `%s` unknown", j.FullName(), j.IntervalMode)
+	}
+
+	switch strings.ToLower(j.Type) {
+	case JOB_TYPE_COMBINED:
+		if err := j.checkTriggers(); err != nil {
+			return err
+		}
+	case JOB_TYPE_RRULE:
+		timezone, err := time.LoadLocation(j.Timezone)
+		if err != nil {
+			return fmt.Errorf("job `%s` Timezone `%s` error: %s", j.FullName(), j.Timezone, err)
+		}
+		if _, err := parseRRule(j.RRule, j.StartAt, timezone); err != nil {
+			return fmt.Errorf("job `%s` RRule `%s` error: %s", j.FullName(), j.RRule, err)
+		}
+	}
+
 	if j.MaxInstances <= 0 {
 		return fmt.Errorf("job `%s` MaxInstances must be greater than 0, got %d", j.FullName(), j.MaxInstances)
 	}
 
+	if j.MaxAttempts < 0 {
+		return fmt.Errorf("job `%s` MaxAttempts must not be negative, got %d", j.FullName(), j.MaxAttempts)
+	}
+
+	if j.RetryBackoff != "" {
+		if _, err := time.ParseDuration(j.RetryBackoff); err != nil {
+			return fmt.Errorf("job `%s` RetryBackoff `%s` error: %s", j.FullName(), j.RetryBackoff, err)
+		}
+	}
+
+	if j.RetryBackoffMax != "" {
+		retryBackoffMax, err := time.ParseDuration(j.RetryBackoffMax)
+		if err != nil {
+			return fmt.Errorf("job `%s` RetryBackoffMax `%s` error: %s", j.FullName(), j.RetryBackoffMax, err)
+		}
+		if retryBackoffMax <= 0 {
+			return fmt.Errorf("job `%s` RetryBackoffMax must be greater than 0, got %s", j.FullName(), j.RetryBackoffMax)
+		}
+	}
+
+	if j.RateLimitPolicy != "" &&
+		j.RateLimitPolicy != RATE_LIMIT_POLICY_WAIT && j.RateLimitPolicy != RATE_LIMIT_POLICY_SKIP {
+		return fmt.Errorf("job `%s` RateLimitPolicy `%s` unknown", j.FullName(), j.RateLimitPolicy)
+	}
+
+	if j.MaxRuns < 0 {
+		return fmt.Errorf("job `%s` MaxRuns must not be negative, got %d", j.FullName(), j.MaxRuns)
+	}
+
+	if slices.Contains(j.Upstreams, j.Id) {
+		return fmt.Errorf("job `%s` Upstreams cannot contain itself", j.FullName())
+	}
+
+	if j.MisfireGraceTime != "" {
+		misfireGraceTime, err := time.ParseDuration(j.MisfireGraceTime)
+		if err != nil {
+			return fmt.Errorf("job `%s` MisfireGraceTime `%s` error: %s", j.FullName(), j.MisfireGraceTime, err)
+		}
+		if misfireGraceTime < 0 {
+			return fmt.Errorf("job `%s` MisfireGraceTime must not be negative, got %s", j.FullName(), j.MisfireGraceTime)
+		}
+	}
+
 	return nil
 }
 
@@ -165,6 +435,60 @@ func (j *Job) FullName() string {
 	return j.Id + ":" + j.Name
 }
 
+func (j *Job) isFixedDelay() bool {
+	return strings.ToLower(j.Type) == JOB_TYPE_INTERVAL && j.IntervalMode == INTERVAL_MODE_FIXED_DELAY
+}
+
+// When `Coalesce` is not set, it is considered true.
+func (j *Job) IsCoalesce() bool {
+	return j.Coalesce == nil || *j.Coalesce
+}
+
+// Whether the run scheduled at `runTime` is too late to start at `now`.
+func (j *Job) isMisfired(runTime, now time.Time) bool {
+	if j.MisfireGraceTime == "" {
+		return false
+	}
+
+	misfireGraceTime, err := time.ParseDuration(j.MisfireGraceTime)
+	if err != nil {
+		return false
+	}
+
+	return now.Sub(runTime) > misfireGraceTime
+}
+
+// Whether the attempt with `status` should be retried.
+func (j *Job) isRetryable(status string) bool {
+	switch status {
+	case RECORD_STATUS_ERROR:
+		return true
+	case RECORD_STATUS_TIMEOUT:
+		return j.RetryOnTimeout
+	default:
+		return false
+	}
+}
+
+// Exponential backoff with jitter, capped by `RetryBackoffMax`, `attempt` starts from 1.
+func (j *Job) retryDelay(attempt int) time.Duration {
+	backoff, err := time.ParseDuration(j.RetryBackoff)
+	if err != nil || backoff <= 0 {
+		backoff = time.Second
+	}
+	backoffMax, err := time.ParseDuration(j.RetryBackoffMax)
+	if err != nil || backoffMax <= 0 {
+		backoffMax = 10 * time.Minute
+	}
+
+	delay := backoff << min(attempt-1, 20)
+	if delay <= 0 || delay > backoffMax {
+		delay = backoffMax
+	}
+
+	return delay - time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
 func (j *Job) LastRunTimeWithTimezone() time.Time {
 	timezone, _ := time.LoadLocation(j.Timezone)
 
@@ -188,12 +512,22 @@ func GetNextRunTimeMax() (time.Time, error) {
 func (j Job) String() string {
 	return fmt.Sprintf(
 		"Job{'Id':'%s', 'Name':'%s', 'Type':'%s', 'StartAt':'%s', 'EndAt':'%s', "+
-			"'Interval':'%s', 'CronExpr':'%s', 'Timezone':'%s', "+
+			"'Interval':'%s', 'IntervalMode':'%s', 'CronExpr':'%s', 'RRule':'%s', "+
+			"'Triggers':'%s', 'TriggerMode':'%s', 'Calendar':'%s', 'Timezone':'%s', "+
 			"'FuncName':'%s', 'Args':'%s', 'Timeout':'%s', 'Queues':'%s', 'MaxInstances':'%d', "+
+			"'MisfireGraceTime':'%s', 'Coalesce':'%t', "+
+			"'MaxAttempts':'%d', 'RetryBackoff':'%s', 'RetryBackoffMax':'%s', 'RetryOnTimeout':'%t', 'HeartbeatTimeout':'%s', 'SoftTimeout':'%s', 'SLA':'%s', "+
+			"'Upstreams':'%s', 'WorkflowRunId':'%s', 'Priority':'%d', "+
+			"'RateLimiter':'%s', 'RateLimitPolicy':'%s', 'MaxRuns':'%d', 'Runs':'%d', "+
 			"'LastRunTime':'%s', 'NextRunTime':'%s', 'Status':'%s'}",
 		j.Id, j.Name, j.Type, j.StartAt, j.EndAt,
-		j.Interval, j.CronExpr, j.Timezone,
+		j.Interval, j.IntervalMode, j.CronExpr, j.RRule,
+		j.Triggers, j.TriggerMode, j.Calendar, j.Timezone,
 		j.FuncName, j.Args, j.Timeout, j.Queues, j.MaxInstances,
+		j.MisfireGraceTime, j.IsCoalesce(),
+		j.MaxAttempts, j.RetryBackoff, j.RetryBackoffMax, j.RetryOnTimeout, j.HeartbeatTimeout, j.SoftTimeout, j.SLA,
+		j.Upstreams, j.WorkflowRunId, j.Priority,
+		j.RateLimiter, j.RateLimitPolicy, j.MaxRuns, j.Runs,
 		j.LastRunTimeWithTimezone(), j.NextRunTimeWithTimezone(), j.Status,
 	)
 }
@@ -225,6 +559,28 @@ func JobUnmarshal(bJ []byte) (Job, error) {
 	return j, nil
 }
 
+// Serialize JobRun and convert to Bytes, used as the message of the queues.
+func JobRunMarshal(jr JobRun) ([]byte, error) {
+	return json.Marshal(jr)
+}
+
+// Deserialize Bytes and convert to JobRun,
+// the message of a job pushed by an earlier version is the first attempt of the job.
+func JobRunUnmarshal(bJr []byte) (JobRun, error) {
+	var jr JobRun
+	if err := json.Unmarshal(bJr, &jr); err != nil {
+		return JobRun{}, err
+	}
+	if jr.Job.Id == "" {
+		j, err := JobUnmarshal(bJr)
+		if err != nil {
+			return JobRun{}, err
+		}
+		jr = JobRun{Job: j}
+	}
+	return jr, nil
+}
+
 // Used to gRPC Protobuf
 func JobToPbJobPtr(j Job) (*pb.Job, error) {
 	args, err := structpb.NewStruct(j.Args)
@@ -239,7 +595,12 @@ func JobToPbJobPtr(j Job) (*pb.Job, error) {
 		StartAt:      j.StartAt,
 		EndAt:        j.EndAt,
 		Interval:     j.Interval,
+		IntervalMode: j.IntervalMode,
 		CronExpr:     j.CronExpr,
+		Rrule:        j.RRule,
+		Triggers:     TriggersToPbTriggersPtr(j.Triggers),
+		TriggerMode:  j.TriggerMode,
+		Calendar:     j.Calendar,
 		Timezone:     j.Timezone,
 		FuncName:     j.FuncName,
 		Args:         args,
@@ -247,6 +608,23 @@ func JobToPbJobPtr(j Job) (*pb.Job, error) {
 		Queues:       j.Queues,
 		MaxInstances: int32(j.MaxInstances),
 
+		MisfireGraceTime: j.MisfireGraceTime,
+		Coalesce:         j.Coalesce,
+		MaxAttempts:      int32(j.MaxAttempts),
+		RetryBackoff:     j.RetryBackoff,
+		RetryBackoffMax:  j.RetryBackoffMax,
+		RetryOnTimeout:   j.RetryOnTimeout,
+		HeartbeatTimeout: j.HeartbeatTimeout,
+		SoftTimeout:      j.SoftTimeout,
+		Sla:              j.SLA,
+		Upstreams:        j.Upstreams,
+		WorkflowRunId:    j.WorkflowRunId,
+		Priority:         int32(j.Priority),
+		RateLimiter:      j.RateLimiter,
+		RateLimitPolicy:  j.RateLimitPolicy,
+		MaxRuns:          int32(j.MaxRuns),
+		Runs:             int32(j.Runs),
+
 		LastRunTime: timestamppb.New(j.LastRunTime),
 		NextRunTime: timestamppb.New(j.NextRunTime),
 		Status:      j.Status,
@@ -257,14 +635,19 @@ func JobToPbJobPtr(j Job) (*pb.Job, error) {
 
 // Used to gRPC Protobuf
 func PbJobPtrToJob(pbJob *pb.Job) Job {
-	return Job{
+	j := Job{
 		Id:           pbJob.GetId(),
 		Name:         pbJob.GetName(),
 		Type:         pbJob.GetType(),
 		StartAt:      pbJob.GetStartAt(),
 		EndAt:        pbJob.GetEndAt(),
 		Interval:     pbJob.GetInterval(),
+		IntervalMode: pbJob.GetIntervalMode(),
 		CronExpr:     pbJob.GetCronExpr(),
+		RRule:        pbJob.GetRrule(),
+		Triggers:     PbTriggersPtrToTriggers(pbJob.GetTriggers()),
+		TriggerMode:  pbJob.GetTriggerMode(),
+		Calendar:     pbJob.GetCalendar(),
 		Timezone:     pbJob.GetTimezone(),
 		FuncName:     pbJob.GetFuncName(),
 		Args:         pbJob.GetArgs().AsMap(),
@@ -272,10 +655,28 @@ func PbJobPtrToJob(pbJob *pb.Job) Job {
 		Queues:       pbJob.GetQueues(),
 		MaxInstances: max(1, int(pbJob.GetMaxInstances())),
 
+		MisfireGraceTime: pbJob.GetMisfireGraceTime(),
+		Coalesce:         pbJob.Coalesce,
+		MaxAttempts:      max(1, int(pbJob.GetMaxAttempts())),
+		RetryBackoff:     pbJob.GetRetryBackoff(),
+		RetryBackoffMax:  pbJob.GetRetryBackoffMax(),
+		RetryOnTimeout:   pbJob.GetRetryOnTimeout(),
+		HeartbeatTimeout: pbJob.GetHeartbeatTimeout(),
+		SoftTimeout:      pbJob.GetSoftTimeout(),
+		SLA:              pbJob.GetSla(),
+		Upstreams:        pbJob.GetUpstreams(),
+		WorkflowRunId:    pbJob.GetWorkflowRunId(),
+		Priority:         int(pbJob.GetPriority()),
+		RateLimiter:      pbJob.GetRateLimiter(),
+		RateLimitPolicy:  pbJob.GetRateLimitPolicy(),
+		MaxRuns:          int(pbJob.GetMaxRuns()),
+		Runs:             int(pbJob.GetRuns()),
+
 		LastRunTime: pbJob.GetLastRunTime().AsTime(),
 		NextRunTime: pbJob.GetNextRunTime().AsTime(),
 		Status:      pbJob.GetStatus(),
 	}
+	return j
 }
 
 // Used to gRPC Protobuf
@@ -304,37 +705,3 @@ func PbJobsPtrToJobs(pbJs []*pb.Job) []Job {
 
 	return js
 }
-
-type FuncPkg struct {
-	Func func(context.Context, Job) (result string)
-	// About this function.
-	Info string
-}
-
-// Record the actual path of function and the corresponding function.
-// Since golang can't serialize functions,
-// need to register them with `RegisterFuncs` before using it.
-var FuncMap = make(map[string]FuncPkg)
-
-func FuncMapReadable() []map[string]string {
-	funcs := []map[string]string{}
-
-	for fName, fPkg := range FuncMap {
-		funcs = append(funcs, map[string]string{
-			"name": fName, "info": fPkg.Info,
-		})
-	}
-
-	return funcs
-}
-
-func getFuncName(f func(context.Context, Job) (result string)) string {
-	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
-}
-
-func RegisterFuncs(fps ...FuncPkg) {
-	for _, fp := range fps {
-		fName := getFuncName(fp.Func)
-		FuncMap[fName] = fp
-	}
-}
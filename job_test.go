@@ -2,8 +2,10 @@ package agscheduler
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -32,13 +34,29 @@ func TestJobString(t *testing.T) {
 	typeOfJob := reflect.TypeOf(j)
 	for i := 0; i < typeOfJob.NumField(); i++ {
 		fieldType := typeOfJob.Field(i)
-		if fieldType.Name == "Func" {
+		if fieldType.Type.Kind() == reflect.Func {
 			continue
 		}
 		assert.Contains(t, j.String(), "'"+fieldType.Name+"'")
 	}
 }
 
+func TestJobRetryDelay(t *testing.T) {
+	j := getJob()
+	j.RetryBackoff = "1s"
+
+	for attempt, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		d := j.retryDelay(attempt + 1)
+		assert.GreaterOrEqual(t, d, delay/2)
+		assert.LessOrEqual(t, d, delay)
+	}
+
+	j.RetryBackoffMax = "3s"
+	d := j.retryDelay(10)
+	assert.GreaterOrEqual(t, d, 3*time.Second/2)
+	assert.LessOrEqual(t, d, 3*time.Second)
+}
+
 func TestJobDeepCopy(t *testing.T) {
 	j := getJob()
 	cJ, err := j.DeepCopy()
@@ -76,6 +94,24 @@ func TestJobUnmarshalError(t *testing.T) {
 	assert.Empty(t, j)
 }
 
+func TestJobRunUnmarshal(t *testing.T) {
+	j := getJob()
+	j.Id = "1"
+	bJr, err := JobRunMarshal(JobRun{Job: j, Attempt: 2})
+	assert.NoError(t, err)
+	jr, err := JobRunUnmarshal(bJr)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", jr.Job.Id)
+	assert.Equal(t, 2, jr.Attempt)
+
+	bJ, err := JobMarshal(j)
+	assert.NoError(t, err)
+	jr, err = JobRunUnmarshal(bJ)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", jr.Job.Id)
+	assert.Equal(t, 0, jr.Attempt)
+}
+
 func TestJobToPbJobPtr(t *testing.T) {
 	j := getJob()
 	pbJ, err := JobToPbJobPtr(j)
@@ -119,20 +155,232 @@ func TestPbJobsPtrToJobs(t *testing.T) {
 }
 
 func TestRegisterFuncs(t *testing.T) {
-	assert.Empty(t, FuncMap)
+	funcLen := len(DefaultFuncRegistry.cloneFuncs())
 
 	RegisterFuncs(
 		FuncPkg{Func: func(ctx context.Context, j Job) (result string) { return }},
 	)
 
-	assert.Len(t, FuncMap, 1)
+	assert.Len(t, DefaultFuncRegistry.cloneFuncs(), funcLen+1)
+}
+
+func TestRegisterBuiltinFuncs(t *testing.T) {
+	fr := &FuncRegistry{}
+	_, ok := fr.lookupFunc(getFuncName(RunShell))
+	assert.False(t, ok)
+
+	fr.RegisterBuiltinFuncs()
+
+	_, ok = fr.lookupFunc(getFuncName(RunShell))
+	assert.True(t, ok)
+	_, ok = fr.lookupFunc(getFuncName(RunWebhook))
+	assert.True(t, ok)
+
+	fr.UnregisterBuiltinFuncs()
+
+	_, ok = fr.lookupFunc(getFuncName(RunShell))
+	assert.False(t, ok)
+	_, ok = fr.lookupFunc(getFuncName(RunWebhook))
+	assert.False(t, ok)
+}
+
+func TestBuiltinFuncsRegisteredByDefault(t *testing.T) {
+	_, ok := DefaultFuncRegistry.lookupFunc(getFuncName(RunShell))
+	assert.True(t, ok)
+	_, ok = DefaultFuncRegistry.lookupFunc(getFuncName(RunWebhook))
+	assert.True(t, ok)
+}
+
+func TestRegisterTypedFuncs(t *testing.T) {
+	f := func(ctx context.Context, j Job) (any, error) { return nil, nil }
+	RegisterFuncs(
+		FuncPkg{TypedFunc: f},
+	)
+
+	assert.Contains(t, DefaultFuncRegistry.cloneFuncs(), getFuncName(f))
+
+	j := Job{TypedFunc: f}
+	j.init()
+	assert.Equal(t, getFuncName(f), j.FuncName)
+}
+
+func TestRegisterFuncsName(t *testing.T) {
+	f := func(ctx context.Context, j Job) (result string) { return }
+	fV2 := func(ctx context.Context, j Job) (result string) { return "v2" }
+	fV1_10 := func(ctx context.Context, j Job) (result string) { return "v1.10" }
+	RegisterFuncs(
+		FuncPkg{Func: f, Name: "test_stable", Version: "v1", Aliases: []string{"main.oldFunc"}},
+		FuncPkg{Func: fV2, Name: "test_stable", Version: "v2"},
+		FuncPkg{Func: fV1_10, Name: "test_stable", Version: "v1.10"},
+	)
+
+	assert.Contains(t, DefaultFuncRegistry.cloneFuncs(), "test_stable@v1")
+	assert.Contains(t, DefaultFuncRegistry.cloneFuncs(), "test_stable@v2")
+	assert.NotContains(t, DefaultFuncRegistry.cloneFuncs(), "test_stable")
+
+	for name, expect := range map[string]string{
+		"test_stable@v1":    "test_stable@v1",
+		"main.oldFunc":      "test_stable@v1",
+		getFuncName(f):      "test_stable@v1",
+		"test_stable":       "test_stable@v2",
+		getFuncName(fV2):    "test_stable@v2",
+		"test_stable@v2":    "test_stable@v2",
+		"test_stable@v1.10": "test_stable@v1.10",
+	} {
+		fName, ok := DefaultFuncRegistry.resolveFuncName(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, expect, fName, name)
+	}
+	_, ok := DefaultFuncRegistry.resolveFuncName("main.unknownFunc")
+	assert.False(t, ok)
+
+	j := Job{Name: "Job", Type: JOB_TYPE_INTERVAL, Interval: "1s", Func: f}
+	assert.NoError(t, j.init())
+	assert.NoError(t, j.checkFunc(DefaultFuncRegistry))
+	assert.Equal(t, "test_stable@v1", j.FuncName)
+	j.FuncName = "main.oldFunc"
+	assert.NoError(t, j.checkFunc(DefaultFuncRegistry))
+	assert.Equal(t, "test_stable@v1", j.FuncName)
+
+	for _, fr := range FuncMapReadable() {
+		switch fr["name"] {
+		case "test_stable@v1":
+			assert.Equal(t, "v1", fr["version"])
+			assert.Equal(t, []string{getFuncName(f), "main.oldFunc"}, fr["aliases"])
+		case "test_stable@v1.10":
+			assert.Equal(t, []string{getFuncName(fV1_10)}, fr["aliases"])
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, c := range []struct {
+		a, b   string
+		expect int
+	}{
+		{"v1", "v1", 0},
+		{"v1", "v1.0.0", 0},
+		{"v2", "v1.10", 1},
+		{"v1.9", "v1.10", -1},
+		{"1.2.0", "v1.2.1", -1},
+		{"v1.0.0-rc2", "v1.0.0-rc1", 1},
+	} {
+		assert.Equal(t, c.expect, compareVersions(c.a, c.b), c)
+	}
 }
 
 func TestFuncMapReadable(t *testing.T) {
 	RegisterFuncs(
 		FuncPkg{Func: func(ctx context.Context, j Job) (result string) { return }},
 	)
-	funcLen := len(FuncMap)
+	funcLen := len(DefaultFuncRegistry.cloneFuncs())
 
 	assert.Len(t, FuncMapReadable(), funcLen)
 }
+
+func TestDueJobs(t *testing.T) {
+	now := time.Now().UTC()
+	js := []Job{
+		{Id: "1", NextRunTime: now.Add(-2 * time.Second)},
+		{Id: "2", NextRunTime: now.Add(time.Second), Priority: 10},
+		{Id: "3", NextRunTime: now.Add(-time.Second), Priority: 5},
+		{Id: "4", NextRunTime: now.Add(-3 * time.Second)},
+		{Id: "5", NextRunTime: now.Add(-time.Second), Priority: -1},
+	}
+
+	ids := []string{}
+	for _, j := range dueJobs(js, now) {
+		ids = append(ids, j.Id)
+	}
+	assert.Equal(t, []string{"3", "4", "1", "5"}, ids)
+}
+
+func TestCalcNextRunTimeEndAt(t *testing.T) {
+	now := time.Date(2026, 10, 18, 7, 30, 0, 0, time.UTC)
+	j := Job{
+		Name: "Job", Type: JOB_TYPE_INTERVAL, Interval: "1m", Timezone: "UTC",
+		EndAt: now.Add(90 * time.Second).Format(time.DateTime), Status: JOB_STATUS_RUNNING,
+	}
+	var jeErr JobEndedError
+
+	nextRunTime, err := calcNextRunTime(j, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Minute), nextRunTime)
+	// `AddJob` rejects the job that has passed its `EndAt`.
+	_, err = calcNextRunTime(j, now.Add(time.Minute))
+	assert.ErrorAs(t, err, &jeErr)
+
+	// The paused job is due at its `EndAt`, and it ends when it has passed it.
+	j.Status = JOB_STATUS_PAUSED
+	nextRunTime, err = calcNextRunTime(j, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(90*time.Second), nextRunTime)
+	_, err = calcNextRunTime(j, now.Add(90*time.Second))
+	assert.ErrorAs(t, err, &jeErr)
+
+	j.EndAt = ""
+	nextRunTime, err = calcNextRunTime(j, now)
+	assert.NoError(t, err)
+	nextRunTimeMax, _ := GetNextRunTimeMax()
+	assert.Equal(t, nextRunTimeMax.UTC(), nextRunTime)
+}
+
+func TestCalcDueRunTimes(t *testing.T) {
+	s := &Scheduler{}
+	now := time.Now().UTC().Truncate(time.Second).Add(500 * time.Millisecond)
+	nextRunTime := now.Add(-24 * time.Hour).Truncate(time.Second)
+	coalesce := false
+	j := Job{
+		Type: JOB_TYPE_INTERVAL, Interval: "1s", Timezone: "UTC",
+		StartAt: nextRunTime.Format(time.DateTime), NextRunTime: nextRunTime, Coalesce: &coalesce,
+	}
+
+	runTimes := s.calcDueRunTimes(j, now)
+	assert.Len(t, runTimes, dueRunTimesMax+1)
+	assert.Equal(t, nextRunTime, runTimes[0])
+	assert.Equal(t, nextRunTime.Add(time.Duration(dueRunTimesMax-1)*time.Second), runTimes[dueRunTimesMax-1])
+	assert.Equal(t, now.Truncate(time.Second), runTimes[dueRunTimesMax])
+
+	// No run time is dropped up to `dueRunTimesMax`+1 due run times.
+	for _, n := range []int{dueRunTimesMax, dueRunTimesMax + 1, dueRunTimesMax + 2} {
+		nextRunTime := now.Truncate(time.Second).Add(-time.Duration(n-1) * time.Second)
+		j := Job{
+			Type: JOB_TYPE_INTERVAL, Interval: "1s", Timezone: "UTC",
+			StartAt: nextRunTime.Format(time.DateTime), NextRunTime: nextRunTime, Coalesce: &coalesce,
+		}
+		runTimes := s.calcDueRunTimes(j, now)
+		assert.Len(t, runTimes, min(n, dueRunTimesMax+1), n)
+		assert.Equal(t, nextRunTime.Add(time.Duration(dueRunTimesMax-1)*time.Second), runTimes[dueRunTimesMax-1], n)
+		assert.Equal(t, now.Truncate(time.Second), runTimes[len(runTimes)-1], n)
+	}
+
+	coalesce = true
+	assert.Equal(t, []time.Time{now.Truncate(time.Second)}, s.calcDueRunTimes(j, now))
+
+	j = Job{Type: JOB_TYPE_CRON, CronExpr: "*/5 * * * *", Timezone: "UTC", NextRunTime: now.Add(-30 * 24 * time.Hour).Truncate(time.Hour)}
+	runTimes = s.calcDueRunTimes(j, now)
+	assert.Len(t, runTimes, 1)
+	assert.Equal(t, now.Truncate(5*time.Minute), runTimes[0])
+}
+
+type errorMetadataBackend struct {
+	Backend
+}
+
+func (b *errorMetadataBackend) Init() error { return nil }
+
+func (b *errorMetadataBackend) RecordMetadata(r Record) error {
+	return errors.New("backend unavailable")
+}
+
+func TestRunJobAttemptRecordMetadataError(t *testing.T) {
+	s := &Scheduler{}
+	s.init()
+	s.recorder = &Recorder{Backend: &errorMetadataBackend{}}
+	assert.NoError(t, s.recorder.init())
+	j := getJob()
+
+	status, result, _, _, _ := s._runJobAttempt(JobRun{Job: j, Attempt: 1}, reflect.ValueOf(j.Func), time.Second, "")
+	assert.Equal(t, RECORD_STATUS_ERROR, status)
+	assert.Contains(t, result, "backend unavailable")
+}
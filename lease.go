@@ -0,0 +1,201 @@
+package agscheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The lease of a run expires after `leaseTTL` unless it is renewed,
+// so the instances held by a dead node are released.
+const (
+	leaseTTL           = 30 * time.Second
+	leaseRenewInterval = 10 * time.Second
+)
+
+// When the lease cannot be acquired due to an error, e.g. the store is unavailable,
+// it is retried before the run is recorded as `RECORD_STATUS_ERROR`.
+const (
+	leaseAcquireRetries       = 3
+	leaseAcquireRetryInterval = time.Second
+)
+
+// A lease held by a run of the job, at most `Max` leases are held for the same job across the cluster.
+type Lease struct {
+	JobId   string
+	LeaseId string
+	Max     int
+	TTL     time.Duration
+}
+
+// Leases kept in memory, used when the store does not implement `LeaseStore`.
+// In cluster mode, they are kept by the main node.
+type leaseTable struct {
+	// Expiration time keyed by job id and lease id.
+	leases map[string]map[string]time.Time
+	m      sync.Mutex
+}
+
+func newLeaseTable() *leaseTable {
+	return &leaseTable{leases: make(map[string]map[string]time.Time)}
+}
+
+func (lt *leaseTable) acquire(l Lease) bool {
+	lt.m.Lock()
+	defer lt.m.Unlock()
+
+	now := time.Now()
+	ls := lt.leases[l.JobId]
+	for id, expireAt := range ls {
+		if now.After(expireAt) {
+			delete(ls, id)
+		}
+	}
+	if len(ls) >= l.Max {
+		return false
+	}
+
+	if ls == nil {
+		ls = make(map[string]time.Time)
+		lt.leases[l.JobId] = ls
+	}
+	ls[l.LeaseId] = now.Add(l.TTL)
+
+	return true
+}
+
+// The lease is added again if it has expired or was lost, e.g. the main node changed.
+func (lt *leaseTable) renew(l Lease) {
+	lt.m.Lock()
+	defer lt.m.Unlock()
+
+	if _, ok := lt.leases[l.JobId]; !ok {
+		lt.leases[l.JobId] = make(map[string]time.Time)
+	}
+	lt.leases[l.JobId][l.LeaseId] = time.Now().Add(l.TTL)
+}
+
+func (lt *leaseTable) release(l Lease) {
+	lt.m.Lock()
+	defer lt.m.Unlock()
+
+	delete(lt.leases[l.JobId], l.LeaseId)
+	if len(lt.leases[l.JobId]) == 0 {
+		delete(lt.leases, l.JobId)
+	}
+}
+
+func newLease(j Job) Lease {
+	return Lease{
+		JobId:   j.Id,
+		LeaseId: strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Max:     j.MaxInstances,
+		TTL:     leaseTTL,
+	}
+}
+
+// Acquire a lease for the run of the job,
+// in the store if it implements `LeaseStore`, otherwise from the main node in cluster mode.
+//
+//	@return false, nil, if `Job.MaxInstances` is reached.
+func (s *Scheduler) acquireLease(l Lease) (bool, error) {
+	if ls, ok := s.store.(LeaseStore); ok {
+		return ls.AcquireLease(l.JobId, l.LeaseId, l.Max, l.TTL)
+	}
+	if s.IsClusterMode() && !s.clusterNode.IsMainNode() {
+		return s.clusterNode.acquireLeaseRemote(l)
+	}
+
+	return s.leases.acquire(l), nil
+}
+
+// Acquire the lease, and retry it when it fails due to an error.
+// The wait between the retries is interrupted by `Stop`,
+// since the broker worker running the job waits for it.
+func (s *Scheduler) acquireLeaseRetry(j Job, l Lease) (bool, error) {
+	ok, err := s.acquireLease(l)
+	if err == nil {
+		return ok, nil
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	s.addRetryCancel(0, &cancel)
+	defer s.deleteRetryCancel(0, &cancel)
+
+	for range leaseAcquireRetries {
+		slog.Warn(fmt.Sprintf("Job `%s` acquire lease error: %s, retry in %s", j.FullName(), err, leaseAcquireRetryInterval))
+		timer := time.NewTimer(leaseAcquireRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false, fmt.Errorf("%s, %s", err, context.Cause(ctx))
+		case <-timer.C:
+		}
+
+		ok, err = s.acquireLease(l)
+		if err == nil {
+			return ok, nil
+		}
+	}
+
+	return false, err
+}
+
+func (s *Scheduler) renewLease(l Lease) error {
+	if ls, ok := s.store.(LeaseStore); ok {
+		return ls.RenewLease(l.JobId, l.LeaseId, l.TTL)
+	}
+	if s.IsClusterMode() && !s.clusterNode.IsMainNode() {
+		return s.clusterNode.leaseRemote("CRPCService.RenewLease", l)
+	}
+
+	s.leases.renew(l)
+	return nil
+}
+
+func (s *Scheduler) releaseLease(l Lease) error {
+	if ls, ok := s.store.(LeaseStore); ok {
+		return ls.ReleaseLease(l.JobId, l.LeaseId)
+	}
+	if s.IsClusterMode() && !s.clusterNode.IsMainNode() {
+		return s.clusterNode.leaseRemote("CRPCService.ReleaseLease", l)
+	}
+
+	s.leases.release(l)
+	return nil
+}
+
+// Renew the lease until the run ends, then release it.
+//
+//	@return the function called when the run ends.
+func (s *Scheduler) holdLease(j Job, l Lease) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(leaseRenewInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.renewLease(l); err != nil {
+					slog.Error(fmt.Sprintf("Job `%s` renew lease error: %s", j.FullName(), err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		if err := s.releaseLease(l); err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` release lease error: %s", j.FullName(), err))
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package agscheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaseTable(t *testing.T) {
+	lt := newLeaseTable()
+
+	assert.True(t, lt.acquire(Lease{JobId: "1", LeaseId: "1", Max: 2, TTL: time.Minute}))
+	assert.True(t, lt.acquire(Lease{JobId: "1", LeaseId: "2", Max: 2, TTL: 50 * time.Millisecond}))
+	assert.False(t, lt.acquire(Lease{JobId: "1", LeaseId: "3", Max: 2, TTL: time.Minute}))
+	assert.True(t, lt.acquire(Lease{JobId: "2", LeaseId: "4", Max: 1, TTL: time.Minute}))
+
+	// The expired lease is released.
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, lt.acquire(Lease{JobId: "1", LeaseId: "3", Max: 2, TTL: time.Minute}))
+
+	lt.release(Lease{JobId: "2", LeaseId: "4"})
+	assert.NotContains(t, lt.leases, "2")
+
+	// The lost lease is added again.
+	lt.renew(Lease{JobId: "2", LeaseId: "4", TTL: time.Minute})
+	assert.False(t, lt.acquire(Lease{JobId: "2", LeaseId: "5", Max: 1, TTL: time.Minute}))
+}
+
+type errorLeaseStore struct {
+	Store
+}
+
+func (s *errorLeaseStore) AcquireLease(jId, leaseId string, limit int, ttl time.Duration) (bool, error) {
+	return false, errors.New("store unavailable")
+}
+
+func (s *errorLeaseStore) RenewLease(jId, leaseId string, ttl time.Duration) error { return nil }
+
+func (s *errorLeaseStore) ReleaseLease(jId, leaseId string) error { return nil }
+
+func TestAcquireLeaseRetryCancelled(t *testing.T) {
+	s := &Scheduler{}
+	s.init()
+	s.store = &errorLeaseStore{}
+	j := Job{Id: "1", Name: "Job", MaxInstances: 1}
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := s.acquireLeaseRetry(j, newLease(j))
+		errChan <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	s.cancelRetries()
+
+	select {
+	case err := <-errChan:
+		assert.ErrorContains(t, err, errRetryCancelled.Error())
+	case <-time.After(500 * time.Millisecond):
+		assert.Fail(t, "lease retry not interrupted")
+	}
+}
@@ -23,13 +23,26 @@ const (
 	EVENT_JOB_ERROR
 	EVENT_JOB_TIMEOUT
 	EVENT_JOB_MAX_INSTANCES
+	EVENT_JOB_ENDED
+	EVENT_JOB_MISSED
+	EVENT_JOB_RETRIES_EXHAUSTED
+	EVENT_JOB_MAX_RUNS_REACHED
+	EVENT_JOB_CANCELLED
+	EVENT_JOB_PROGRESS
+	EVENT_JOB_STALLED
+	EVENT_JOB_SOFT_TIMEOUT
+	EVENT_JOB_SLA_MISSED
+	EVENT_JOB_RATE_LIMITED
 
 	EVENT_ALL event = EVENT_SCHEDULER_STARTED | EVENT_SCHEDULER_STOPPED |
 		EVENT_JOB_ADDED | EVENT_JOB_UPDATED |
 		EVENT_JOB_DELETED | EVENT_ALL_JOBS_DELETED |
 		EVENT_JOB_PAUSED | EVENT_JOB_RESUMED |
 		EVENT_JOB_EXECUTED | EVENT_JOB_ERROR | EVENT_JOB_TIMEOUT |
-		EVENT_JOB_MAX_INSTANCES
+		EVENT_JOB_MAX_INSTANCES | EVENT_JOB_ENDED | EVENT_JOB_MISSED |
+		EVENT_JOB_RETRIES_EXHAUSTED | EVENT_JOB_MAX_RUNS_REACHED |
+		EVENT_JOB_CANCELLED | EVENT_JOB_PROGRESS | EVENT_JOB_STALLED |
+		EVENT_JOB_SOFT_TIMEOUT | EVENT_JOB_SLA_MISSED | EVENT_JOB_RATE_LIMITED
 )
 
 type EventPkg struct {
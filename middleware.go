@@ -0,0 +1,34 @@
+package agscheduler
+
+import (
+	"context"
+)
+
+// Run one attempt of the job and return the status and result to be recorded,
+// `recordId` is 0 when the recorder does not exist.
+type RunHandler func(ctx context.Context, j Job, recordId uint64) (status string, result string)
+
+// Wrap the run of each attempt, e.g. locks, metrics, panic reporting or args decryption.
+// It can change the context and job passed to `next`, and the status and result returned by `next`,
+// or return without calling `next` to short-circuit the run.
+// It runs on the node that runs the job, in standalone, broker and cluster mode.
+type Middleware func(next RunHandler) RunHandler
+
+// Add middlewares to wrap the job runs, the first one added is the outermost.
+func (s *Scheduler) Use(mws ...Middleware) {
+	s.middlewareM.Lock()
+	defer s.middlewareM.Unlock()
+
+	s.middlewares = append(s.middlewares, mws...)
+}
+
+func (s *Scheduler) applyMiddlewares(h RunHandler) RunHandler {
+	s.middlewareM.RLock()
+	defer s.middlewareM.RUnlock()
+
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		h = s.middlewares[i](h)
+	}
+
+	return h
+}
@@ -0,0 +1,193 @@
+package agscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Heartbeats and progress reports without changes are persisted at most once in this interval.
+const heartbeatRecordInterval = time.Second
+
+// The progress of the current attempt, shared by `ReportProgress`, `Heartbeat` and the stall watcher.
+type runProgress struct {
+	s        *Scheduler
+	j        Job
+	recordId uint64
+
+	p Progress
+	// The time the progress is last persisted.
+	recordedAt time.Time
+	// Set when the progress changes while it is being persisted.
+	dirty bool
+	// Set while a goroutine persists the progress.
+	recording bool
+
+	m sync.Mutex
+}
+
+type runProgressKey struct{}
+
+func (s *Scheduler) withRunProgress(ctx context.Context, j Job, recordId uint64) (context.Context, *runProgress) {
+	rp := &runProgress{s: s, j: j, recordId: recordId}
+	rp.p.HeartbeatAt = time.Now().UTC()
+
+	return context.WithValue(ctx, runProgressKey{}, rp), rp
+}
+
+// Report the progress of the current run, `ctx` is the context passed to `Func`.
+// The progress is saved to `Record.Progress`, and `EVENT_JOB_PROGRESS` is dispatched when it changes.
+// `fields` must be encodable as JSON, they are saved as decoded from JSON, e.g. numbers become `float64`.
+// It is also a heartbeat.
+func ReportProgress(ctx context.Context, percent float64, message string, fields map[string]any) error {
+	rp, ok := ctx.Value(runProgressKey{}).(*runProgress)
+	if !ok {
+		return ErrNotInRun
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("progress percent `%v` must be between 0 and 100", percent)
+	}
+	fields, err := normalizeProgressFields(fields)
+	if err != nil {
+		return err
+	}
+
+	rp.m.Lock()
+	changed := rp.p.Percent != percent || rp.p.Message != message || !reflect.DeepEqual(rp.p.Fields, fields)
+	stalled := rp.p.Stalled
+	rp.p.Percent = percent
+	rp.p.Message = message
+	rp.p.Fields = fields
+	rp.p.HeartbeatAt = time.Now().UTC()
+	rp.p.Stalled = false
+	p := rp.p
+	skip := !changed && !stalled && rp.p.HeartbeatAt.Sub(rp.recordedAt) < heartbeatRecordInterval
+	rp.m.Unlock()
+
+	if skip {
+		return nil
+	}
+	if err := rp.record(); err != nil {
+		return err
+	}
+	if changed {
+		rp.s.dispatchEvent(EventPkg{EVENT_JOB_PROGRESS, rp.j.Id, p})
+	}
+
+	return nil
+}
+
+// Round-trip the fields through JSON, so they are saved and converted to gRPC in the same way by all backends,
+// the values that cannot be encoded as JSON are rejected.
+func normalizeProgressFields(fields map[string]any) (map[string]any, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	bFields, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("progress fields error: %s", err)
+	}
+	var nFields map[string]any
+	if err := json.Unmarshal(bFields, &nFields); err != nil {
+		return nil, fmt.Errorf("progress fields error: %s", err)
+	}
+
+	return nFields, nil
+}
+
+// Report that the current run is alive, `ctx` is the context passed to `Func`.
+// Call it more often than `Job.HeartbeatTimeout`, or the run is flagged as stalled.
+func Heartbeat(ctx context.Context) error {
+	rp, ok := ctx.Value(runProgressKey{}).(*runProgress)
+	if !ok {
+		return ErrNotInRun
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	rp.m.Lock()
+	stalled := rp.p.Stalled
+	rp.p.HeartbeatAt = time.Now().UTC()
+	rp.p.Stalled = false
+	skip := !stalled && rp.p.HeartbeatAt.Sub(rp.recordedAt) < heartbeatRecordInterval
+	rp.m.Unlock()
+
+	if skip {
+		return nil
+	}
+
+	return rp.record()
+}
+
+// Flag the run as stalled when no heartbeat is received within the timeout,
+// until the context is done.
+func (rp *runProgress) watch(ctx context.Context, timeout time.Duration) {
+	ticker := time.NewTicker(max(timeout/4, 10*time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rp.checkStalled(timeout)
+		}
+	}
+}
+
+func (rp *runProgress) checkStalled(timeout time.Duration) {
+	rp.m.Lock()
+	if rp.p.Stalled || time.Since(rp.p.HeartbeatAt) < timeout {
+		rp.m.Unlock()
+		return
+	}
+	slog.Warn(fmt.Sprintf("Job `%s` run stalled, no heartbeat since `%s`", rp.j.FullName(), rp.p.HeartbeatAt))
+	rp.p.Stalled = true
+	p := rp.p
+	rp.m.Unlock()
+
+	if err := rp.record(); err != nil {
+		slog.Error(fmt.Sprintf("Job `%s` record progress error: `%s`", rp.j.FullName(), err))
+	}
+	rp.s.dispatchEvent(EventPkg{EVENT_JOB_STALLED, rp.j.Id, p})
+}
+
+// Persist the progress when the recorder exists.
+// The progress is copied under the lock and saved outside it, so `Func` is not blocked by the recorder.
+// Only one goroutine saves at a time, the others mark the progress as dirty and return,
+// and the saving goroutine saves again until the latest progress is saved, so the saves stay in order.
+func (rp *runProgress) record() error {
+	rp.m.Lock()
+	defer rp.m.Unlock()
+
+	rp.dirty = true
+	if rp.recording {
+		return nil
+	}
+	rp.recording = true
+	defer func() { rp.recording = false }()
+
+	var err error
+	for rp.dirty {
+		rp.dirty = false
+		rp.recordedAt = time.Now().UTC()
+		p := rp.p
+		if !rp.s.HasRecorder() || rp.recordId == 0 {
+			continue
+		}
+
+		rp.m.Unlock()
+		err = rp.s.recorder.RecordProgress(rp.recordId, p)
+		rp.m.Lock()
+	}
+
+	return err
+}
@@ -0,0 +1,62 @@
+package agscheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type slowProgressBackend struct {
+	Backend
+
+	ps []Progress
+	m  sync.Mutex
+}
+
+func (b *slowProgressBackend) Init() error { return nil }
+
+func (b *slowProgressBackend) RecordProgress(id uint64, p Progress) error {
+	time.Sleep(100 * time.Millisecond)
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.ps = append(b.ps, p)
+	return nil
+}
+
+func (b *slowProgressBackend) progresses() []Progress {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return append([]Progress{}, b.ps...)
+}
+
+func TestRunProgressRecord(t *testing.T) {
+	b := &slowProgressBackend{}
+	s := &Scheduler{}
+	s.init()
+	s.recorder = &Recorder{Backend: b}
+	assert.NoError(t, s.recorder.init())
+	ctx, _ := s.withRunProgress(context.Background(), getJob(), 1)
+
+	go func() { _ = ReportProgress(ctx, 10, "", nil) }()
+	time.Sleep(20 * time.Millisecond)
+
+	// The reports are not blocked while the progress is saved, the latest one is saved last.
+	start := time.Now()
+	assert.NoError(t, ReportProgress(ctx, 20, "", nil))
+	assert.NoError(t, ReportProgress(ctx, 30, "", nil))
+	assert.NoError(t, Heartbeat(ctx))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	time.Sleep(300 * time.Millisecond)
+	ps := b.progresses()
+	assert.Len(t, ps, 2)
+	assert.Equal(t, 30.0, ps[len(ps)-1].Percent)
+
+	// The unchanged progress is throttled like the heartbeats.
+	assert.NoError(t, ReportProgress(ctx, 30, "", nil))
+	assert.Len(t, b.progresses(), 2)
+}
@@ -1,5 +1,17 @@
 package queues
 
-import "context"
+import (
+	"context"
+	"strconv"
+)
 
 var ctx = context.Background()
+
+// The record id of the run to cancel is pushed as a decimal string.
+func marshalCancel(recordId uint64) []byte {
+	return []byte(strconv.FormatUint(recordId, 10))
+}
+
+func unmarshalCancel(b []byte) (uint64, error) {
+	return strconv.ParseUint(string(b), 10, 64)
+}
@@ -71,3 +71,57 @@ func runTest(t *testing.T, brk *agscheduler.Broker) {
 	err = sto.Clear()
 	assert.NoError(t, err)
 }
+
+func runPriorityTest(t *testing.T, q agscheduler.Queue) {
+	ctx, cancel := context.WithCancel(ctx)
+	err := q.Init(ctx)
+	assert.NoError(t, err)
+
+	pq := q.(agscheduler.PriorityQueue)
+	for _, pj := range []struct {
+		bJ       string
+		priority int
+	}{{"low", 0}, {"low2", 0}, {"high", 5}} {
+		err := pq.PushPriorityJob([]byte(pj.bJ), pj.priority)
+		assert.NoError(t, err)
+	}
+
+	bJs := []string{}
+	for range 3 {
+		select {
+		case bJ := <-q.PullJob():
+			bJs = append(bJs, string(bJ))
+		case <-time.After(3 * time.Second):
+			assert.Fail(t, "job not delivered")
+		}
+	}
+	// The first job may have been delivered before the others are pushed.
+	if assert.Len(t, bJs, 3) {
+		assert.Equal(t, "low2", bJs[2])
+	}
+
+	cancel()
+	err = q.Clear()
+	assert.NoError(t, err)
+}
+
+func runCancelTest(t *testing.T, q agscheduler.Queue) {
+	ctx, cancel := context.WithCancel(ctx)
+	err := q.Init(ctx)
+	assert.NoError(t, err)
+
+	cq := q.(agscheduler.CancelQueue)
+	err = cq.PushCancel(1)
+	assert.NoError(t, err)
+
+	select {
+	case recordId := <-cq.PullCancel():
+		assert.Equal(t, uint64(1), recordId)
+	case <-time.After(3 * time.Second):
+		assert.Fail(t, "cancel not delivered")
+	}
+
+	cancel()
+	err = q.Clear()
+	assert.NoError(t, err)
+}
@@ -20,6 +20,8 @@ const (
 )
 
 // Queue jobs in Kafka.
+// Job priority is not supported.
+// Broadcasting the cancellations of runs is not supported.
 //
 // Producer and consumer must be separated,
 // otherwise the offset will be fetched incorrectly.
@@ -1,15 +1,33 @@
 package queues
 
-import "context"
+import (
+	"context"
+	"slices"
+	"sync"
+)
 
-// Queue jobs in an channel in RAM.
+// Queue jobs in RAM, the jobs with higher priority are delivered first.
 // Provides no persistence support.
 // Cluster mode is not supported.
 type MemoryQueue struct {
-	// Size of the channel.
+	// Maximum number of jobs in the queue, pushing blocks when it is full.
 	// Default: `32`
 	Size int
-	jobC chan []byte
+
+	// Sorted by priority descending, then by push order.
+	jobs  []*memoryJob
+	jobsM sync.Mutex
+	// Bound the number of jobs to `Size`.
+	slots chan struct{}
+	// Notify the dispatcher that a job is pushed.
+	pushedC chan struct{}
+	jobC    chan []byte
+	cancel  context.CancelFunc
+}
+
+type memoryJob struct {
+	bJ       []byte
+	priority int
 }
 
 func (q *MemoryQueue) Name() string {
@@ -21,27 +39,97 @@ func (q *MemoryQueue) Init(ctx context.Context) error {
 		q.Size = 32
 	}
 
-	q.jobC = make(chan []byte, q.Size)
+	q.slots = make(chan struct{}, q.Size)
+	q.pushedC = make(chan struct{}, 1)
+	q.jobC = make(chan []byte)
+
+	ctx, q.cancel = context.WithCancel(ctx)
+	go q.dispatch(ctx)
 
 	return nil
 }
 
 func (q *MemoryQueue) PushJob(bJ []byte) error {
-	q.jobC <- bJ
+	return q.PushPriorityJob(bJ, 0)
+}
+
+func (q *MemoryQueue) PushPriorityJob(bJ []byte, priority int) error {
+	q.slots <- struct{}{}
+
+	q.jobsM.Lock()
+	i, _ := slices.BinarySearchFunc(q.jobs, priority, func(e *memoryJob, p int) int {
+		// Put after the jobs with the same priority.
+		if e.priority >= p {
+			return -1
+		}
+		return 1
+	})
+	q.jobs = slices.Insert(q.jobs, i, &memoryJob{bJ: bJ, priority: priority})
+	q.jobsM.Unlock()
+
+	select {
+	case q.pushedC <- struct{}{}:
+	default:
+	}
 
 	return nil
 }
 
+// Hand the job with the highest priority to a worker,
+// a job pushed meanwhile is considered before the handoff.
+func (q *MemoryQueue) dispatch(ctx context.Context) {
+	for {
+		q.jobsM.Lock()
+		var mj *memoryJob
+		if len(q.jobs) > 0 {
+			mj = q.jobs[0]
+		}
+		q.jobsM.Unlock()
+
+		if mj == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.pushedC:
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.pushedC:
+		case q.jobC <- mj.bJ:
+			q.jobsM.Lock()
+			q.jobs = slices.DeleteFunc(q.jobs, func(e *memoryJob) bool { return e == mj })
+			q.jobsM.Unlock()
+			<-q.slots
+		}
+	}
+}
+
 func (q *MemoryQueue) PullJob() <-chan []byte {
 	return q.jobC
 }
 
 func (q *MemoryQueue) CountJobs() (int, error) {
-	return len(q.jobC), nil
+	q.jobsM.Lock()
+	defer q.jobsM.Unlock()
+
+	return len(q.jobs), nil
 }
 
 func (q *MemoryQueue) Clear() error {
-	defer close(q.jobC)
+	q.cancel()
+
+	q.jobsM.Lock()
+	defer q.jobsM.Unlock()
+
+	// Free the slots of the dropped jobs, so that pushing does not block.
+	for range q.jobs {
+		<-q.slots
+	}
+	q.jobs = nil
 
 	return nil
 }
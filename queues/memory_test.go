@@ -2,6 +2,9 @@ package queues
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 
 	"github.com/agscheduler/agscheduler"
 )
@@ -19,3 +22,52 @@ func TestMemoryQueue(t *testing.T) {
 
 	runTest(t, broker)
 }
+
+func TestMemoryQueuePriority(t *testing.T) {
+	mq := &MemoryQueue{}
+	err := mq.Init(ctx)
+	assert.NoError(t, err)
+	defer func() {
+		err = mq.Clear()
+		assert.NoError(t, err)
+	}()
+
+	for _, pj := range []struct {
+		bJ       string
+		priority int
+	}{{"low", 0}, {"high", 5}, {"high2", 5}, {"lowest", -1}} {
+		err := mq.PushPriorityJob([]byte(pj.bJ), pj.priority)
+		assert.NoError(t, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	count, err := mq.CountJobs()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	bJs := []string{}
+	for range 4 {
+		bJs = append(bJs, string(<-mq.PullJob()))
+	}
+	assert.Equal(t, []string{"high", "high2", "low", "lowest"}, bJs)
+}
+
+func TestMemoryQueueClear(t *testing.T) {
+	mq := &MemoryQueue{Size: 1}
+	err := mq.Init(ctx)
+	assert.NoError(t, err)
+
+	err = mq.PushJob([]byte("job"))
+	assert.NoError(t, err)
+	err = mq.Clear()
+	assert.NoError(t, err)
+
+	pushed := make(chan error)
+	go func() { pushed <- mq.PushJob([]byte("job")) }()
+	select {
+	case err := <-pushed:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "push blocked after clear")
+	}
+}
@@ -14,17 +14,22 @@ import (
 const (
 	MQTT_TOPIC_PREFIX = "$share/agscheduler/"
 	MQTT_TOPIC        = "topic"
+	MQTT_CANCEL_TOPIC = "agscheduler_cancel"
 )
 
 // Queue jobs in MQTT.
+// Job priority is not supported.
 // History jobs are not supported.
 type MqttQueue struct {
 	Cli         mqtt.Client
 	TopicPrefix string
 	Topic       string
+	// Subscribed by every client, not shared, to receive the cancellations of runs.
+	CancelTopic string
 
-	size int
-	jobC chan []byte
+	size    int
+	jobC    chan []byte
+	cancelC chan uint64
 }
 
 func (q *MqttQueue) Name() string {
@@ -38,9 +43,13 @@ func (q *MqttQueue) Init(ctx context.Context) error {
 	if q.Topic == "" {
 		q.Topic = MQTT_TOPIC
 	}
+	if q.CancelTopic == "" {
+		q.CancelTopic = MQTT_CANCEL_TOPIC
+	}
 
 	q.size = int(math.Abs(float64(q.size)))
 	q.jobC = make(chan []byte, q.size)
+	q.cancelC = make(chan uint64, q.size)
 
 	topic, err := url.JoinPath(MQTT_TOPIC_PREFIX, q.Topic)
 	if err != nil {
@@ -49,6 +58,9 @@ func (q *MqttQueue) Init(ctx context.Context) error {
 	if t := q.Cli.Subscribe(topic, 2, q.handleMessage); t.Wait() && t.Error() != nil {
 		return fmt.Errorf("failed to subscribe `%s`: %s", t, t.Error())
 	}
+	if t := q.Cli.Subscribe(q.CancelTopic, 1, q.handleCancel); t.Wait() && t.Error() != nil {
+		return fmt.Errorf("failed to subscribe `%s`: %s", q.CancelTopic, t.Error())
+	}
 
 	return nil
 }
@@ -65,6 +77,18 @@ func (q *MqttQueue) PullJob() <-chan []byte {
 	return q.jobC
 }
 
+func (q *MqttQueue) PushCancel(recordId uint64) error {
+	if t := q.Cli.Publish(q.CancelTopic, 1, false, marshalCancel(recordId)); t.Wait() && t.Error() != nil {
+		return t.Error()
+	}
+
+	return nil
+}
+
+func (q *MqttQueue) PullCancel() <-chan uint64 {
+	return q.cancelC
+}
+
 func (q *MqttQueue) CountJobs() (int, error) {
 	return -1, nil
 }
@@ -72,6 +96,10 @@ func (q *MqttQueue) CountJobs() (int, error) {
 func (q *MqttQueue) Clear() error {
 	defer close(q.jobC)
 
+	if t := q.Cli.Unsubscribe(q.CancelTopic); t.Wait() && t.Error() != nil {
+		return t.Error()
+	}
+
 	return nil
 }
 
@@ -85,3 +113,13 @@ func (q *MqttQueue) handleMessage(c mqtt.Client, msg mqtt.Message) {
 
 	q.jobC <- msg.Payload()
 }
+
+func (q *MqttQueue) handleCancel(c mqtt.Client, msg mqtt.Message) {
+	recordId, err := unmarshalCancel(msg.Payload())
+	if err != nil {
+		slog.Error(fmt.Sprintf("MqttQueue cancel `%s` error: `%s`", msg.Payload(), err))
+		return
+	}
+
+	q.cancelC <- recordId
+}
@@ -32,4 +32,10 @@ func TestMqttQueue(t *testing.T) {
 	}
 
 	runTest(t, broker)
+
+	runCancelTest(t, &MqttQueue{
+		Cli:         c,
+		Topic:       "test_cancel_topic",
+		CancelTopic: "agscheduler_test_cancel",
+	})
 }
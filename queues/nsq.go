@@ -20,6 +20,8 @@ const (
 )
 
 // Queue jobs in NSQ.
+// Job priority is not supported.
+// Broadcasting the cancellations of runs is not supported.
 type NsqQueue struct {
 	Producer *nsq.Producer
 	Consumer *nsq.Consumer
@@ -17,6 +17,7 @@ import (
 const (
 	RABBITMQ_EXCHANGE = "agscheduler_exchange"
 	RABBITMQ_QUEUE    = "agscheduler_queue"
+	RABBITMQ_CANCEL   = "agscheduler_cancel_exchange"
 )
 
 // Queue jobs in RabbitMQ.
@@ -27,11 +28,21 @@ type RabbitMQQueue struct {
 	HttpAddr string
 	Username string
 	Password string
+	// If greater than 0, the queue is declared with `x-max-priority`,
+	// and the jobs with higher priority are delivered first.
+	// The priority of the job is limited to between 0 and `MaxPriority`.
+	// An existing queue must be deleted to change it.
+	// Optional: `1` ~ `255`
+	MaxPriority int
+	// The fanout exchange of the cancellations of runs,
+	// each scheduler binds an exclusive queue to receive them.
+	CancelExchange string
 
 	ch *amqp.Channel
 
-	size int
-	jobC chan []byte
+	size    int
+	jobC    chan []byte
+	cancelC chan uint64
 }
 
 func (q *RabbitMQQueue) Name() string {
@@ -45,9 +56,22 @@ func (q *RabbitMQQueue) Init(ctx context.Context) error {
 	if q.Queue == "" {
 		q.Queue = RABBITMQ_QUEUE
 	}
+	if q.CancelExchange == "" {
+		q.CancelExchange = RABBITMQ_CANCEL
+	}
+
+	if q.MaxPriority < 0 || q.MaxPriority > 255 {
+		return fmt.Errorf("RabbitMQQueue MaxPriority must be between 0 and 255, got %d", q.MaxPriority)
+	}
 
 	q.size = int(math.Abs(float64(q.size)))
 	q.jobC = make(chan []byte, q.size)
+	q.cancelC = make(chan uint64, q.size)
+
+	var args amqp.Table
+	if q.MaxPriority > 0 {
+		args = amqp.Table{"x-max-priority": q.MaxPriority}
+	}
 
 	var err error
 	q.ch, err = q.Conn.Channel()
@@ -72,7 +96,7 @@ func (q *RabbitMQQueue) Init(ctx context.Context) error {
 		false,   // delete when unused
 		false,   // exclusive
 		false,   // no-wait
-		nil,     // arguments
+		args,    // arguments
 	)
 	if err != nil {
 		return fmt.Errorf("failed to declare a queue: %s", err)
@@ -96,12 +120,70 @@ func (q *RabbitMQQueue) Init(ctx context.Context) error {
 		return fmt.Errorf("failed to bind a queue: %s", err)
 	}
 
+	cancelMsgs, err := q.declareCancel()
+	if err != nil {
+		return err
+	}
+
 	go q.handleMessage(ctx)
+	go q.handleCancel(ctx, cancelMsgs)
 
 	return nil
 }
 
+// Declare the exchange of the cancellations of runs and bind an exclusive queue to it.
+func (q *RabbitMQQueue) declareCancel() (<-chan amqp.Delivery, error) {
+	err := q.ch.ExchangeDeclare(
+		q.CancelExchange, // name
+		"fanout",         // type
+		false,            // durable
+		false,            // auto-deleted
+		false,            // internal
+		false,            // no-wait
+		nil,              // arguments
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to declare an exchange: %s", err)
+	}
+	cq, err := q.ch.QueueDeclare(
+		"",    // name
+		false, // durable
+		true,  // delete when unused
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to declare a queue: %s", err)
+	}
+	err = q.ch.QueueBind(
+		cq.Name,          // queue name
+		"",               // routing key
+		q.CancelExchange, // exchange
+		false,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind a queue: %s", err)
+	}
+
+	return q.ch.Consume(
+		cq.Name, // queue
+		"",      // consumer
+		true,    // auto-ack
+		true,    // exclusive
+		false,   // no-local
+		false,   // no-wait
+		nil,     // args
+	)
+}
+
 func (q *RabbitMQQueue) PushJob(bJ []byte) error {
+	return q.PushPriorityJob(bJ, 0)
+}
+
+// The priority is ignored if `MaxPriority` is 0.
+func (q *RabbitMQQueue) PushPriorityJob(bJ []byte, priority int) error {
 	pCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	err := q.ch.PublishWithContext(pCtx,
@@ -112,6 +194,7 @@ func (q *RabbitMQQueue) PushJob(bJ []byte) error {
 		amqp.Publishing{
 			DeliveryMode: amqp.Persistent,
 			ContentType:  "text/plain",
+			Priority:     uint8(min(max(priority, 0), q.MaxPriority)),
 			Body:         bJ,
 		},
 	)
@@ -126,6 +209,26 @@ func (q *RabbitMQQueue) PullJob() <-chan []byte {
 	return q.jobC
 }
 
+func (q *RabbitMQQueue) PushCancel(recordId uint64) error {
+	pCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return q.ch.PublishWithContext(pCtx,
+		q.CancelExchange, // exchange
+		"",               // routing key
+		false,            // mandatory
+		false,            // immediate
+		amqp.Publishing{
+			ContentType: "text/plain",
+			Body:        marshalCancel(recordId),
+		},
+	)
+}
+
+func (q *RabbitMQQueue) PullCancel() <-chan uint64 {
+	return q.cancelC
+}
+
 type binding struct {
 	Destination string `json:"destination"`
 }
@@ -208,6 +311,10 @@ func (q *RabbitMQQueue) Clear() error {
 	if err != nil {
 		return err
 	}
+	err = q.ch.ExchangeDelete(q.CancelExchange, false, false)
+	if err != nil {
+		return err
+	}
 	_ = q.ch.Close()
 
 	return nil
@@ -249,3 +356,22 @@ func (q *RabbitMQQueue) handleMessage(ctx context.Context) {
 		}
 	}
 }
+
+func (q *RabbitMQQueue) handleCancel(ctx context.Context, msgs <-chan amqp.Delivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d, ok := <-msgs:
+			if !ok {
+				return
+			}
+			recordId, err := unmarshalCancel(d.Body)
+			if err != nil {
+				slog.Error(fmt.Sprintf("RabbitMQQueue cancel `%s` error: `%s`", d.Body, err))
+				continue
+			}
+			q.cancelC <- recordId
+		}
+	}
+}
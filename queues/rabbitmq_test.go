@@ -41,4 +41,18 @@ func TestRabbitMQQueue(t *testing.T) {
 	}
 
 	runTest(t, broker)
+
+	runPriorityTest(t, &RabbitMQQueue{
+		Conn:        c,
+		Exchange:    "agscheduler_test_priority_exchange",
+		Queue:       "agscheduler_test_priority_queue",
+		MaxPriority: 10,
+	})
+
+	runCancelTest(t, &RabbitMQQueue{
+		Conn:           c,
+		Exchange:       "agscheduler_test_cancel_exchange",
+		Queue:          "agscheduler_test_cancel_queue",
+		CancelExchange: "agscheduler_test_cancel",
+	})
 }
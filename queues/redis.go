@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"math"
 	"runtime/debug"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -15,17 +17,30 @@ const (
 	REDIS_STREAM   = "agscheduler_stream"
 	REDIS_GROUP    = "agscheduler_group"
 	REDIS_CONSUMER = "agscheduler_consumer"
+	REDIS_JOBS     = "agscheduler_stream_jobs"
+	REDIS_CANCEL   = "agscheduler_cancel"
 )
 
-// Queue jobs in Redis.
+// Make the members of the jobs with the same priority unique and sorted by push order.
+var redisJobSeq atomic.Uint64
+
+// Queue jobs in Redis, the jobs with higher priority are delivered first.
+//
+// The jobs are kept in a sorted set by priority,
+// and each push adds an entry to the stream, which is read by the consumer group to pop a job.
 type RedisQueue struct {
 	RDB      *redis.Client
 	Stream   string
 	Group    string
 	Consumer string
-
-	size int
-	jobC chan []byte
+	// The sorted set of the jobs.
+	Jobs string
+	// The Pub/Sub channel of the cancellations of runs.
+	CancelChannel string
+
+	size    int
+	jobC    chan []byte
+	cancelC chan uint64
 }
 
 func (q *RedisQueue) Name() string {
@@ -42,9 +57,16 @@ func (q *RedisQueue) Init(ctx context.Context) error {
 	if q.Consumer == "" {
 		q.Consumer = REDIS_CONSUMER
 	}
+	if q.Jobs == "" {
+		q.Jobs = REDIS_JOBS
+	}
+	if q.CancelChannel == "" {
+		q.CancelChannel = REDIS_CANCEL
+	}
 
 	q.size = int(math.Abs(float64(q.size)))
 	q.jobC = make(chan []byte, q.size)
+	q.cancelC = make(chan uint64, q.size)
 
 	groupIsExist := false
 	gs, _ := q.RDB.XInfoGroups(ctx, q.Stream).Result()
@@ -61,17 +83,32 @@ func (q *RedisQueue) Init(ctx context.Context) error {
 		}
 	}
 
+	sub := q.RDB.Subscribe(ctx, q.CancelChannel)
+	if _, err := sub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe channel `%s`: %s", q.CancelChannel, err)
+	}
+
 	go q.handleMessage(ctx)
+	go q.handleCancel(ctx, sub)
 
 	return nil
 }
 
 func (q *RedisQueue) PushJob(bJ []byte) error {
-	err := q.RDB.XAdd(ctx, &redis.XAddArgs{
-		Stream: q.Stream,
-		ID:     "*",
-		Values: map[string]any{"job": bJ},
-	}).Err()
+	return q.PushPriorityJob(bJ, 0)
+}
+
+func (q *RedisQueue) PushPriorityJob(bJ []byte, priority int) error {
+	member := fmt.Sprintf("%020d:%06d:%s", time.Now().UnixNano(), redisJobSeq.Add(1)%1e6, bJ)
+	_, err := q.RDB.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, q.Jobs, redis.Z{Score: float64(-priority), Member: member})
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: q.Stream,
+			ID:     "*",
+			Values: map[string]any{"priority": priority},
+		})
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -79,29 +116,46 @@ func (q *RedisQueue) PushJob(bJ []byte) error {
 	return nil
 }
 
+// Pop the job with the highest priority.
+//
+//	@return nil, nil, if there are no job.
+func (q *RedisQueue) popJob() ([]byte, error) {
+	zs, err := q.RDB.ZPopMin(ctx, q.Jobs, 1).Result()
+	if err != nil || len(zs) == 0 {
+		return nil, err
+	}
+
+	// Remove the push time and sequence.
+	parts := strings.SplitN(zs[0].Member.(string), ":", 3)
+	return []byte(parts[2]), nil
+}
+
 func (q *RedisQueue) PullJob() <-chan []byte {
 	return q.jobC
 }
 
-func (q *RedisQueue) CountJobs() (int, error) {
-	count := 0
+func (q *RedisQueue) PushCancel(recordId uint64) error {
+	return q.RDB.Publish(ctx, q.CancelChannel, marshalCancel(recordId)).Err()
+}
 
-	gsInfo, err := q.RDB.XInfoGroups(ctx, q.Stream).Result()
+func (q *RedisQueue) PullCancel() <-chan uint64 {
+	return q.cancelC
+}
+
+// The jobs are kept in the sorted set until they are pulled.
+func (q *RedisQueue) CountJobs() (int, error) {
+	count, err := q.RDB.ZCard(ctx, q.Jobs).Result()
 	if err != nil {
 		return -1, err
 	}
 
-	for _, g := range gsInfo {
-		count += int(g.Lag + g.Pending)
-	}
-
-	return count, nil
+	return int(count), nil
 }
 
 func (q *RedisQueue) Clear() error {
 	defer close(q.jobC)
 
-	err := q.RDB.Del(ctx, q.Stream).Err()
+	err := q.RDB.Del(ctx, q.Stream, q.Jobs).Err()
 	if err != nil {
 		return err
 	}
@@ -109,6 +163,29 @@ func (q *RedisQueue) Clear() error {
 	return nil
 }
 
+func (q *RedisQueue) handleCancel(ctx context.Context, sub *redis.PubSub) {
+	defer func() {
+		_ = sub.Close()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-sub.Channel():
+			if !ok {
+				return
+			}
+			recordId, err := unmarshalCancel([]byte(msg.Payload))
+			if err != nil {
+				slog.Error(fmt.Sprintf("RedisQueue cancel `%s` error: `%s`", msg.Payload, err))
+				continue
+			}
+			q.cancelC <- recordId
+		}
+	}
+}
+
 func (q *RedisQueue) handleMessage(ctx context.Context) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -136,8 +213,25 @@ func (q *RedisQueue) handleMessage(ctx context.Context) {
 				continue
 			}
 			for _, msg := range messages[0].Messages {
-				bJ := []byte(fmt.Sprintf("%v", msg.Values["job"]))
-				q.jobC <- bJ
+				var bJ []byte
+				if job, ok := msg.Values["job"]; ok {
+					// Pushed before the jobs were kept in the sorted set.
+					bJ = []byte(fmt.Sprintf("%v", job))
+				} else {
+					// Each message stands for one job in the sorted set,
+					// so the job is popped before the message is acked.
+					for bJ, err = q.popJob(); err != nil; bJ, err = q.popJob() {
+						slog.Error(fmt.Sprintf("RedisQueue pop job error: `%s`", err))
+						select {
+						case <-ctx.Done():
+							return
+						case <-time.After(1 * time.Second):
+						}
+					}
+				}
+				if bJ != nil {
+					q.jobC <- bJ
+				}
 				err := q.RDB.XAck(ctx, q.Stream, q.Group, msg.ID).Err()
 				if err != nil {
 					slog.Error(fmt.Sprintf("RedisQueue ack error: `%s`", err))
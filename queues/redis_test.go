@@ -37,4 +37,20 @@ func TestRedisQueue(t *testing.T) {
 	}
 
 	runTest(t, broker)
+
+	runPriorityTest(t, &RedisQueue{
+		RDB:      rdb,
+		Stream:   "agscheduler_test_priority_stream",
+		Group:    "agscheduler_test_group",
+		Consumer: "agscheduler_test_consumer",
+		Jobs:     "agscheduler_test_priority_jobs",
+	})
+
+	runCancelTest(t, &RedisQueue{
+		RDB:           rdb,
+		Stream:        "agscheduler_test_cancel_stream",
+		Group:         "agscheduler_test_group",
+		Consumer:      "agscheduler_test_consumer",
+		CancelChannel: "agscheduler_test_cancel",
+	})
 }
@@ -0,0 +1,245 @@
+package agscheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// A named token bucket limiting the runs of the jobs referencing it,
+// e.g. the jobs calling the same third-party API.
+// It must be added on each node running the jobs,
+// and the tokens are shared across the cluster if the store implements `RateLimitStore`.
+type RateLimiter struct {
+	// The unique identifier of this rate limiter.
+	Name string `json:"name"`
+	// Number of runs allowed per `Period`.
+	Limit int `json:"limit"`
+	// Default: `1m`
+	Period string `json:"period"`
+	// Maximum number of tokens saved when the runs are fewer than `Limit`.
+	// Default: `Limit`
+	Burst int `json:"burst"`
+}
+
+// Called when the scheduler run `AddRateLimiter`.
+func (rl *RateLimiter) init() error {
+	if rl.Name == "" {
+		return fmt.Errorf("rate limiter Name cannot be empty")
+	}
+
+	if rl.Period == "" {
+		rl.Period = "1m"
+	}
+
+	if rl.Burst <= 0 {
+		rl.Burst = rl.Limit
+	}
+
+	if rl.Limit <= 0 {
+		return fmt.Errorf("rate limiter `%s` Limit must be greater than 0, got %d", rl.Name, rl.Limit)
+	}
+	period, err := time.ParseDuration(rl.Period)
+	if err != nil {
+		return fmt.Errorf("rate limiter `%s` Period `%s` error: %s", rl.Name, rl.Period, err)
+	}
+	if period <= 0 {
+		return fmt.Errorf("rate limiter `%s` Period must be greater than 0, got %s", rl.Name, rl.Period)
+	}
+
+	return nil
+}
+
+// The time to add a token.
+func (rl *RateLimiter) interval() time.Duration {
+	period, _ := time.ParseDuration(rl.Period)
+
+	return period / time.Duration(rl.Limit)
+}
+
+// The state of the token bucket of a rate limiter.
+type RateBucket struct {
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Take a token at `now`, the bucket that has never been updated is full.
+//
+//	@return 0 if a token is taken, otherwise the time until a token is available.
+func (b *RateBucket) Take(burst int, interval time.Duration, now time.Time) time.Duration {
+	if b.UpdatedAt.IsZero() {
+		b.Tokens = float64(burst)
+		b.UpdatedAt = now
+	} else if now.After(b.UpdatedAt) {
+		b.Tokens = min(float64(burst), b.Tokens+float64(now.Sub(b.UpdatedAt))/float64(interval))
+		b.UpdatedAt = now
+	}
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		return 0
+	}
+
+	return time.Duration(math.Ceil((1 - b.Tokens) * float64(interval)))
+}
+
+// Add the rate limiter, it replaces the rate limiter with the same name.
+func (s *Scheduler) AddRateLimiter(rl RateLimiter) (RateLimiter, error) {
+	if err := rl.init(); err != nil {
+		return RateLimiter{}, err
+	}
+
+	s.rateLimiterM.Lock()
+	defer s.rateLimiterM.Unlock()
+
+	slog.Info(fmt.Sprintf("Scheduler add rate limiter `%s`.", rl.Name))
+
+	s.initRateLimiters()
+	s.rateLimiters[rl.Name] = rl
+	// The bucket is full again with the new limit.
+	delete(s.rateBuckets, rl.Name)
+
+	return rl, nil
+}
+
+// The maps are made on first use, so the rate limiters can be added before `SetStore`,
+// and they are kept when the store is set again. Called with `rateLimiterM` held.
+func (s *Scheduler) initRateLimiters() {
+	if s.rateLimiters == nil {
+		s.rateLimiters = make(map[string]RateLimiter)
+	}
+	if s.rateBuckets == nil {
+		s.rateBuckets = make(map[string]*RateBucket)
+	}
+}
+
+func (s *Scheduler) GetRateLimiter(name string) (RateLimiter, error) {
+	s.rateLimiterM.Lock()
+	defer s.rateLimiterM.Unlock()
+
+	rl, ok := s.rateLimiters[name]
+	if !ok {
+		return RateLimiter{}, RateLimiterNotFoundError(name)
+	}
+	return rl, nil
+}
+
+func (s *Scheduler) DeleteRateLimiter(name string) error {
+	s.rateLimiterM.Lock()
+	defer s.rateLimiterM.Unlock()
+
+	slog.Info(fmt.Sprintf("Scheduler delete rate limiter `%s`.", name))
+
+	if _, ok := s.rateLimiters[name]; !ok {
+		return RateLimiterNotFoundError(name)
+	}
+
+	delete(s.rateLimiters, name)
+	delete(s.rateBuckets, name)
+	return nil
+}
+
+// Take a token from the store if it implements `RateLimitStore`, otherwise from the scheduler.
+func (s *Scheduler) takeToken(rl RateLimiter) (time.Duration, error) {
+	if rs, ok := s.store.(RateLimitStore); ok {
+		return rs.TakeToken(rl.Name, rl.Burst, rl.interval())
+	}
+
+	s.rateLimiterM.Lock()
+	defer s.rateLimiterM.Unlock()
+
+	b, ok := s.rateBuckets[rl.Name]
+	if !ok {
+		s.initRateLimiters()
+		b = &RateBucket{}
+		s.rateBuckets[rl.Name] = b
+	}
+	return b.Take(rl.Burst, rl.interval(), time.Now().UTC()), nil
+}
+
+// Take a token of the rate limiter referenced by the job,
+// if there is none, wait for it or skip the run according to `RateLimitPolicy`.
+// The wait is interrupted by `Stop`, or by `CancelRun` with the record id of the deferred run.
+//
+//	@return false if the run is skipped or cancelled.
+func (s *Scheduler) waitRateLimiter(j Job) bool {
+	rl, err := s.GetRateLimiter(j.RateLimiter)
+	if err != nil {
+		s._skipJob(j, fmt.Sprintf("skipped due to %s", err))
+		return false
+	}
+
+	var ctx context.Context
+	var cancel context.CancelCauseFunc
+	var rId uint64
+	for {
+		wait, err := s.takeToken(rl)
+		if err != nil {
+			s._skipJob(j, fmt.Sprintf("skipped due to take token of rate limiter `%s` error: %s", rl.Name, err))
+			return false
+		}
+		if wait == 0 {
+			return true
+		}
+
+		if j.RateLimitPolicy == RATE_LIMIT_POLICY_SKIP {
+			s._skipJob(j, fmt.Sprintf("skipped by rate limiter `%s`, next token in %s", rl.Name, wait))
+			s.dispatchEvent(EventPkg{EVENT_JOB_RATE_LIMITED, j.Id, wait})
+			return false
+		}
+		// Only the first wait is recorded, the later ones are caused by other runs taking the token first.
+		if ctx == nil {
+			rId = s._deferJob(j, fmt.Sprintf("deferred by rate limiter `%s` for %s", rl.Name, wait))
+			s.dispatchEvent(EventPkg{EVENT_JOB_RATE_LIMITED, j.Id, wait})
+			ctx, cancel = context.WithCancelCause(context.Background())
+			s.addRetryCancel(rId, &cancel)
+			defer s.deleteRetryCancel(rId, &cancel)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s._cancelDeferredJob(j, rId, context.Cause(ctx).Error())
+			return false
+		case <-timer.C:
+		}
+	}
+}
+
+// Called when the run waits for a token of its rate limiter.
+//
+//	@return the record id of the deferred run, 0 if it is not recorded.
+func (s *Scheduler) _deferJob(j Job, result string) uint64 {
+	slog.Info(fmt.Sprintf("Job `%s` %s", j.FullName(), result))
+
+	if s.HasRecorder() {
+		rId, err := s.recorder.RecordMetadata(j)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record metadata error: `%s`", j.FullName(), err))
+			return 0
+		}
+		if err := s.recorder.RecordResult(rId, RECORD_STATUS_DEFERRED, result); err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record result error: `%s`", j.FullName(), err))
+		}
+		return rId
+	}
+
+	return 0
+}
+
+// Called when the wait for a token is interrupted, the deferred record is updated.
+func (s *Scheduler) _cancelDeferredJob(j Job, recordId uint64, result string) {
+	slog.Warn(fmt.Sprintf("Job `%s` rate limiter wait cancelled", j.FullName()))
+
+	if recordId != 0 {
+		if err := s.recorder.RecordResult(recordId, RECORD_STATUS_CANCELLED, result); err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record result error: `%s`", j.FullName(), err))
+		}
+	}
+
+	s.dispatchEvent(EventPkg{EVENT_JOB_CANCELLED, j.Id, recordId})
+	s.completeJob(j, RECORD_STATUS_CANCELLED, result)
+}
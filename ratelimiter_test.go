@@ -0,0 +1,71 @@
+package agscheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterInit(t *testing.T) {
+	rl := RateLimiter{Name: "api", Limit: 10}
+	assert.NoError(t, rl.init())
+	assert.Equal(t, "1m", rl.Period)
+	assert.Equal(t, 10, rl.Burst)
+	assert.Equal(t, 6*time.Second, rl.interval())
+}
+
+func TestRateLimiterInitError(t *testing.T) {
+	for _, rl := range []RateLimiter{
+		{Limit: 1},
+		{Name: "api"},
+		{Name: "api", Limit: 1, Period: "errorPeriod"},
+		{Name: "api", Limit: 1, Period: "-1s"},
+	} {
+		assert.Error(t, rl.init())
+	}
+}
+
+func TestRateBucketTake(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	b := &RateBucket{}
+
+	assert.Equal(t, time.Duration(0), b.Take(2, time.Second, now))
+	assert.Equal(t, time.Duration(0), b.Take(2, time.Second, now))
+	assert.Equal(t, time.Second, b.Take(2, time.Second, now))
+	assert.Equal(t, 500*time.Millisecond, b.Take(2, time.Second, now.Add(500*time.Millisecond)))
+	assert.Equal(t, time.Duration(0), b.Take(2, time.Second, now.Add(time.Second)))
+
+	// The tokens do not exceed the burst.
+	assert.Equal(t, time.Duration(0), b.Take(2, time.Second, now.Add(time.Hour)))
+	assert.Equal(t, time.Duration(0), b.Take(2, time.Second, now.Add(time.Hour)))
+	assert.Equal(t, time.Second, b.Take(2, time.Second, now.Add(time.Hour)))
+}
+
+func TestSchedulerRateLimiter(t *testing.T) {
+	s := &Scheduler{}
+	s.init()
+
+	_, err := s.AddRateLimiter(RateLimiter{Name: "api"})
+	assert.Error(t, err)
+
+	rl, err := s.AddRateLimiter(RateLimiter{Name: "api", Limit: 1, Period: "1h"})
+	assert.NoError(t, err)
+	rl, err = s.GetRateLimiter(rl.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rl.Burst)
+
+	wait, err := s.takeToken(rl)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+	wait, err = s.takeToken(rl)
+	assert.NoError(t, err)
+	assert.Greater(t, wait, 59*time.Minute)
+
+	err = s.DeleteRateLimiter(rl.Name)
+	assert.NoError(t, err)
+	_, err = s.GetRateLimiter(rl.Name)
+	assert.ErrorIs(t, err, RateLimiterNotFoundError(rl.Name))
+	err = s.DeleteRateLimiter(rl.Name)
+	assert.ErrorIs(t, err, RateLimiterNotFoundError(rl.Name))
+}
@@ -1,12 +1,14 @@
 package agscheduler
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"sync"
 	"time"
 
 	"github.com/sony/sonyflake"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/agscheduler/agscheduler/services/proto"
@@ -18,6 +20,10 @@ const (
 	RECORD_STATUS_COMPLETED = "completed"
 	RECORD_STATUS_ERROR     = "error"
 	RECORD_STATUS_TIMEOUT   = "timeout"
+	RECORD_STATUS_MISSED    = "missed"
+	RECORD_STATUS_CANCELLED = "cancelled"
+	RECORD_STATUS_SKIPPED   = "skipped"
+	RECORD_STATUS_DEFERRED  = "deferred"
 )
 
 // Carry the information of the job run.
@@ -28,7 +34,7 @@ type Record struct {
 	JobId string `json:"job_id"`
 	// Job name
 	JobName string `json:"job_name"`
-	// Optional: `RECORD_STATUS_RUNNING` | `RECORD_STATUS_COMPLETED` | `RECORD_STATUS_ERROR` | `RECORD_STATUS_TIMEOUT`
+	// Optional: `RECORD_STATUS_RUNNING` | `RECORD_STATUS_COMPLETED` | `RECORD_STATUS_ERROR` | `RECORD_STATUS_TIMEOUT` | `RECORD_STATUS_MISSED` | `RECORD_STATUS_CANCELLED` | `RECORD_STATUS_SKIPPED` | `RECORD_STATUS_DEFERRED`
 	Status string `json:"status"`
 	// The result of the job run
 	Result string `json:"result"`
@@ -36,6 +42,43 @@ type Record struct {
 	StartAt time.Time `json:"start_at"`
 	// End time
 	EndAt time.Time `json:"end_at"`
+	// The attempt number of the job run, starting from 1.
+	Attempt int `json:"attempt"`
+	// The last progress reported by `ReportProgress` and `Heartbeat`.
+	Progress Progress `json:"progress"`
+	// Whether the run exceeds `Job.SoftTimeout`.
+	SoftTimedOut bool `json:"soft_timed_out"`
+}
+
+// The progress of the job run, reported by `Func` with `ReportProgress` and `Heartbeat`.
+type Progress struct {
+	// From 0 to 100.
+	Percent float64 `json:"percent"`
+	Message string  `json:"message"`
+	// Custom fields, e.g. the current offset of a backfill.
+	Fields map[string]any `json:"fields"`
+	// The time of the last progress or heartbeat.
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+	// Whether no heartbeat is received within `Job.HeartbeatTimeout`,
+	// it is reset by the next progress or heartbeat.
+	Stalled bool `json:"stalled"`
+}
+
+// Serialize Progress and convert to String, used by the backends.
+func ProgressMarshal(p Progress) (string, error) {
+	bP, err := json.Marshal(p)
+	return string(bP), err
+}
+
+// Deserialize String and convert to Progress, used by the backends.
+// An empty string is the zero Progress.
+func ProgressUnmarshal(sP string) (Progress, error) {
+	var p Progress
+	if sP == "" {
+		return p, nil
+	}
+	err := json.Unmarshal([]byte(sP), &p)
+	return p, err
 }
 
 // `sort.Interface`, sorted by 'StartAt', descend.
@@ -55,6 +98,21 @@ func RecordToPbRecordPtr(r Record) (*pb.Record, error) {
 		Result:  r.Result,
 		StartAt: timestamppb.New(r.StartAt),
 		EndAt:   timestamppb.New(r.EndAt),
+		Attempt: int32(r.Attempt),
+
+		SoftTimedOut: r.SoftTimedOut,
+	}
+
+	fields, err := structpb.NewStruct(r.Progress.Fields)
+	if err != nil {
+		return &pb.Record{}, err
+	}
+	pbR.Progress = &pb.Progress{
+		Percent:     r.Progress.Percent,
+		Message:     r.Progress.Message,
+		Fields:      fields,
+		HeartbeatAt: timestamppb.New(r.Progress.HeartbeatAt),
+		Stalled:     r.Progress.Stalled,
 	}
 
 	return pbR, nil
@@ -70,6 +128,15 @@ func PbRecordPtrToRecord(pbRecord *pb.Record) Record {
 		Result:  pbRecord.GetResult(),
 		StartAt: pbRecord.GetStartAt().AsTime(),
 		EndAt:   pbRecord.GetEndAt().AsTime(),
+		Attempt: int(pbRecord.GetAttempt()),
+		Progress: Progress{
+			Percent:     pbRecord.GetProgress().GetPercent(),
+			Message:     pbRecord.GetProgress().GetMessage(),
+			Fields:      pbRecord.GetProgress().GetFields().AsMap(),
+			HeartbeatAt: pbRecord.GetProgress().GetHeartbeatAt().AsTime(),
+			Stalled:     pbRecord.GetProgress().GetStalled(),
+		},
+		SoftTimedOut: pbRecord.GetSoftTimedOut(),
 	}
 }
 
@@ -136,6 +203,10 @@ func (r *Recorder) init() error {
 }
 
 func (r *Recorder) RecordMetadata(j Job) (id uint64, err error) {
+	return r.RecordAttemptMetadata(j, 1)
+}
+
+func (r *Recorder) RecordAttemptMetadata(j Job, attempt int) (id uint64, err error) {
 	r.backendM.Lock()
 	defer r.backendM.Unlock()
 
@@ -152,6 +223,7 @@ func (r *Recorder) RecordMetadata(j Job) (id uint64, err error) {
 		Status:  RECORD_STATUS_RUNNING,
 		StartAt: t,
 		EndAt:   t,
+		Attempt: attempt,
 	})
 
 	return id, err
@@ -164,6 +236,41 @@ func (r *Recorder) RecordResult(id uint64, status string, result string) error {
 	return r.Backend.RecordResult(id, status, result)
 }
 
+func (r *Recorder) RecordProgress(id uint64, p Progress) error {
+	r.backendM.Lock()
+	defer r.backendM.Unlock()
+
+	return r.Backend.RecordProgress(id, p)
+}
+
+func (r *Recorder) RecordSoftTimeout(id uint64) error {
+	r.backendM.Lock()
+	defer r.backendM.Unlock()
+
+	return r.Backend.RecordSoftTimeout(id)
+}
+
+func (r *Recorder) RecordLogs(id uint64, logs string) error {
+	r.backendM.Lock()
+	defer r.backendM.Unlock()
+
+	return r.Backend.RecordLogs(id, logs)
+}
+
+// Get the logs of the job run with the record id,
+// if `tail` > 0, only the last `tail` lines are returned.
+func (r *Recorder) GetRecordLogs(id uint64, tail int) (string, error) {
+	r.backendM.RLock()
+	defer r.backendM.RUnlock()
+
+	logs, err := r.Backend.GetRecordLogs(id)
+	if err != nil {
+		return "", err
+	}
+
+	return tailLines(logs, tail), nil
+}
+
 func (r *Recorder) GetRecords(jId string, page, pageSize int) ([]Record, int64, error) {
 	r.backendM.RLock()
 	defer r.backendM.RUnlock()
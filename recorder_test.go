@@ -97,6 +97,7 @@ func TestRecorderRecordMetadata(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, j.Id, rs[0].JobId)
 	assert.Equal(t, agscheduler.RECORD_STATUS_RUNNING, rs[0].Status)
+	assert.Equal(t, 1, rs[0].Attempt)
 }
 
 func TestRecorderRecordResult(t *testing.T) {
@@ -117,6 +118,65 @@ func TestRecorderRecordResult(t *testing.T) {
 	assert.Equal(t, agscheduler.RECORD_STATUS_COMPLETED, rs[0].Status)
 }
 
+func TestRecorderRecordProgress(t *testing.T) {
+	j := agscheduler.Job{Id: "1"}
+	s := &agscheduler.Scheduler{}
+	rec := getRecorder()
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+
+	id, err := rec.RecordMetadata(j)
+	assert.NoError(t, err)
+
+	p := agscheduler.Progress{Percent: 50, Message: "half", Fields: map[string]any{"offset": 10}}
+	err = rec.RecordProgress(id, p)
+	assert.NoError(t, err)
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, p, rs[0].Progress)
+}
+
+func TestRecorderRecordLogs(t *testing.T) {
+	j := agscheduler.Job{Id: "1"}
+	s := &agscheduler.Scheduler{}
+	rec := getRecorder()
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+
+	id, err := rec.RecordMetadata(j)
+	assert.NoError(t, err)
+
+	err = rec.RecordLogs(id, "a\nb\n")
+	assert.NoError(t, err)
+
+	logs, err := rec.GetRecordLogs(id, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "a\nb\n", logs)
+	logs, err = rec.GetRecordLogs(id, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "b\n", logs)
+
+	_, err = rec.GetRecordLogs(id+1, 0)
+	assert.ErrorIs(t, err, agscheduler.RecordNotFoundError(id+1))
+}
+
+func TestProgressMarshal(t *testing.T) {
+	p := agscheduler.Progress{Percent: 50, Message: "half", Stalled: true}
+	sP, err := agscheduler.ProgressMarshal(p)
+	assert.NoError(t, err)
+
+	p2, err := agscheduler.ProgressUnmarshal(sP)
+	assert.NoError(t, err)
+	assert.Equal(t, p.Percent, p2.Percent)
+	assert.Equal(t, p.Message, p2.Message)
+	assert.True(t, p2.Stalled)
+
+	p2, err = agscheduler.ProgressUnmarshal("")
+	assert.NoError(t, err)
+	assert.Empty(t, p2)
+}
+
 func TestRecorderGetRecords(t *testing.T) {
 	j := agscheduler.Job{Id: "1"}
 	s := &agscheduler.Scheduler{}
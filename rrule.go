@@ -0,0 +1,570 @@
+package agscheduler
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Maximum number of periods checked when calculating the next run time of a RRULE.
+const rRuleSearchMax = 100000
+
+const (
+	rRuleFreqYearly   = "YEARLY"
+	rRuleFreqMonthly  = "MONTHLY"
+	rRuleFreqWeekly   = "WEEKLY"
+	rRuleFreqDaily    = "DAILY"
+	rRuleFreqHourly   = "HOURLY"
+	rRuleFreqMinutely = "MINUTELY"
+)
+
+var rRuleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// e.g. `MO` is every Monday, `2TU` is the second Tuesday, `-1FR` is the last Friday.
+type rRuleWeekday struct {
+	weekday time.Weekday
+	n       int
+}
+
+// A subset of the RFC 5545 recurrence rule,
+// `BYYEARDAY`, `BYWEEKNO` and `FREQ=SECONDLY` are not supported.
+type rRule struct {
+	loc *time.Location
+
+	dtStart time.Time
+	rDates  []time.Time
+	exDates []time.Time
+
+	hasRule    bool
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byMonth    []int
+	byMonthDay []int
+	byDay      []rRuleWeekday
+	bySetPos   []int
+	byHour     []int
+	byMinute   []int
+	bySecond   []int
+	wkst       time.Weekday
+}
+
+// Parse the lines of `DTSTART`, `RRULE`, `RDATE` and `EXDATE`,
+// a line without a name is considered `RRULE`.
+// If there is no `DTSTART`, `startAt` is used.
+func parseRRule(s string, startAt string, loc *time.Location) (*rRule, error) {
+	r := &rRule{loc: loc, interval: 1, wkst: time.Monday}
+
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToUpper(line), "FREQ=") {
+			line = "RRULE:" + line
+		}
+
+		nameParams, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line `%s` invalid", line)
+		}
+		params := strings.Split(nameParams, ";")
+		tzid := ""
+		for _, param := range params[1:] {
+			k, v, _ := strings.Cut(param, "=")
+			if strings.ToUpper(k) == "TZID" {
+				tzid = v
+			}
+		}
+
+		switch strings.ToUpper(params[0]) {
+		case "DTSTART":
+			dtStart, err := parseRRuleTime(value, tzid, loc)
+			if err != nil {
+				return nil, fmt.Errorf("DTSTART `%s` error: %s", value, err)
+			}
+			r.dtStart = dtStart
+		case "RRULE":
+			if r.hasRule {
+				return nil, fmt.Errorf("only one RRULE is supported")
+			}
+			if err := r.parseRule(value, loc); err != nil {
+				return nil, fmt.Errorf("RRULE `%s` error: %s", value, err)
+			}
+		case "RDATE", "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				t, err := parseRRuleTime(v, tzid, loc)
+				if err != nil {
+					return nil, fmt.Errorf("%s `%s` error: %s", params[0], v, err)
+				}
+				if strings.ToUpper(params[0]) == "RDATE" {
+					r.rDates = append(r.rDates, t)
+				} else {
+					r.exDates = append(r.exDates, t)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("property `%s` unknown", params[0])
+		}
+	}
+
+	if r.dtStart.IsZero() {
+		if startAt == "" {
+			return nil, fmt.Errorf("DTSTART cannot be empty")
+		}
+		dtStart, err := time.ParseInLocation(time.DateTime, startAt, loc)
+		if err != nil {
+			return nil, fmt.Errorf("StartAt `%s` error: %s", startAt, err)
+		}
+		r.dtStart = dtStart
+	}
+	r.dtStart = r.dtStart.In(loc)
+
+	if !r.hasRule && len(r.rDates) == 0 {
+		return nil, fmt.Errorf("RRULE and RDATE cannot both be empty")
+	}
+
+	return r, nil
+}
+
+// Times with `Z` are in UTC, times with `TZID` are in it, others are in `loc`.
+func parseRRuleTime(v string, tzid string, loc *time.Location) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if tzid != "" {
+		tz, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, err
+		}
+		loc = tz
+	}
+
+	switch {
+	case strings.HasSuffix(v, "Z"):
+		return time.Parse("20060102T150405Z", v)
+	case len(v) == len("20060102"):
+		return time.ParseInLocation("20060102", v, loc)
+	default:
+		return time.ParseInLocation("20060102T150405", v, loc)
+	}
+}
+
+func (r *rRule) parseRule(value string, loc *time.Location) error {
+	r.hasRule = true
+
+	for _, part := range strings.Split(value, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return fmt.Errorf("part `%s` invalid", part)
+		}
+
+		var err error
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			r.freq = strings.ToUpper(v)
+			switch r.freq {
+			case rRuleFreqYearly, rRuleFreqMonthly, rRuleFreqWeekly,
+				rRuleFreqDaily, rRuleFreqHourly, rRuleFreqMinutely:
+			default:
+				return fmt.Errorf("FREQ `%s` unsupported", v)
+			}
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(v)
+			if err == nil && r.interval <= 0 {
+				err = fmt.Errorf("must be greater than 0")
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(v)
+			if err == nil && r.count <= 0 {
+				err = fmt.Errorf("must be greater than 0")
+			}
+		case "UNTIL":
+			r.until, err = parseRRuleTime(v, "", loc)
+		case "BYMONTH":
+			r.byMonth, err = parseRRuleInts(v, 1, 12, false)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseRRuleInts(v, 1, 31, true)
+		case "BYSETPOS":
+			r.bySetPos, err = parseRRuleInts(v, 1, 366, true)
+		case "BYHOUR":
+			r.byHour, err = parseRRuleInts(v, 0, 23, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseRRuleInts(v, 0, 59, false)
+		case "BYSECOND":
+			r.bySecond, err = parseRRuleInts(v, 0, 59, false)
+		case "BYDAY":
+			r.byDay, err = parseRRuleWeekdays(v)
+		case "WKST":
+			wkst, ok := rRuleWeekdays[strings.ToUpper(v)]
+			if !ok {
+				err = fmt.Errorf("weekday unknown")
+			}
+			r.wkst = wkst
+		default:
+			return fmt.Errorf("part `%s` unsupported", k)
+		}
+		if err != nil {
+			return fmt.Errorf("%s `%s` error: %s", k, v, err)
+		}
+	}
+
+	if r.freq == "" {
+		return fmt.Errorf("FREQ cannot be empty")
+	}
+	if r.count > 0 && !r.until.IsZero() {
+		return fmt.Errorf("COUNT and UNTIL cannot both be set")
+	}
+	if r.freq != rRuleFreqMonthly && r.freq != rRuleFreqYearly {
+		for _, wd := range r.byDay {
+			if wd.n != 0 {
+				return fmt.Errorf("BYDAY with a number can only be used when FREQ is `%s` | `%s`",
+					rRuleFreqMonthly, rRuleFreqYearly)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Negative values are allowed when `negative` is true, they count from the end.
+func parseRRuleInts(v string, minValue, maxValue int, negative bool) ([]int, error) {
+	ns := []int{}
+	for _, s := range strings.Split(v, ",") {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, err
+		}
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+		if abs < minValue || abs > maxValue {
+			return nil, fmt.Errorf("`%d` out of range", n)
+		}
+		ns = append(ns, n)
+	}
+
+	return ns, nil
+}
+
+func parseRRuleWeekdays(v string) ([]rRuleWeekday, error) {
+	wds := []rRuleWeekday{}
+	for _, s := range strings.Split(v, ",") {
+		s = strings.ToUpper(s)
+		if len(s) < 2 {
+			return nil, fmt.Errorf("`%s` invalid", s)
+		}
+		weekday, ok := rRuleWeekdays[s[len(s)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("`%s` weekday unknown", s)
+		}
+		n := 0
+		if len(s) > 2 {
+			var err error
+			n, err = strconv.Atoi(s[:len(s)-2])
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("`%s` out of range", s)
+			}
+		}
+		wds = append(wds, rRuleWeekday{weekday: weekday, n: n})
+	}
+
+	return wds, nil
+}
+
+// The earliest occurrence after `t`, it returns false when there is none.
+func (r *rRule) next(t time.Time) (time.Time, bool) {
+	var nextTime time.Time
+	if r.hasRule {
+		if ruleTime, ok := r.nextRuleTime(t); ok {
+			nextTime = ruleTime
+		}
+	}
+	for _, rDate := range r.rDates {
+		if rDate.After(t) && !r.isExcluded(rDate) && (nextTime.IsZero() || rDate.Before(nextTime)) {
+			nextTime = rDate
+		}
+	}
+
+	return nextTime, !nextTime.IsZero()
+}
+
+func (r *rRule) isExcluded(t time.Time) bool {
+	return slices.ContainsFunc(r.exDates, func(exDate time.Time) bool {
+		return exDate.Unix() == t.Unix()
+	})
+}
+
+func (r *rRule) nextRuleTime(t time.Time) (time.Time, bool) {
+	// With `COUNT`, the occurrences must be counted from `DTSTART`.
+	n := 0
+	if r.count == 0 {
+		n = max(0, r.periodIndex(t)-1)
+	}
+
+	count := 0
+	for range rRuleSearchMax {
+		start := r.periodStart(n)
+		if !r.until.IsZero() && start.After(r.until) {
+			return time.Time{}, false
+		}
+		for _, occurrence := range r.expand(start) {
+			if occurrence.Before(r.dtStart) {
+				continue
+			}
+			if !r.until.IsZero() && occurrence.After(r.until) {
+				return time.Time{}, false
+			}
+			count++
+			if r.count > 0 && count > r.count {
+				return time.Time{}, false
+			}
+			if occurrence.After(t) && !r.isExcluded(occurrence) {
+				return occurrence, true
+			}
+		}
+		n++
+	}
+
+	return time.Time{}, false
+}
+
+// The start of the `n`th period from `DTSTART`.
+func (r *rRule) periodStart(n int) time.Time {
+	s := r.dtStart
+	step := n * r.interval
+	switch r.freq {
+	case rRuleFreqYearly:
+		return time.Date(s.Year()+step, 1, 1, 0, 0, 0, 0, r.loc)
+	case rRuleFreqMonthly:
+		return time.Date(s.Year(), s.Month()+time.Month(step), 1, 0, 0, 0, 0, r.loc)
+	case rRuleFreqWeekly:
+		offset := (int(s.Weekday()) - int(r.wkst) + 7) % 7
+		return time.Date(s.Year(), s.Month(), s.Day()-offset+7*step, 0, 0, 0, 0, r.loc)
+	case rRuleFreqDaily:
+		return time.Date(s.Year(), s.Month(), s.Day()+step, 0, 0, 0, 0, r.loc)
+	case rRuleFreqHourly:
+		return time.Date(s.Year(), s.Month(), s.Day(), s.Hour(), 0, 0, 0, r.loc).Add(time.Duration(step) * time.Hour)
+	default:
+		return time.Date(s.Year(), s.Month(), s.Day(), s.Hour(), s.Minute(), 0, 0, r.loc).Add(time.Duration(step) * time.Minute)
+	}
+}
+
+// The index of the period containing `t`, it may be less than the actual one.
+func (r *rRule) periodIndex(t time.Time) int {
+	s := r.dtStart
+	t = t.In(r.loc)
+	if !t.After(s) {
+		return 0
+	}
+
+	days := func(a, b time.Time) int {
+		da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+		db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+		return int(db.Sub(da) / (24 * time.Hour))
+	}
+
+	var periods int
+	switch r.freq {
+	case rRuleFreqYearly:
+		periods = t.Year() - s.Year()
+	case rRuleFreqMonthly:
+		periods = (t.Year()-s.Year())*12 + int(t.Month()) - int(s.Month())
+	case rRuleFreqWeekly:
+		periods = days(r.periodStart(0), t) / 7
+	case rRuleFreqDaily:
+		periods = days(s, t)
+	case rRuleFreqHourly:
+		periods = int(t.Sub(r.periodStart(0)) / time.Hour)
+	default:
+		periods = int(t.Sub(r.periodStart(0)) / time.Minute)
+	}
+
+	return periods / r.interval
+}
+
+// The sorted occurrences in the period starting at `start`.
+func (r *rRule) expand(start time.Time) []time.Time {
+	var days []time.Time
+	switch r.freq {
+	case rRuleFreqYearly:
+		if len(r.byMonth) == 0 && len(r.byMonthDay) == 0 && len(r.byDay) > 0 {
+			days = r.weekdaysIn(start, start.AddDate(1, 0, 0))
+			break
+		}
+		months := r.byMonth
+		if len(months) == 0 {
+			if len(r.byMonthDay) > 0 || len(r.byDay) > 0 {
+				months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			} else {
+				months = []int{int(r.dtStart.Month())}
+			}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(start.Year(), time.Month(m))...)
+		}
+	case rRuleFreqMonthly:
+		if len(r.byMonth) == 0 || slices.Contains(r.byMonth, int(start.Month())) {
+			days = r.monthDays(start.Year(), start.Month())
+		}
+	case rRuleFreqWeekly:
+		for i := range 7 {
+			day := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, r.loc)
+			if r.matchWeekday(day, r.dtStart.Weekday()) && r.matchMonth(day) && r.matchMonthDay(day) {
+				days = append(days, day)
+			}
+		}
+	default:
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, r.loc)
+		if r.matchMonth(day) && r.matchMonthDay(day) && r.matchWeekday(day, -1) {
+			days = append(days, day)
+		}
+	}
+
+	hours := r.byHour
+	minutes := r.byMinute
+	seconds := r.bySecond
+	switch r.freq {
+	case rRuleFreqHourly:
+		if len(hours) > 0 && !slices.Contains(hours, start.Hour()) {
+			return nil
+		}
+		hours = []int{start.Hour()}
+	case rRuleFreqMinutely:
+		if (len(hours) > 0 && !slices.Contains(hours, start.Hour())) ||
+			(len(minutes) > 0 && !slices.Contains(minutes, start.Minute())) {
+			return nil
+		}
+		hours = []int{start.Hour()}
+		minutes = []int{start.Minute()}
+	}
+	if len(hours) == 0 {
+		hours = []int{r.dtStart.Hour()}
+	}
+	if len(minutes) == 0 {
+		minutes = []int{r.dtStart.Minute()}
+	}
+	if len(seconds) == 0 {
+		seconds = []int{r.dtStart.Second()}
+	}
+
+	occurrences := []time.Time{}
+	for _, day := range days {
+		for _, h := range hours {
+			for _, m := range minutes {
+				for _, sec := range seconds {
+					occurrences = append(occurrences, time.Date(day.Year(), day.Month(), day.Day(), h, m, sec, 0, r.loc))
+				}
+			}
+		}
+	}
+	slices.SortFunc(occurrences, func(a, b time.Time) int { return a.Compare(b) })
+	occurrences = slices.CompactFunc(occurrences, func(a, b time.Time) bool { return a.Equal(b) })
+
+	if len(r.bySetPos) == 0 {
+		return occurrences
+	}
+	selected := []time.Time{}
+	for _, pos := range r.bySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(occurrences) + pos
+		}
+		if i >= 0 && i < len(occurrences) {
+			selected = append(selected, occurrences[i])
+		}
+	}
+	slices.SortFunc(selected, func(a, b time.Time) int { return a.Compare(b) })
+
+	return slices.CompactFunc(selected, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+// The days of the month matching `BYMONTHDAY` and `BYDAY`,
+// if neither is set, the day of `DTSTART` is used.
+func (r *rRule) monthDays(year int, month time.Month) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, r.loc)
+	last := first.AddDate(0, 1, -1).Day()
+
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		if r.dtStart.Day() > last {
+			return nil
+		}
+		return []time.Time{time.Date(year, month, r.dtStart.Day(), 0, 0, 0, 0, r.loc)}
+	}
+
+	days := []time.Time{}
+	if len(r.byDay) > 0 {
+		days = r.weekdaysIn(first, first.AddDate(0, 1, 0))
+	} else {
+		for d := 1; d <= last; d++ {
+			days = append(days, time.Date(year, month, d, 0, 0, 0, 0, r.loc))
+		}
+	}
+
+	return slices.DeleteFunc(days, func(day time.Time) bool { return !r.matchMonthDay(day) })
+}
+
+// The days in [from, to) matching `BYDAY`, the numbers count within the range.
+func (r *rRule) weekdaysIn(from, to time.Time) []time.Time {
+	byWeekday := map[time.Weekday][]time.Time{}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		byWeekday[day.Weekday()] = append(byWeekday[day.Weekday()], day)
+	}
+
+	days := []time.Time{}
+	for _, wd := range r.byDay {
+		candidates := byWeekday[wd.weekday]
+		switch {
+		case wd.n == 0:
+			days = append(days, candidates...)
+		case wd.n > 0 && wd.n <= len(candidates):
+			days = append(days, candidates[wd.n-1])
+		case wd.n < 0 && -wd.n <= len(candidates):
+			days = append(days, candidates[len(candidates)+wd.n])
+		}
+	}
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+
+	return days
+}
+
+// If `BYDAY` is not set, the day matches `weekday`, or any weekday when it is negative.
+func (r *rRule) matchWeekday(day time.Time, weekday time.Weekday) bool {
+	if len(r.byDay) == 0 {
+		return weekday < 0 || day.Weekday() == weekday
+	}
+
+	return slices.ContainsFunc(r.byDay, func(wd rRuleWeekday) bool { return wd.weekday == day.Weekday() })
+}
+
+func (r *rRule) matchMonth(day time.Time) bool {
+	return len(r.byMonth) == 0 || slices.Contains(r.byMonth, int(day.Month()))
+}
+
+func (r *rRule) matchMonthDay(day time.Time) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, r.loc).Day()
+	for _, d := range r.byMonthDay {
+		if d == day.Day() || (d < 0 && last+1+d == day.Day()) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+package agscheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRRuleNext(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	after := time.Date(2024, 1, 15, 12, 0, 0, 0, loc)
+
+	for _, c := range []struct {
+		rrule   string
+		expects []time.Time
+	}{
+		// The last business day of the month.
+		{
+			"DTSTART:20240101T090000\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			[]time.Time{
+				time.Date(2024, 1, 31, 9, 0, 0, 0, loc),
+				time.Date(2024, 2, 29, 9, 0, 0, 0, loc),
+				time.Date(2024, 3, 29, 9, 0, 0, 0, loc),
+			},
+		},
+		// Every 2nd Tuesday.
+		{
+			"DTSTART:20240101T090000\nRRULE:FREQ=MONTHLY;BYDAY=2TU",
+			[]time.Time{
+				time.Date(2024, 2, 13, 9, 0, 0, 0, loc),
+				time.Date(2024, 3, 12, 9, 0, 0, 0, loc),
+			},
+		},
+		// Every 10 days from the start date.
+		{
+			"DTSTART:20240101T090000\nRRULE:FREQ=DAILY;INTERVAL=10",
+			[]time.Time{
+				time.Date(2024, 1, 21, 9, 0, 0, 0, loc),
+				time.Date(2024, 1, 31, 9, 0, 0, 0, loc),
+			},
+		},
+		{
+			"DTSTART:20240101T090000\nRRULE:FREQ=WEEKLY;BYDAY=MO,FR;BYHOUR=9,18",
+			[]time.Time{
+				time.Date(2024, 1, 15, 18, 0, 0, 0, loc),
+				time.Date(2024, 1, 19, 9, 0, 0, 0, loc),
+				time.Date(2024, 1, 19, 18, 0, 0, 0, loc),
+			},
+		},
+		{
+			"DTSTART:20240101T090000\nRRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1",
+			[]time.Time{
+				time.Date(2024, 2, 29, 9, 0, 0, 0, loc),
+				time.Date(2025, 2, 28, 9, 0, 0, 0, loc),
+			},
+		},
+		{
+			"DTSTART:20240101T090000\nRRULE:FREQ=DAILY\nEXDATE:20240116T090000\nRDATE:20240116T120000",
+			[]time.Time{
+				time.Date(2024, 1, 16, 12, 0, 0, 0, loc),
+				time.Date(2024, 1, 17, 9, 0, 0, 0, loc),
+			},
+		},
+		{
+			"DTSTART;TZID=UTC:20240115T000000\nRRULE:FREQ=HOURLY;INTERVAL=6",
+			[]time.Time{
+				time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"DTSTART:20240115T115800\nRRULE:FREQ=MINUTELY;INTERVAL=15;BYHOUR=12",
+			[]time.Time{
+				time.Date(2024, 1, 15, 12, 13, 0, 0, loc),
+				time.Date(2024, 1, 15, 12, 28, 0, 0, loc),
+			},
+		},
+	} {
+		r, err := parseRRule(c.rrule, "", loc)
+		assert.NoError(t, err)
+
+		nextTime := after
+		for _, expect := range c.expects {
+			var ok bool
+			nextTime, ok = r.next(nextTime)
+			assert.True(t, ok, c.rrule)
+			assert.True(t, expect.Equal(nextTime), "%s: expect %s, got %s", c.rrule, expect, nextTime)
+		}
+	}
+}
+
+func TestRRuleNextEnded(t *testing.T) {
+	loc := time.UTC
+	after := time.Date(2024, 1, 15, 12, 0, 0, 0, loc)
+
+	for _, rrule := range []string{
+		"DTSTART:20240101T090000\nRRULE:FREQ=DAILY;COUNT=3",
+		"DTSTART:20240101T090000\nRRULE:FREQ=DAILY;UNTIL=20240110T090000Z",
+		"DTSTART:20240101T090000\nRDATE:20240102T090000",
+	} {
+		r, err := parseRRule(rrule, "", loc)
+		assert.NoError(t, err)
+
+		_, ok := r.next(after)
+		assert.False(t, ok, rrule)
+	}
+}
+
+func TestRRuleStartAt(t *testing.T) {
+	r, err := parseRRule("FREQ=DAILY", "2024-01-01 09:00:00", time.UTC)
+	assert.NoError(t, err)
+
+	nextTime, ok := r.next(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC), nextTime)
+}
+
+func TestParseRRuleError(t *testing.T) {
+	for _, rrule := range []string{
+		"",
+		"RRULE:FREQ=DAILY",
+		"DTSTART:20240101T090000\nRRULE:FREQ=SECONDLY",
+		"DTSTART:20240101T090000\nRRULE:INTERVAL=2",
+		"DTSTART:20240101T090000\nRRULE:FREQ=DAILY;INTERVAL=0",
+		"DTSTART:20240101T090000\nRRULE:FREQ=DAILY;COUNT=2;UNTIL=20240110T090000Z",
+		"DTSTART:20240101T090000\nRRULE:FREQ=DAILY;BYDAY=2TU",
+		"DTSTART:20240101T090000\nRRULE:FREQ=YEARLY;BYWEEKNO=20",
+		"DTSTART:20240101T090000\nRRULE:FREQ=MONTHLY;BYMONTHDAY=32",
+		"DTSTART:2024-01-01\nRRULE:FREQ=DAILY",
+		"DTSTART:20240101T090000\nUNKNOWN:1",
+	} {
+		_, err := parseRRule(rrule, "", time.UTC)
+		assert.Error(t, err, rrule)
+	}
+}
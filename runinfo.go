@@ -0,0 +1,61 @@
+package agscheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// The metadata of the current run, get it from the context passed to `Func` with `RunInfoFromContext`.
+// It is filled in the same way in standalone, broker and cluster mode.
+type RunInfo struct {
+	// The id of the record of this attempt, `0` when the recorder does not exist.
+	RecordId uint64
+	// The time the run is scheduled at, e.g. `NextRunTime` when the job is due.
+	// For the runs not scheduled by `Type`, e.g. `RunJob`, it is the same as `StartAt`.
+	ScheduledAt time.Time
+	// The time this attempt actually starts.
+	StartAt time.Time
+	// Starting from `1`.
+	Attempt int
+	// The endpoint of the cluster node that runs the job,
+	// or `<hostname>:<pid>` of the process in standalone and broker mode.
+	Node string
+	// The name of the broker's queue or the cluster node's queue that the job runs on, empty in standalone mode.
+	Queue string
+}
+
+type runInfoKey struct{}
+
+// Return the `RunInfo` of the current run, `false` when the context is not from a job run.
+func RunInfoFromContext(ctx context.Context) (RunInfo, bool) {
+	ri, ok := ctx.Value(runInfoKey{}).(RunInfo)
+	return ri, ok
+}
+
+// Identify the process that runs the job when the scheduler is not a cluster node.
+var processNode = sync.OnceValue(func() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+})
+
+func (s *Scheduler) withRunInfo(ctx context.Context, jr JobRun, recordId uint64, queue string) context.Context {
+	ri := RunInfo{
+		RecordId:    recordId,
+		ScheduledAt: jr.ScheduledAt,
+		StartAt:     time.Now().UTC(),
+		Attempt:     jr.Attempt,
+		Node:        processNode(),
+		Queue:       queue,
+	}
+	if ri.ScheduledAt.IsZero() {
+		ri.ScheduledAt = ri.StartAt
+	}
+	if s.IsClusterMode() {
+		ri.Node = s.clusterNode.Endpoint
+	}
+
+	return context.WithValue(ctx, runInfoKey{}, ri)
+}
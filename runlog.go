@@ -0,0 +1,135 @@
+package agscheduler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Maximum number of bytes of the logs kept for each attempt, the later lines are dropped.
+const runLogMax = 256 << 10
+
+// How often the logs of the running attempt are saved, so that they can be tailed before it ends.
+const runLogFlushInterval = time.Second
+
+// Buffer the logs written by `Func` with the logger from `LoggerFromContext`.
+type runLog struct {
+	s        *Scheduler
+	j        Job
+	recordId uint64
+
+	buf bytes.Buffer
+	// Number of lines dropped after `runLogMax` is reached.
+	dropped int
+	// Whether there are logs not saved yet.
+	dirty bool
+
+	m sync.Mutex
+	// Keep the flushes in order, so that older logs do not overwrite newer ones.
+	flushM sync.Mutex
+}
+
+type runLogKey struct{}
+
+// When the recorder exists, the logs of the attempt are buffered and saved with the record.
+func (s *Scheduler) withRunLog(ctx context.Context, j Job, recordId uint64) (context.Context, *runLog) {
+	if !s.HasRecorder() || recordId == 0 {
+		return ctx, nil
+	}
+
+	rl := &runLog{s: s, j: j, recordId: recordId}
+	logger := slog.New(slog.NewTextHandler(rl, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	return context.WithValue(ctx, runLogKey{}, logger), rl
+}
+
+// Return the logger of the current run, `ctx` is the context passed to `Func`.
+// The lines are saved with the record and can be fetched by `Recorder.GetRecordLogs`,
+// instead of being mixed into the logs of the scheduler.
+// When the recorder does not exist or the context is not from a job run, `slog.Default()` is returned.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(runLogKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// `slog.TextHandler` writes one line per call.
+func (rl *runLog) Write(p []byte) (int, error) {
+	rl.m.Lock()
+	defer rl.m.Unlock()
+
+	rl.dirty = true
+	if rl.dropped > 0 || rl.buf.Len()+len(p) > runLogMax {
+		rl.dropped++
+		return len(p), nil
+	}
+	rl.buf.Write(p)
+
+	return len(p), nil
+}
+
+func (rl *runLog) String() string {
+	if rl.dropped > 0 {
+		return rl.buf.String() + fmt.Sprintf("...(%d lines dropped)\n", rl.dropped)
+	}
+
+	return rl.buf.String()
+}
+
+// Save the logs until the context is done.
+func (rl *runLog) watch(ctx context.Context) {
+	ticker := time.NewTicker(runLogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rl.flush()
+		}
+	}
+}
+
+// Save the logs if there are new lines.
+// The logs are copied under the lock and saved outside it, so `Func` is not blocked by the recorder.
+func (rl *runLog) flush() {
+	rl.flushM.Lock()
+	defer rl.flushM.Unlock()
+
+	rl.m.Lock()
+	if !rl.dirty {
+		rl.m.Unlock()
+		return
+	}
+	rl.dirty = false
+	logs := rl.String()
+	rl.m.Unlock()
+
+	if err := rl.s.recorder.RecordLogs(rl.recordId, logs); err != nil {
+		slog.Error(fmt.Sprintf("Job `%s` record logs error: `%s`", rl.j.FullName(), err))
+	}
+}
+
+// Return the last lines of the logs, all lines if `n` <= 0.
+func tailLines(logs string, n int) string {
+	if n <= 0 {
+		return logs
+	}
+
+	lines := strings.SplitAfter(logs, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return strings.Join(lines, "")
+}
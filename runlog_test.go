@@ -0,0 +1,30 @@
+package agscheduler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLogWrite(t *testing.T) {
+	rl := &runLog{}
+	line := strings.Repeat("a", 1023) + "\n"
+	for range runLogMax/len(line) + 2 {
+		_, err := rl.Write([]byte(line))
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, runLogMax, rl.buf.Len())
+	assert.True(t, strings.HasSuffix(rl.String(), "...(2 lines dropped)\n"))
+}
+
+func TestTailLines(t *testing.T) {
+	logs := "a\nb\nc\n"
+
+	assert.Equal(t, logs, tailLines(logs, 0))
+	assert.Equal(t, "b\nc\n", tailLines(logs, 2))
+	assert.Equal(t, logs, tailLines(logs, 10))
+	assert.Equal(t, "c", tailLines("a\nb\nc", 1))
+	assert.Equal(t, "", tailLines("", 1))
+}
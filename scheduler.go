@@ -2,8 +2,12 @@ package agscheduler
 
 import (
 	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/rpc"
 	"reflect"
 	"runtime/debug"
@@ -20,6 +24,8 @@ var GetClusterNode = (*Scheduler).getClusterNode
 var GetBroker = (*Scheduler).getBroker
 var GetRecorder = (*Scheduler).getRecorder
 var GetListener = (*Scheduler).getListener
+var GetFuncRegistry = (*Scheduler).getFuncRegistry
+var GetExecutorPool = (*Scheduler).getExecutorPool
 
 // In standalone mode, the scheduler only needs to run jobs on a regular basis.
 // In cluster mode, the scheduler also needs to be responsible for allocating jobs to cluster nodes.
@@ -32,6 +38,11 @@ type Scheduler struct {
 	quitChan chan struct{}
 	// It should not be set manually.
 	isRunning bool
+	// The SLA deadlines of jobs before this time have been checked,
+	// if zero, the most recent deadline of each job is checked.
+	slaCheckedAt time.Time
+	// Held while checking the SLA deadlines.
+	slaM sync.Mutex
 
 	// Used in cluster mode, bind to each other and the cluster node.
 	clusterNode *ClusterNode
@@ -41,10 +52,37 @@ type Scheduler struct {
 	// When recorder exist, record the results of job runs.
 	recorder *Recorder
 	listener *Listener
-
-	// Track running job instances for max_instances control
-	runningJobs   map[string]int
-	jobInstancesM sync.RWMutex
+	// When func registry does not exist, use `DefaultFuncRegistry`.
+	funcRegistry *FuncRegistry
+	// When executor pool exist, limit the number of job runs executing at the same time.
+	executorPool *ExecutorPool
+
+	// Wrap the job runs, added by `Use`.
+	middlewares []Middleware
+	middlewareM sync.RWMutex
+
+	// Leases of the running instances, used when the store does not implement `LeaseStore`.
+	leases *leaseTable
+
+	// Rate limiters added by `AddRateLimiter`, keyed by name.
+	rateLimiters map[string]RateLimiter
+	// Token buckets of the rate limiters, used when the store does not implement `RateLimitStore`.
+	rateBuckets  map[string]*RateBucket
+	rateLimiterM sync.Mutex
+
+	// Workflow runs kept in memory, used when the store does not implement `WorkflowRunStore`.
+	workflowRuns map[string]WorkflowRun
+	workflowM    sync.RWMutex
+
+	// Calendars kept in memory, used when the store does not implement `CalendarStore`.
+	calendars map[string]Calendar
+	calendarM sync.RWMutex
+
+	// Cancel functions of the runs on this node, keyed by record id.
+	runCancels map[uint64]context.CancelCauseFunc
+	// Cancel functions of the pending retries on this node, interrupted by `Stop`.
+	retryCancels map[*context.CancelCauseFunc]struct{}
+	runCancelM   sync.Mutex
 
 	statusM sync.RWMutex
 	storeM  sync.RWMutex
@@ -75,37 +113,11 @@ func (s *Scheduler) getStore() Store {
 }
 
 func (s *Scheduler) init() {
-	s.runningJobs = make(map[string]int)
-}
-
-func (s *Scheduler) canRunJob(jobName string, maxInstances int) bool {
-	s.jobInstancesM.RLock()
-	defer s.jobInstancesM.RUnlock()
-
-	return s.runningJobs[jobName] < maxInstances
-}
-
-func (s *Scheduler) incrementJobInstance(jobName string) {
-	s.jobInstancesM.Lock()
-	defer s.jobInstancesM.Unlock()
-
-	s.runningJobs[jobName]++
-}
-
-func (s *Scheduler) decrementJobInstance(jobName string) {
-	s.jobInstancesM.Lock()
-	defer s.jobInstancesM.Unlock()
-
-	if s.runningJobs[jobName] > 0 {
-		s.runningJobs[jobName]--
-	}
-}
-
-func (s *Scheduler) getJobInstanceCount(jobName string) int {
-	s.jobInstancesM.RLock()
-	defer s.jobInstancesM.RUnlock()
-
-	return s.runningJobs[jobName]
+	s.leases = newLeaseTable()
+	s.workflowRuns = make(map[string]WorkflowRun)
+	s.calendars = make(map[string]Calendar)
+	s.runCancels = make(map[uint64]context.CancelCauseFunc)
+	s.retryCancels = make(map[*context.CancelCauseFunc]struct{})
 }
 
 // Bind the cluster node
@@ -150,6 +162,14 @@ func (s *Scheduler) HasBroker() bool {
 	return s.broker != nil
 }
 
+func (s *Scheduler) getBrokerQueue(queue string) (QueuePkg, bool) {
+	if !s.HasBroker() {
+		return QueuePkg{}, false
+	}
+	qPkg, ok := s.broker.Queues[queue]
+	return qPkg, ok
+}
+
 // Bind the recorder
 func (s *Scheduler) SetRecorder(rec *Recorder) error {
 	slog.Info("Scheduler set Recorder.")
@@ -190,15 +210,75 @@ func (s *Scheduler) HasListener() bool {
 	return s.listener != nil
 }
 
+// Bind the func registry
+func (s *Scheduler) SetFuncRegistry(fr *FuncRegistry) {
+	slog.Info("Scheduler set FuncRegistry.")
+
+	s.funcRegistry = fr
+}
+
+func (s *Scheduler) getFuncRegistry() *FuncRegistry {
+	if s.funcRegistry == nil {
+		return DefaultFuncRegistry
+	}
+
+	return s.funcRegistry
+}
+
+// Bind the executor pool
+func (s *Scheduler) SetExecutorPool(ep *ExecutorPool) error {
+	slog.Info("Scheduler set ExecutorPool.")
+
+	if err := ep.init(); err != nil {
+		return err
+	}
+	s.executorPool = ep
+
+	return nil
+}
+
+func (s *Scheduler) getExecutorPool() *ExecutorPool {
+	return s.executorPool
+}
+
+func (s *Scheduler) HasExecutorPool() bool {
+	return s.executorPool != nil
+}
+
 // Calculate the next run time, different job type will be calculated in different ways,
-// when the job is paused, will return `9999-09-09 09:09:09`.
+// when the job is paused, will return `9999-09-09 09:09:09`, or its `EndAt` so that it is ended then.
 func CalcNextRunTime(j Job) (time.Time, error) {
+	return calcNextRunTime(j, time.Now())
+}
+
+// Calculate the next run time after `t`.
+func calcNextRunTime(j Job, t time.Time) (time.Time, error) {
 	timezone, err := time.LoadLocation(j.Timezone)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("job `%s` Timezone `%s` error: %s", j.FullName(), j.Timezone, err)
 	}
 
+	var endAt time.Time
+	if j.EndAt != "" && strings.ToLower(j.Type) != JOB_TYPE_DATETIME {
+		endAt, err = time.ParseInLocation(time.DateTime, j.EndAt, timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("job `%s` EndAt `%s` error: %s", j.FullName(), j.EndAt, err)
+		}
+	}
+
 	if j.Status == JOB_STATUS_PAUSED {
+		if !endAt.IsZero() {
+			if !endAt.After(t) {
+				return time.Time{}, JobEndedError(j.FullName())
+			}
+			return time.Unix(endAt.Unix(), 0).UTC(), nil
+		}
+		nextRunTimeMax, _ := GetNextRunTimeMax()
+		return time.Unix(nextRunTimeMax.Unix(), 0).UTC(), nil
+	}
+
+	// The job in the workflow is run by its upstream jobs.
+	if len(j.Upstreams) != 0 {
 		nextRunTimeMax, _ := GetNextRunTimeMax()
 		return time.Unix(nextRunTimeMax.Unix(), 0).UTC(), nil
 	}
@@ -215,20 +295,125 @@ func CalcNextRunTime(j Job) (time.Time, error) {
 		if err != nil {
 			return time.Time{}, fmt.Errorf("job `%s` Interval `%s` error: %s", j.FullName(), j.Interval, err)
 		}
-		nextRunTime = time.Now().In(timezone).Add(i)
+		if i <= 0 {
+			return time.Time{}, fmt.Errorf("job `%s` Interval must be greater than 0, got %s", j.FullName(), j.Interval)
+		}
+		anchor := t.In(timezone)
+		if j.StartAt != "" {
+			anchor, err = time.ParseInLocation(time.DateTime, j.StartAt, timezone)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("job `%s` StartAt `%s` error: %s", j.FullName(), j.StartAt, err)
+			}
+		} else if !j.LastRunTime.IsZero() && !j.isFixedDelay() {
+			anchor = j.LastRunTime.In(timezone)
+		}
+		switch {
+		case anchor.After(t):
+			nextRunTime = anchor
+		case j.isFixedDelay():
+			nextRunTime = t.In(timezone).Add(i)
+		default:
+			nextRunTime = anchor.Add((t.Sub(anchor)/i + 1) * i)
+		}
 	case JOB_TYPE_CRON:
 		expr, err := cronexpr.Parse(j.CronExpr)
 		if err != nil {
 			return time.Time{}, fmt.Errorf("job `%s` CronExpr `%s` error: %s", j.FullName(), j.CronExpr, err)
 		}
-		nextRunTime = expr.Next(time.Now().In(timezone))
+		nextRunTime = expr.Next(t.In(timezone))
+	case JOB_TYPE_RRULE:
+		rr, err := parseRRule(j.RRule, j.StartAt, timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("job `%s` RRule `%s` error: %s", j.FullName(), j.RRule, err)
+		}
+		var ok bool
+		nextRunTime, ok = rr.next(t)
+		if !ok {
+			return time.Time{}, JobEndedError(j.FullName())
+		}
+	case JOB_TYPE_COMBINED:
+		nextRunTime, err = calcCombinedNextRunTime(j, t)
+		if err != nil {
+			return time.Time{}, err
+		}
 	default:
 		return time.Time{}, fmt.Errorf("job `%s` Type `%s` unknown", j.FullName(), j.Type)
 	}
 
+	if !endAt.IsZero() && nextRunTime.After(endAt) {
+		return time.Time{}, JobEndedError(j.FullName())
+	}
+
 	return time.Unix(nextRunTime.Unix(), 0).UTC(), nil
 }
 
+// Maximum number of missed runs replayed when `Coalesce` is false,
+// the first `dueRunTimesMax` run times are replayed, followed by the latest one,
+// and the run times between them are dropped.
+const dueRunTimesMax = 100
+
+// Calculate the run times that are due at `now`, starting from `NextRunTime`.
+// More than one run time is returned when the scheduler has missed several runs,
+// when `Coalesce` is true, only the latest of them is returned.
+func (s *Scheduler) calcDueRunTimes(j Job, now time.Time) []time.Time {
+	runTimes := []time.Time{}
+	if !j.NextRunTime.Before(now) {
+		return runTimes
+	}
+
+	runTime := j.NextRunTime
+	runTimes = append(runTimes, runTime)
+	if strings.ToLower(j.Type) == JOB_TYPE_DATETIME || j.isFixedDelay() {
+		return runTimes
+	}
+	if j.IsCoalesce() {
+		runTimes[0] = s.calcLatestDueRunTime(j, runTime, now)
+		return runTimes
+	}
+
+	for {
+		nextRunTime, err := s.calcNextRunTime(j, runTime)
+		if err != nil || !nextRunTime.After(runTime) || !nextRunTime.Before(now) {
+			break
+		}
+		if len(runTimes) == dueRunTimesMax {
+			latest := s.calcLatestDueRunTime(j, runTime, now)
+			if latest.After(nextRunTime) {
+				slog.Warn(fmt.Sprintf("Job `%s` missed more than %d runs, the runs from `%s` to before `%s` are dropped",
+					j.FullName(), dueRunTimesMax+1, nextRunTime.String(), latest.String()))
+			}
+			runTimes = append(runTimes, latest)
+			break
+		}
+		runTime = nextRunTime
+		runTimes = append(runTimes, runTime)
+	}
+
+	return runTimes
+}
+
+// Calculate the latest run time before `now`, `from` is a run time before `now`.
+// It searches backwards from `now` with doubling steps,
+// so that the runs missed during a long outage are not calculated one by one.
+func (s *Scheduler) calcLatestDueRunTime(j Job, from, now time.Time) time.Time {
+	latest := from
+	for step := time.Second; now.Add(-step).After(from); step *= 2 {
+		runTime, err := s.calcNextRunTime(j, now.Add(-step))
+		if err == nil && runTime.After(from) && runTime.Before(now) {
+			latest = runTime
+			break
+		}
+	}
+
+	for {
+		nextRunTime, err := s.calcNextRunTime(j, latest)
+		if err != nil || !nextRunTime.After(latest) || !nextRunTime.Before(now) {
+			return latest
+		}
+		latest = nextRunTime
+	}
+}
+
 func (s *Scheduler) AddJob(j Job) (Job, error) {
 	s.storeM.Lock()
 	defer s.storeM.Unlock()
@@ -236,6 +421,25 @@ func (s *Scheduler) AddJob(j Job) (Job, error) {
 	if err := j.init(); err != nil {
 		return Job{}, err
 	}
+	if err := j.checkFunc(s.getFuncRegistry()); err != nil {
+		return Job{}, err
+	}
+	if len(j.Upstreams) != 0 {
+		js, err := s.store.GetAllJobs()
+		if err != nil {
+			return Job{}, err
+		}
+		if err := checkUpstreams(j, js); err != nil {
+			return Job{}, err
+		}
+	}
+	if j.Calendar != "" {
+		nextRunTime, err := s.calcNextRunTime(j, time.Now())
+		if err != nil {
+			return Job{}, err
+		}
+		j.NextRunTime = nextRunTime
+	}
 
 	slog.Info(fmt.Sprintf("Scheduler add job `%s`.", j.FullName()))
 
@@ -282,8 +486,20 @@ func (s *Scheduler) _updateJob(j Job) (Job, error) {
 	if err := j.check(); err != nil {
 		return Job{}, err
 	}
+	if err := j.checkFunc(s.getFuncRegistry()); err != nil {
+		return Job{}, err
+	}
+	if len(j.Upstreams) != 0 {
+		js, err := s.store.GetAllJobs()
+		if err != nil {
+			return Job{}, err
+		}
+		if err := checkUpstreams(j, js); err != nil {
+			return Job{}, err
+		}
+	}
 
-	nextRunTime, err := CalcNextRunTime(j)
+	nextRunTime, err := s.calcNextRunTime(j, time.Now())
 	if err != nil {
 		return Job{}, err
 	}
@@ -308,7 +524,14 @@ func (s *Scheduler) UpdateJob(j Job) (Job, error) {
 	s.storeM.Lock()
 	defer s.storeM.Unlock()
 
-	j, err := s._updateJob(j)
+	oJ, err := s.store.GetJob(j.Id)
+	if err != nil {
+		return Job{}, err
+	}
+	// The run counter is updated automatically and cannot be set manually.
+	j.Runs = oJ.Runs
+
+	j, err = s._updateJob(j)
 	if err != nil {
 		return Job{}, err
 	}
@@ -316,6 +539,36 @@ func (s *Scheduler) UpdateJob(j Job) (Job, error) {
 	return j, nil
 }
 
+// Rewrite `FuncName` of the stored jobs that use an alias to the registered name,
+// called after the functions are renamed and registered with the old names in `Aliases`.
+// Return the number of jobs migrated.
+func (s *Scheduler) MigrateFuncNames() (int, error) {
+	s.storeM.Lock()
+	defer s.storeM.Unlock()
+
+	js, err := s.store.GetAllJobs()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, j := range js {
+		fName, ok := s.getFuncRegistry().resolveFuncName(j.FuncName)
+		if !ok || fName == j.FuncName {
+			continue
+		}
+
+		slog.Info(fmt.Sprintf("Scheduler migrate job `%s` FuncName `%s` to `%s`.", j.FullName(), j.FuncName, fName))
+		j.FuncName = fName
+		if err := s.store.UpdateJob(j); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
 func (s *Scheduler) _deleteJob(id string) error {
 	slog.Info(fmt.Sprintf("Scheduler delete jobId `%s`.", id))
 
@@ -352,6 +605,21 @@ func (s *Scheduler) DeleteAllJobs() error {
 	return nil
 }
 
+// Update the job, and end it if it has passed its `EndAt`,
+// in this case, `JobEndedError` is returned.
+func (s *Scheduler) _updateJobOrEnd(j Job) (Job, error) {
+	uJ, err := s._updateJob(j)
+	var jeErr JobEndedError
+	if errors.As(err, &jeErr) {
+		if err := s._endJob(j); err != nil {
+			return Job{}, fmt.Errorf("end job `%s` error: %s", j.FullName(), err)
+		}
+	}
+
+	return uJ, err
+}
+
+// The job that has passed its `EndAt` is ended, and `JobEndedError` is returned.
 func (s *Scheduler) PauseJob(id string) (Job, error) {
 	s.storeM.Lock()
 	defer s.storeM.Unlock()
@@ -365,7 +633,7 @@ func (s *Scheduler) PauseJob(id string) (Job, error) {
 
 	j.Status = JOB_STATUS_PAUSED
 
-	j, err = s._updateJob(j)
+	j, err = s._updateJobOrEnd(j)
 	if err != nil {
 		return Job{}, err
 	}
@@ -374,6 +642,7 @@ func (s *Scheduler) PauseJob(id string) (Job, error) {
 	return j, nil
 }
 
+// The job that has passed its `EndAt` while paused is ended, and `JobEndedError` is returned.
 func (s *Scheduler) ResumeJob(id string) (Job, error) {
 	s.storeM.Lock()
 	defer s.storeM.Unlock()
@@ -387,7 +656,7 @@ func (s *Scheduler) ResumeJob(id string) (Job, error) {
 
 	j.Status = JOB_STATUS_RUNNING
 
-	j, err = s._updateJob(j)
+	j, err = s._updateJobOrEnd(j)
 	if err != nil {
 		return Job{}, err
 	}
@@ -397,37 +666,91 @@ func (s *Scheduler) ResumeJob(id string) (Job, error) {
 }
 
 // When broker exist, push job to queue to run the `RunJob`.
-func (s *Scheduler) pushJob(queue string, j Job) {
+func (s *Scheduler) pushJob(queue string, jr JobRun) {
 	defer func() {
 		if err := recover(); err != nil {
-			slog.Error(fmt.Sprintf("Job `%s` push to queue:`%s` error: %s", j.FullName(), queue, err))
+			slog.Error(fmt.Sprintf("Job `%s` push to queue:`%s` error: %s", jr.Job.FullName(), queue, err))
 			slog.Debug(string(debug.Stack()))
 		}
 	}()
 
-	bJ, err := JobMarshal(j)
+	bJr, err := JobRunMarshal(jr)
 	if err != nil {
 		panic(err)
 	}
-	if err := s.broker.pushJob(queue, bJ); err != nil {
+	if err := s.broker.pushJob(queue, bJr, jr.Job.Priority); err != nil {
 		panic(err)
 	}
 }
 
 // Used in standalone mode.
-func (s *Scheduler) _runJob(j Job) {
-	if !s.canRunJob(j.Name, j.MaxInstances) {
-		slog.Warn(fmt.Sprintf("Job `%s` skipped due to max_instances limit (%d/%d)", j.FullName(), s.getJobInstanceCount(j.Name), j.MaxInstances))
+// The queue is the name of the broker's queue or the cluster node's queue that the job runs on.
+// The lease is acquired before the run is admitted to the executor pool,
+// so a run that waits in the pool is not skipped by `MaxInstances` afterwards.
+//
+//	@return a channel closed when the attempt ends or the run is skipped.
+func (s *Scheduler) _runJob(jr JobRun, queue string) <-chan struct{} {
+	j := jr.Job
+	done := make(chan struct{})
+
+	if j.RateLimiter != "" && !s.waitRateLimiter(j) {
+		close(done)
+		return done
+	}
+
+	l := newLease(j)
+	ok, err := s.acquireLeaseRetry(j, l)
+	if err != nil {
+		result := fmt.Sprintf("acquire lease error: %s", err)
+		slog.Error(fmt.Sprintf("Job `%s` %s", j.FullName(), result))
+		s.dispatchEvent(EventPkg{EVENT_JOB_ERROR, j.Id, err})
+		s._recordJobNotRun(j, RECORD_STATUS_ERROR, result)
+		close(done)
+		return done
+	}
+	if !ok {
+		s._skipJob(j, fmt.Sprintf("skipped due to max_instances limit (%d)", j.MaxInstances))
 		s.dispatchEvent(EventPkg{EVENT_JOB_MAX_INSTANCES, j.Id, nil})
-		return
+		close(done)
+		return done
+	}
+	releaseLease := s.holdLease(j, l)
+
+	run := func() {
+		defer close(done)
+		defer releaseLease()
+		s._executeJob(jr, queue)
+	}
+	if s.HasExecutorPool() {
+		s.executorPool.submit(queue, j.Priority, run)
+	} else {
+		go run()
 	}
 
-	s.incrementJobInstance(j.Name)
-	defer s.decrementJobInstance(j.Name)
+	return done
+}
+
+// Run the attempt of the job that holds the lease, and retry it if it fails.
+func (s *Scheduler) _executeJob(jr JobRun, queue string) {
+	j := jr.Job
+	jr.Attempt = max(1, jr.Attempt)
+	attempt := jr.Attempt
+
+	var status string
+	var result string
+	retrying := false
+	defer func() {
+		if !retrying {
+			s.completeJob(j, status, result)
+		}
+	}()
 
-	f := reflect.ValueOf(FuncMap[j.FuncName].Func)
+	fp, _ := s.getFuncRegistry().lookupFunc(j.FuncName)
+	f := fp.funcValue()
 	if f.IsNil() {
 		slog.Warn(fmt.Sprintf("Job `%s` Func `%s` unregistered", j.FullName(), j.FuncName))
+		status = RECORD_STATUS_ERROR
+		result = FuncUnregisteredError(j.FuncName).Error()
 	} else {
 		slog.Info(fmt.Sprintf("Job `%s` is running, next run time: `%s`", j.FullName(), j.NextRunTimeWithTimezone().String()))
 
@@ -435,65 +758,330 @@ func (s *Scheduler) _runJob(j Job) {
 		if err != nil {
 			e := &JobTimeoutError{FullName: j.FullName(), Timeout: j.Timeout, Err: err}
 			slog.Error(e.Error())
+			status = RECORD_STATUS_ERROR
+			result = e.Error()
 			return
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-
+		var noRetry bool
 		var rId uint64
-		var status string
-		var result string
-		if s.HasRecorder() {
-			rId, err = s.recorder.RecordMetadata(j)
-			if err != nil {
-				slog.Error(fmt.Sprintf("Job `%s` record metadata error: `%s`", j.FullName(), err))
-				return
-			}
+		var done <-chan struct{}
+		status, result, noRetry, rId, done = s._runJobAttempt(jr, f, timeout, queue)
+		if noRetry || !j.isRetryable(status) {
+			return
 		}
 
-		ch := make(chan error, 1)
-		go func() {
-			defer close(ch)
-			defer func() {
-				if err := recover(); err != nil {
-					slog.Error(fmt.Sprintf("Job `%s` run error: %s", j.FullName(), err))
-					s.dispatchEvent(EventPkg{EVENT_JOB_ERROR, j.Id, err})
-					slog.Debug(string(debug.Stack()))
-					status = RECORD_STATUS_ERROR
-					result = fmt.Sprintf("%s", err)
-				}
-			}()
+		maxAttempts := max(1, j.MaxAttempts)
+		if attempt < maxAttempts {
+			retrying = true
+			s.retryJob(JobRun{Job: j, Attempt: attempt + 1, ScheduledAt: jr.ScheduledAt}, queue, rId, done)
+			return
+		}
+		if maxAttempts > 1 {
+			slog.Error(fmt.Sprintf("Job `%s` retries exhausted after %d attempts", j.FullName(), maxAttempts))
+			s.dispatchEvent(EventPkg{EVENT_JOB_RETRIES_EXHAUSTED, j.Id, nil})
+		}
+	}
+}
 
-			rValues := f.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(j)})
-			result = rValues[0].Interface().(string)
-		}()
+// Run the next attempt after the retry delay, on this node or pushed to the same queue of the broker,
+// the lease and the executor pool slot of the failed attempt are released meanwhile.
+// A timed-out attempt is retried after its `Func` returns.
+// The pending retry is interrupted by `Stop`, or by `CancelRun` with the record id of the failed attempt.
+func (s *Scheduler) retryJob(jr JobRun, queue string, recordId uint64, done <-chan struct{}) {
+	j := jr.Job
+	delay := j.retryDelay(jr.Attempt - 1)
+	slog.Warn(fmt.Sprintf("Job `%s` will be retried in %s, attempt %d/%d", j.FullName(), delay, jr.Attempt, j.MaxAttempts))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	s.addRetryCancel(recordId, &cancel)
+
+	go func() {
+		defer s.deleteRetryCancel(recordId, &cancel)
 
 		select {
-		case <-ch:
-			s.dispatchEvent(EventPkg{EVENT_JOB_EXECUTED, j.Id, nil})
-			if status == "" {
-				status = RECORD_STATUS_COMPLETED
-			}
+		case <-done:
 		case <-ctx.Done():
-			slog.Warn(fmt.Sprintf("Job `%s` run timeout", j.FullName()))
-			s.dispatchEvent(EventPkg{EVENT_JOB_TIMEOUT, j.Id, nil})
-			status = RECORD_STATUS_TIMEOUT
+		}
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			slog.Warn(fmt.Sprintf("Job `%s` retry cancelled", j.FullName()))
+			s.dispatchEvent(EventPkg{EVENT_JOB_CANCELLED, j.Id, recordId})
+			s.completeJob(j, RECORD_STATUS_CANCELLED, context.Cause(ctx).Error())
+			return
+		case <-timer.C:
 		}
 
-		if s.HasRecorder() {
-			err := s.recorder.RecordResult(rId, status, result)
-			if err != nil {
-				slog.Error(fmt.Sprintf("Job `%s` record result error: `%s`", j.FullName(), err))
-				return
+		if _, ok := s.getBrokerQueue(queue); ok {
+			s.pushJob(queue, jr)
+		} else {
+			s._runJob(jr, queue)
+		}
+	}()
+}
+
+// Run `Func` once and return the status and result of this attempt,
+// whether `Func` returns a `NonRetryableError`, the record id,
+// and a channel closed when `Func` returns, which may be later than the attempt when it times out.
+// When the recorder fails to record metadata, `Func` is not run and the status is `RECORD_STATUS_ERROR`.
+func (s *Scheduler) _runJobAttempt(jr JobRun, f reflect.Value, timeout time.Duration, queue string) (string, string, bool, uint64, <-chan struct{}) {
+	j := jr.Job
+	runCtx, cancelRun := context.WithCancelCause(context.Background())
+	defer cancelRun(nil)
+	ctx, cancel := context.WithTimeout(runCtx, timeout)
+	defer cancel()
+
+	var rId uint64
+	var status string
+	var result string
+	var noRetry bool
+	var err error
+	if s.HasRecorder() {
+		rId, err = s.recorder.RecordAttemptMetadata(j, jr.Attempt)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record metadata error: `%s`", j.FullName(), err))
+			done := make(chan struct{})
+			close(done)
+			return RECORD_STATUS_ERROR, fmt.Sprintf("record metadata error: %s", err), false, 0, done
+		}
+		s.addRunCancel(rId, cancelRun)
+		defer s.deleteRunCancel(rId)
+	}
+	ctx = s.withRunInfo(ctx, jr, rId, queue)
+	ctx, rp := s.withRunProgress(ctx, j, rId)
+	if heartbeatTimeout, err := time.ParseDuration(j.HeartbeatTimeout); err == nil && heartbeatTimeout > 0 {
+		go rp.watch(ctx, heartbeatTimeout)
+	}
+	ctx, rl := s.withRunLog(ctx, j, rId)
+	if rl != nil {
+		go rl.watch(ctx)
+	}
+	var softTimer *time.Timer
+	if softTimeout, err := time.ParseDuration(j.SoftTimeout); err == nil && softTimeout > 0 {
+		softTimer = time.AfterFunc(softTimeout, func() { s._softTimeoutJob(j, rId) })
+	}
+
+	// The outcome is sent over `ch`, so it is not written after the attempt times out.
+	ch := make(chan runOutcome, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var o runOutcome
+		defer func() {
+			if err := recover(); err != nil {
+				slog.Error(fmt.Sprintf("Job `%s` run error: %s", j.FullName(), err))
+				s.dispatchEvent(EventPkg{EVENT_JOB_ERROR, j.Id, err})
+				slog.Debug(string(debug.Stack()))
+				o.status = RECORD_STATUS_ERROR
+				o.result = fmt.Sprintf("%s", err)
 			}
+			ch <- o
+		}()
+
+		handler := s.applyMiddlewares(func(ctx context.Context, j Job, recordId uint64) (status string, result string) {
+			status, result, o.noRetry = s._callFunc(ctx, j, f)
+			return
+		})
+		o.status, o.result = handler(ctx, j, rId)
+	}()
+
+	select {
+	case o := <-ch:
+		status, result, noRetry = o.status, o.result, o.noRetry
+		s.dispatchEvent(EventPkg{EVENT_JOB_EXECUTED, j.Id, nil})
+		if status == "" {
+			status = RECORD_STATUS_COMPLETED
+		}
+	case <-ctx.Done():
+		if errors.Is(context.Cause(ctx), errRunCancelled) {
+			slog.Warn(fmt.Sprintf("Job `%s` run cancelled", j.FullName()))
+			s.dispatchEvent(EventPkg{EVENT_JOB_CANCELLED, j.Id, rId})
+			status = RECORD_STATUS_CANCELLED
+			break
+		}
+		slog.Warn(fmt.Sprintf("Job `%s` run timeout", j.FullName()))
+		s.dispatchEvent(EventPkg{EVENT_JOB_TIMEOUT, j.Id, nil})
+		status = RECORD_STATUS_TIMEOUT
+	}
+
+	if softTimer != nil {
+		softTimer.Stop()
+	}
+	if rl != nil {
+		rl.flush()
+	}
+	if s.HasRecorder() {
+		err := s.recorder.RecordResult(rId, status, result)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record result error: `%s`", j.FullName(), err))
 		}
 	}
+
+	return status, result, noRetry, rId, done
+}
+
+// The status and result returned by the middlewares, and whether `Func` returns a `NonRetryableError`.
+type runOutcome struct {
+	status  string
+	result  string
+	noRetry bool
+}
+
+// Called when the run exceeds `Job.SoftTimeout`, the run keeps going.
+func (s *Scheduler) _softTimeoutJob(j Job, recordId uint64) {
+	slog.Warn(fmt.Sprintf("Job `%s` run exceeds soft timeout `%s`", j.FullName(), j.SoftTimeout))
+	if s.HasRecorder() && recordId != 0 {
+		if err := s.recorder.RecordSoftTimeout(recordId); err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record soft timeout error: `%s`", j.FullName(), err))
+		}
+	}
+	s.dispatchEvent(EventPkg{EVENT_JOB_SOFT_TIMEOUT, j.Id, recordId})
+}
+
+// Call `Func` and return the status and result,
+// and whether `Func` returns a `NonRetryableError`.
+func (s *Scheduler) _callFunc(ctx context.Context, j Job, f reflect.Value) (string, string, bool) {
+	rValues := f.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(j)})
+	if len(rValues) == 1 {
+		return RECORD_STATUS_COMPLETED, rValues[0].Interface().(string), false
+	}
+
+	rErr, _ := rValues[1].Interface().(error)
+	var skipErr SkipError
+	var nonRetryableErr *NonRetryableError
+	switch {
+	case rErr == nil:
+		result, err := marshalFuncResult(rValues[0].Interface())
+		if err != nil {
+			panic(err)
+		}
+		return RECORD_STATUS_COMPLETED, result, false
+	case errors.As(rErr, &skipErr):
+		slog.Info(fmt.Sprintf("Job `%s` run %s", j.FullName(), rErr))
+		return RECORD_STATUS_SKIPPED, rErr.Error(), false
+	default:
+		slog.Error(fmt.Sprintf("Job `%s` run error: %s", j.FullName(), rErr))
+		s.dispatchEvent(EventPkg{EVENT_JOB_ERROR, j.Id, rErr})
+		return RECORD_STATUS_ERROR, rErr.Error(), errors.As(rErr, &nonRetryableErr)
+	}
+}
+
+// The result returned by `TypedFunc` is saved as JSON, unless it is a string.
+func marshalFuncResult(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		bV, err := json.Marshal(v)
+		return string(bV), err
+	}
+}
+
+func (s *Scheduler) addRunCancel(recordId uint64, cancel context.CancelCauseFunc) {
+	s.runCancelM.Lock()
+	defer s.runCancelM.Unlock()
+
+	s.runCancels[recordId] = cancel
+}
+
+func (s *Scheduler) deleteRunCancel(recordId uint64) {
+	s.runCancelM.Lock()
+	defer s.runCancelM.Unlock()
+
+	delete(s.runCancels, recordId)
+}
+
+// The pending retry is cancelled by the record id of the failed attempt.
+func (s *Scheduler) addRetryCancel(recordId uint64, cancel *context.CancelCauseFunc) {
+	s.runCancelM.Lock()
+	defer s.runCancelM.Unlock()
+
+	s.retryCancels[cancel] = struct{}{}
+	if recordId != 0 {
+		s.runCancels[recordId] = *cancel
+	}
+}
+
+func (s *Scheduler) deleteRetryCancel(recordId uint64, cancel *context.CancelCauseFunc) {
+	s.runCancelM.Lock()
+	defer s.runCancelM.Unlock()
+
+	delete(s.retryCancels, cancel)
+	if recordId != 0 {
+		delete(s.runCancels, recordId)
+	}
+}
+
+// Cancel the pending retries on this node.
+func (s *Scheduler) cancelRetries() {
+	s.runCancelM.Lock()
+	defer s.runCancelM.Unlock()
+
+	for cancel := range s.retryCancels {
+		(*cancel)(errRetryCancelled)
+	}
+}
+
+// Cancel the context passed to `Func` of the run with the record id,
+// `Func` needs to return when `ctx.Done()` to actually stop.
+// The run is recorded as `RECORD_STATUS_CANCELLED` and is not retried.
+// The pending retry of the run is cancelled by the record id of the failed attempt.
+// In cluster mode, the run is cancelled on the node that is running it.
+// In broker mode, the cancellation is also pushed to the queues that implement `CancelQueue`,
+// and the workers of the scheduler running it cancel the run,
+// in this case, it returns nil without knowing whether the run exists.
+// The recorder is required, otherwise `ErrNoRecorder` is returned.
+func (s *Scheduler) CancelRun(recordId uint64) error {
+	if !s.HasRecorder() {
+		return ErrNoRecorder
+	}
+
+	slog.Info(fmt.Sprintf("Scheduler cancel run of recordId `%d`.", recordId))
+
+	err := s.CancelLocalRun(recordId)
+	if err == nil {
+		return nil
+	}
+	if s.IsClusterMode() {
+		if err = s.clusterNode.cancelRunRemote(recordId); err == nil {
+			return nil
+		}
+	}
+	if s.HasBroker() {
+		pushed, pErr := s.broker.pushCancel(recordId)
+		if pErr != nil {
+			return pErr
+		}
+		if pushed {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// Cancel the run with the record id only if it is running on this node.
+func (s *Scheduler) CancelLocalRun(recordId uint64) error {
+	s.runCancelM.Lock()
+	defer s.runCancelM.Unlock()
+
+	cancel, ok := s.runCancels[recordId]
+	if !ok {
+		return RunNotFoundError(recordId)
+	}
+	cancel(errRunCancelled)
+
+	return nil
 }
 
 // Used in cluster mode.
 // Call the RPC API of the other node to run the `RunJob`.
-func (s *Scheduler) _runJobRemote(node *ClusterNode, j Job) {
+func (s *Scheduler) _runJobRemote(node *ClusterNode, jr JobRun) {
+	j := jr.Job
 	defer func() {
 		if err := recover(); err != nil {
 			slog.Error(fmt.Sprintf("Job `%s` _runJobRemote error: %s", j.FullName(), err))
@@ -501,6 +1089,9 @@ func (s *Scheduler) _runJobRemote(node *ClusterNode, j Job) {
 		}
 	}()
 
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+
 	rClient, err := rpc.DialHTTP("tcp", node.Endpoint)
 	if err != nil {
 		slog.Error(fmt.Sprintf("Failed to connect to cluster node: `%s`, error: %s", node.Endpoint, err))
@@ -520,7 +1111,7 @@ func (s *Scheduler) _runJobRemote(node *ClusterNode, j Job) {
 			}
 		}()
 
-		ch <- rClient.Call("CRPCService.RunJob", j, &r)
+		ch <- rClient.Call("CRPCService.RunJob", jr, &r)
 	}()
 	select {
 	case err := <-ch:
@@ -532,7 +1123,111 @@ func (s *Scheduler) _runJobRemote(node *ClusterNode, j Job) {
 	}
 }
 
-func (s *Scheduler) _flushJob(j Job, now time.Time) error {
+// Called when the run is later than `MisfireGraceTime`.
+func (s *Scheduler) _missJob(j Job, runTime time.Time, now time.Time) {
+	result := fmt.Sprintf("run time `%s` missed by %s", runTime.String(), now.Sub(runTime).Truncate(time.Second))
+	slog.Warn(fmt.Sprintf("Job `%s` %s", j.FullName(), result))
+
+	if s.HasRecorder() {
+		rId, err := s.recorder.RecordMetadata(j)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record metadata error: `%s`", j.FullName(), err))
+		} else if err := s.recorder.RecordResult(rId, RECORD_STATUS_MISSED, result); err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record result error: `%s`", j.FullName(), err))
+		}
+	}
+
+	s.dispatchEvent(EventPkg{EVENT_JOB_MISSED, j.Id, runTime})
+}
+
+// Called when the run is skipped before running `Func`, e.g. `MaxInstances` is reached.
+func (s *Scheduler) _skipJob(j Job, result string) {
+	slog.Warn(fmt.Sprintf("Job `%s` %s", j.FullName(), result))
+
+	s._recordJobNotRun(j, RECORD_STATUS_SKIPPED, result)
+}
+
+// Record the run that ends without calling `Func`, and complete it like the runs that call it.
+func (s *Scheduler) _recordJobNotRun(j Job, status, result string) {
+	if s.HasRecorder() {
+		rId, err := s.recorder.RecordMetadata(j)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record metadata error: `%s`", j.FullName(), err))
+		} else if err := s.recorder.RecordResult(rId, status, result); err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` record result error: `%s`", j.FullName(), err))
+		}
+	}
+
+	s.completeJob(j, status, result)
+}
+
+// Report the completion of a run of the fixed-delay job or the workflow job to the scheduler,
+// in cluster mode, it is reported to the main node.
+func (s *Scheduler) completeJob(j Job, status, result string) {
+	if !j.isFixedDelay() && j.WorkflowRunId == "" {
+		return
+	}
+
+	r := JobResult{Job: j, Status: status, Result: result}
+	var err error
+	if s.IsClusterMode() && !s.clusterNode.IsMainNode() {
+		err = s.clusterNode.completeJobRemote(r)
+	} else {
+		err = s.CompleteJob(r)
+	}
+	if err != nil {
+		slog.Error(fmt.Sprintf("Job `%s` complete error: %s", j.FullName(), err))
+	}
+}
+
+// Hold the next run of the fixed-delay job until the running one completes,
+// `Timeout` is added in case the completion is lost.
+func (s *Scheduler) _holdJob(j Job, now time.Time) error {
+	j, err := s.store.GetJob(j.Id)
+	if err != nil {
+		// The job may have been deleted in `_flushJob`.
+		var jnfErr JobNotFoundError
+		if errors.As(err, &jnfErr) {
+			return nil
+		}
+		return err
+	}
+	if j.Status == JOB_STATUS_PAUSED {
+		return nil
+	}
+
+	timeout, _ := time.ParseDuration(j.Timeout)
+	interval, _ := time.ParseDuration(j.Interval)
+	j.NextRunTime = time.Unix(now.Add(timeout+interval).Unix(), 0).UTC()
+
+	return s.store.UpdateJob(j)
+}
+
+// Called when the job has passed its `EndAt`.
+func (s *Scheduler) _endJob(j Job) error {
+	slog.Info(fmt.Sprintf("Scheduler end job `%s`.", j.FullName()))
+
+	if err := s._deleteJob(j.Id); err != nil {
+		return err
+	}
+
+	s.dispatchEvent(EventPkg{EVENT_JOB_ENDED, j.Id, nil})
+	return nil
+}
+
+// Called when the job has reached its `MaxRuns`.
+func (s *Scheduler) _finishJob(j Job) error {
+	slog.Info(fmt.Sprintf("Scheduler finish job `%s` after %d runs.", j.FullName(), j.Runs))
+
+	if err := s._deleteJob(j.Id); err != nil {
+		return err
+	}
+
+	s.dispatchEvent(EventPkg{EVENT_JOB_MAX_RUNS_REACHED, j.Id, nil})
+	return nil
+}
+
+func (s *Scheduler) _flushJob(j Job, lastRunTime time.Time, runs int, now time.Time) error {
 	if j.Type == JOB_TYPE_DATETIME {
 		if j.NextRunTime.Before(now) {
 			if err := s._deleteJob(j.Id); err != nil {
@@ -544,8 +1239,22 @@ func (s *Scheduler) _flushJob(j Job, now time.Time) error {
 		if err != nil {
 			return fmt.Errorf("get job `%s` error: %s", j.FullName(), err)
 		}
-		j.LastRunTime = time.Unix(now.Unix(), 0).UTC()
+		j.LastRunTime = time.Unix(lastRunTime.Unix(), 0).UTC()
+		j.Runs += runs
+		if j.MaxRuns > 0 && j.Runs >= j.MaxRuns {
+			if err := s._finishJob(j); err != nil {
+				return fmt.Errorf("finish job `%s` error: %s", j.FullName(), err)
+			}
+			return nil
+		}
 		if _, err := s._updateJob(j); err != nil {
+			var jeErr JobEndedError
+			if errors.As(err, &jeErr) {
+				if err := s._endJob(j); err != nil {
+					return fmt.Errorf("end job `%s` error: %s", j.FullName(), err)
+				}
+				return nil
+			}
 			return fmt.Errorf("update job `%s` error: %s", j.FullName(), err)
 		}
 	}
@@ -554,14 +1263,15 @@ func (s *Scheduler) _flushJob(j Job, now time.Time) error {
 }
 
 // All nodes are equal and may pick myself.
-func (s *Scheduler) _scheduleJob(j Job) error {
+func (s *Scheduler) _scheduleJob(jr JobRun) error {
+	j := jr.Job
 	if s.HasBroker() {
 		// When broker exist.
 		queue, err := s.broker.choiceQueue(j.Queues)
 		if err != nil {
 			return fmt.Errorf("broker's queues with queue `%s` does not exist", j.Queues)
 		}
-		go s.pushJob(queue, j)
+		go s.pushJob(queue, jr)
 	} else {
 		if s.IsClusterMode() {
 			// In cluster mode.
@@ -569,10 +1279,10 @@ func (s *Scheduler) _scheduleJob(j Job) error {
 			if err != nil {
 				return fmt.Errorf("cluster node with queue `%s` does not exist", j.Queues)
 			}
-			go s._runJobRemote(node, j)
+			go s._runJobRemote(node, jr)
 		} else {
 			// In standalone mode.
-			go s._runJob(j)
+			go s._runJob(jr, "")
 		}
 	}
 
@@ -582,7 +1292,63 @@ func (s *Scheduler) _scheduleJob(j Job) error {
 func (s *Scheduler) RunJob(j Job) error {
 	slog.Info(fmt.Sprintf("Scheduler run job `%s`.", j.FullName()))
 
-	go s._runJob(j)
+	return s.runJob(JobRun{Job: j})
+}
+
+// Run the attempt of the job on this node.
+func (s *Scheduler) runJob(jr JobRun) error {
+	queue := ""
+	if s.IsClusterMode() {
+		queue = s.clusterNode.Queue
+	}
+	go s._runJob(jr, queue)
+
+	return nil
+}
+
+// Used for fixed-delay jobs, the next run is `Interval` after the run completed.
+// Used for workflow jobs, the downstream jobs run after the upstream jobs completed.
+func (s *Scheduler) CompleteJob(r JobResult) error {
+	s.storeM.Lock()
+	defer s.storeM.Unlock()
+
+	if r.Job.WorkflowRunId != "" {
+		if err := s._completeWorkflowStep(r); err != nil {
+			return err
+		}
+	}
+	if !r.Job.isFixedDelay() {
+		return nil
+	}
+
+	j, err := s.store.GetJob(r.Job.Id)
+	if err != nil {
+		return err
+	}
+	if !j.isFixedDelay() || j.Status == JOB_STATUS_PAUSED {
+		return nil
+	}
+
+	nextRunTime, err := s.calcNextRunTime(j, time.Now())
+	if err != nil {
+		var jeErr JobEndedError
+		if errors.As(err, &jeErr) {
+			return s._endJob(j)
+		}
+		return err
+	}
+	j.NextRunTime = nextRunTime
+
+	lastNextWakeupInterval := s.getNextWakeupInterval()
+
+	if err := s.store.UpdateJob(j); err != nil {
+		return err
+	}
+
+	nextWakeupInterval := s.getNextWakeupInterval()
+	if nextWakeupInterval < lastNextWakeupInterval {
+		s.wakeup()
+	}
 
 	return nil
 }
@@ -591,7 +1357,7 @@ func (s *Scheduler) RunJob(j Job) error {
 func (s *Scheduler) ScheduleJob(j Job) error {
 	slog.Info(fmt.Sprintf("Scheduler schedule job `%s`.", j.FullName()))
 
-	err := s._scheduleJob(j)
+	err := s._scheduleJob(JobRun{Job: j})
 	if err != nil {
 		return fmt.Errorf("scheduler schedule job `%s` error: %s", j.FullName(), err)
 	}
@@ -599,6 +1365,23 @@ func (s *Scheduler) ScheduleJob(j Job) error {
 	return nil
 }
 
+// Return the jobs due before `now`, those with higher priority first,
+// and those with the same priority by next run time.
+func dueJobs(js []Job, now time.Time) []Job {
+	// If there are ineligible job, subsequent job do not need to be checked.
+	sort.Sort(JobSlice(js))
+	dueJs := []Job{}
+	for _, j := range js {
+		if !j.NextRunTime.Before(now) {
+			break
+		}
+		dueJs = append(dueJs, j)
+	}
+	sort.SliceStable(dueJs, func(i, k int) bool { return dueJs[i].Priority > dueJs[k].Priority })
+
+	return dueJs
+}
+
 func (s *Scheduler) run() {
 	for {
 		select {
@@ -624,33 +1407,71 @@ func (s *Scheduler) run() {
 				continue
 			}
 
-			// If there are ineligible job, subsequent job do not need to be checked.
-			sort.Sort(JobSlice(js))
-			for _, j := range js {
-				if j.NextRunTime.Before(now) {
-					nextRunTime, err := CalcNextRunTime(j)
-					if err != nil {
-						slog.Error(fmt.Sprintf("Scheduler calc next run time error: %s", err))
+			for _, j := range dueJobs(js, now) {
+				// The paused job is due at its `EndAt`.
+				if j.Status == JOB_STATUS_PAUSED {
+					if err := s._endJob(j); err != nil {
+						slog.Error(fmt.Sprintf("Scheduler end job `%s` error: %s", j.FullName(), err))
+					}
+					continue
+				}
+
+				runTimes := s.calcDueRunTimes(j, now)
+
+				// The job that has ended still needs to run this time,
+				// and then it will be deleted in `_flushJob`.
+				nextRunTime, err := s.calcNextRunTime(j, time.Now())
+				var jeErr JobEndedError
+				if err != nil && !errors.As(err, &jeErr) {
+					slog.Error(fmt.Sprintf("Scheduler calc next run time error: %s", err))
+					continue
+				}
+				j.NextRunTime = nextRunTime
+
+				runs := 0
+				for _, runTime := range runTimes {
+					if j.MaxRuns > 0 && j.Runs+runs >= j.MaxRuns {
+						break
+					}
+					if j.isMisfired(runTime, now) {
+						s._missJob(j, runTime, now)
 						continue
 					}
-					j.NextRunTime = nextRunTime
 
-					err = s._scheduleJob(j)
+					wJ, err := s._startWorkflowRun(j, js)
 					if err != nil {
-						slog.Error(fmt.Sprintf("Scheduler schedule job `%s` error: %s", j.FullName(), err))
+						slog.Error(fmt.Sprintf("Scheduler start workflow run by job `%s` error: %s", j.FullName(), err))
+						continue
 					}
-
-					err = s._flushJob(j, now)
+					err = s._scheduleJob(JobRun{Job: wJ, ScheduledAt: runTime})
 					if err != nil {
-						slog.Error(fmt.Sprintf("Scheduler %s", err))
+						slog.Error(fmt.Sprintf("Scheduler schedule job `%s` error: %s", j.FullName(), err))
+						if wJ.WorkflowRunId != "" {
+							_ = s._completeWorkflowStep(JobResult{Job: wJ, Status: RECORD_STATUS_ERROR, Result: err.Error()})
+						}
 						continue
 					}
-				} else {
-					break
+					runs++
+				}
+
+				err = s._flushJob(j, runTimes[len(runTimes)-1], runs, now)
+				if err != nil {
+					slog.Error(fmt.Sprintf("Scheduler %s", err))
+					continue
+				}
+
+				if runs > 0 && j.isFixedDelay() {
+					if err := s._holdJob(j, now); err != nil {
+						slog.Error(fmt.Sprintf("Scheduler hold job `%s` error: %s", j.FullName(), err))
+					}
 				}
 			}
 
 			nextWakeupInterval := s.getNextWakeupInterval()
+			if slaInterval := nextSLAInterval(js, now); slaInterval > 0 {
+				nextWakeupInterval = min(nextWakeupInterval, slaInterval)
+				go s.checkSLAs(js, now)
+			}
 			slog.Debug(fmt.Sprintf("Scheduler next wakeup interval %s", nextWakeupInterval))
 
 			s.timer.Reset(nextWakeupInterval)
@@ -693,6 +1514,7 @@ func (s *Scheduler) Stop() {
 
 	s.quitChan <- struct{}{}
 	s.isRunning = false
+	s.cancelRetries()
 
 	slog.Info("Scheduler stop.")
 	s.dispatchEvent(EventPkg{EVENT_SCHEDULER_STOPPED, "", nil})
@@ -743,6 +1565,9 @@ func (s *Scheduler) Info() map[string]any {
 			"has_recorder": s.HasRecorder(),
 			"backend":      "",
 		},
+		"executor_pool": map[string]any{
+			"has_executor_pool": s.HasExecutorPool(),
+		},
 		"cluster": map[string]any{
 			"is_cluster_mode": s.IsClusterMode(),
 			"main_node":       map[string]any{},
@@ -762,6 +1587,10 @@ func (s *Scheduler) Info() map[string]any {
 		info["recorder"].(map[string]any)["backend"] = s.recorder.Backend.Name()
 	}
 
+	if s.HasExecutorPool() {
+		maps.Copy(info["executor_pool"].(map[string]any), s.executorPool.info())
+	}
+
 	if s.IsClusterMode() {
 		info["cluster"].(map[string]any)["main_node"] = map[string]any{
 			"endpoint_main": s.clusterNode.GetEndpointMain(),
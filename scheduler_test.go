@@ -2,6 +2,10 @@ package agscheduler_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
 	"testing"
 	"time"
 
@@ -18,6 +22,22 @@ func dryRunScheduler(ctx context.Context, j agscheduler.Job) (result string) { r
 
 func runSchedulerPanic(ctx context.Context, j agscheduler.Job) (result string) { panic(nil) }
 
+func runSchedulerSleep(ctx context.Context, j agscheduler.Job) (result string) {
+	time.Sleep(2 * time.Second)
+	return
+}
+
+func runSchedulerWorkflow(ctx context.Context, j agscheduler.Job) (result string) {
+	if results, ok := j.Args[agscheduler.WORKFLOW_ARG_UPSTREAM_RESULTS].(map[string]any); ok {
+		return fmt.Sprintf("%d", len(results))
+	}
+	return j.Name
+}
+
+func runSchedulerWorkflowSkip(ctx context.Context, j agscheduler.Job) (any, error) {
+	return nil, agscheduler.SkipError("nothing to do")
+}
+
 func dryCallbackScheduler(ep agscheduler.EventPkg) {}
 
 func getSchedulerWithStore(t *testing.T) *agscheduler.Scheduler {
@@ -33,6 +53,9 @@ func getJob() agscheduler.Job {
 	agscheduler.RegisterFuncs(
 		agscheduler.FuncPkg{Func: dryRunScheduler},
 		agscheduler.FuncPkg{Func: runSchedulerPanic},
+		agscheduler.FuncPkg{Func: runSchedulerSleep},
+		agscheduler.FuncPkg{Func: runSchedulerWorkflow},
+		agscheduler.FuncPkg{TypedFunc: runSchedulerWorkflowSkip},
 	)
 
 	return agscheduler.Job{
@@ -183,171 +206,1377 @@ func TestSchedulerAddJobDatetime(t *testing.T) {
 	assert.ErrorIs(t, err, agscheduler.JobNotFoundError(j.Id))
 }
 
+func TestSchedulerAddJobEndAt(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+	j.Interval = "1s"
+	j.EndAt = time.Now().UTC().Add(2 * time.Second).Format(time.DateTime)
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Start()
+	time.Sleep(3500 * time.Millisecond)
+
+	_, err = s.GetJob(j.Id)
+	assert.ErrorIs(t, err, agscheduler.JobNotFoundError(j.Id))
+}
+
+func TestSchedulerAddJobEndAtError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.EndAt = "2023-09-22 07:30:08"
+
+	_, err := s.AddJob(j)
+	var jeErr agscheduler.JobEndedError
+	assert.ErrorAs(t, err, &jeErr)
+}
+
+func TestSchedulerAddJobMaxRuns(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+	j.Interval = "1s"
+	j.MaxRuns = 2
+
+	// Add just after a whole second, so that only one run is within the first window.
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(1100 * time.Millisecond)))
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, j.Runs)
+
+	s.Start()
+	time.Sleep(1500 * time.Millisecond)
+
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, j.Runs)
+
+	j.Interval = "1s"
+	j.Runs = 0
+	j, err = s.UpdateJob(j)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, j.Runs)
+
+	time.Sleep(1 * time.Second)
+
+	_, err = s.GetJob(j.Id)
+	assert.ErrorIs(t, err, agscheduler.JobNotFoundError(j.Id))
+}
+
+func TestSchedulerAddJobMaxRunsError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.MaxRuns = -1
+
+	_, err := s.AddJob(j)
+	assert.Error(t, err)
+}
+
+func TestSchedulerAddJobCombined(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+	j.Type = agscheduler.JOB_TYPE_COMBINED
+	j.Triggers = []agscheduler.Trigger{
+		{Type: agscheduler.JOB_TYPE_INTERVAL, Interval: "1s"},
+		{Type: agscheduler.JOB_TYPE_CRON, CronExpr: "*/1 * * * *"},
+	}
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.TRIGGER_MODE_OR, j.TriggerMode)
+
+	s.Start()
+	time.Sleep(1500 * time.Millisecond)
+
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.False(t, j.LastRunTime.IsZero())
+}
+
+func TestSchedulerAddJobCombinedError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Type = agscheduler.JOB_TYPE_COMBINED
+	j.TriggerMode = agscheduler.TRIGGER_MODE_AND
+	j.Triggers = []agscheduler.Trigger{
+		{Type: agscheduler.JOB_TYPE_INTERVAL, Interval: "1s"},
+		{Type: agscheduler.JOB_TYPE_CRON, CronExpr: "*/1 * * * *"},
+	}
+
+	_, err := s.AddJob(j)
+	assert.Error(t, err)
+}
+
+func TestSchedulerAddJobRRule(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+	j.Type = agscheduler.JOB_TYPE_RRULE
+	j.StartAt = "2023-09-22 07:30:08"
+	j.RRule = "RRULE:FREQ=MINUTELY;BYSECOND=0,30"
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+	assert.Contains(t, []int{0, 30}, j.NextRunTime.Second())
+}
+
+func TestSchedulerCalendar(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	now := time.Now().UTC()
+
+	c, err := s.AddCalendar(agscheduler.Calendar{
+		Name: "holidays",
+		Excludes: []agscheduler.CalendarRange{
+			{Start: now.Format(time.DateOnly), End: now.AddDate(0, 0, 1).Format(time.DateOnly)},
+		},
+	})
+	assert.NoError(t, err)
+	cs, err := s.GetAllCalendars()
+	assert.NoError(t, err)
+	assert.Len(t, cs, 1)
+
+	j := getJob()
+	j.Interval = "1h"
+	j.Calendar = c.Name
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, 2).Format(time.DateOnly), j.NextRunTime.Format(time.DateOnly))
+
+	j.Type = agscheduler.JOB_TYPE_CRON
+	j.CronExpr = "0 9 * * *"
+	j, err = s.UpdateJob(j)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(now.Year(), now.Month(), now.Day()+2, 9, 0, 0, 0, time.UTC), j.NextRunTime)
+
+	err = s.DeleteCalendar(c.Name)
+	assert.Error(t, err)
+
+	c.Excludes = []agscheduler.CalendarRange{}
+	_, err = s.AddCalendar(c)
+	assert.NoError(t, err)
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.True(t, j.NextRunTime.Before(now.AddDate(0, 0, 1)))
+
+	err = s.DeleteJob(j.Id)
+	assert.NoError(t, err)
+	err = s.DeleteCalendar(c.Name)
+	assert.NoError(t, err)
+	_, err = s.GetCalendar(c.Name)
+	assert.ErrorIs(t, err, agscheduler.CalendarNotFoundError(c.Name))
+}
+
+func TestSchedulerCalendarError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+
+	_, err := s.AddCalendar(agscheduler.Calendar{})
+	assert.Error(t, err)
+
+	j := getJob()
+	j.Calendar = "unknown"
+	_, err = s.AddJob(j)
+	assert.ErrorIs(t, err, agscheduler.CalendarNotFoundError("unknown"))
+}
+
+func TestSchedulerAddJobMisfireGraceTime(t *testing.T) {
+	rec := getRecorder()
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+	j.Type = agscheduler.JOB_TYPE_DATETIME
+	j.StartAt = "2023-09-22 07:30:08"
+	j.MisfireGraceTime = "1s"
+
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Start()
+	time.Sleep(50 * time.Millisecond)
+
+	_, err = s.GetJob(j.Id)
+	assert.ErrorIs(t, err, agscheduler.JobNotFoundError(j.Id))
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, agscheduler.RECORD_STATUS_MISSED, rs[0].Status)
+}
+
+func TestSchedulerAddJobMisfireGraceTimeError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.MisfireGraceTime = "errorMisfireGraceTime"
+
+	_, err := s.AddJob(j)
+	assert.Contains(t, err.Error(), "MisfireGraceTime `"+j.MisfireGraceTime+"` error")
+}
+
+func TestSchedulerAddJobCoalesce(t *testing.T) {
+	for _, coalesce := range []bool{true, false} {
+		rec := getRecorder()
+		s := getSchedulerWithStore(t)
+		j := getJob()
+		j.Interval = "1s"
+		j.MaxInstances = 3
+		j.Coalesce = &coalesce
+
+		err := s.SetRecorder(rec)
+		assert.NoError(t, err)
+		j, err = s.AddJob(j)
+		assert.NoError(t, err)
+
+		// Start just after a whole second, so that the next run is not within the window.
+		time.Sleep(2 * time.Second)
+		time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(1100 * time.Millisecond)))
+		s.Start()
+		time.Sleep(300 * time.Millisecond)
+		s.Stop()
+
+		rs, _, err := rec.GetRecords(j.Id, 1, 10)
+		assert.NoError(t, err)
+		if coalesce {
+			assert.Len(t, rs, 1)
+		} else {
+			assert.GreaterOrEqual(t, len(rs), 2)
+		}
+	}
+}
+
+func TestSchedulerAddJobFixedDelay(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+	j.Interval = "3s"
+	j.IntervalMode = agscheduler.INTERVAL_MODE_FIXED_DELAY
+	j.Timeout = "10s"
+	j.Func = runSchedulerSleep
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Start()
+	time.Sleep(3100 * time.Millisecond)
+
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.True(t, j.NextRunTime.After(time.Now().Add(5*time.Second)))
+
+	time.Sleep(2500 * time.Millisecond)
+
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.True(t, j.NextRunTime.Before(time.Now().Add(3*time.Second)))
+}
+
+func TestSchedulerAddJobIntervalModeError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.IntervalMode = "unknown"
+
+	_, err := s.AddJob(j)
+	assert.Contains(t, err.Error(), "IntervalMode `"+j.IntervalMode+"` unknown")
+}
+
+func TestSchedulerWorkflow(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+
+	jA := getJob()
+	jA.Name = "A"
+	jA.Interval = "1s"
+	jA.Func = runSchedulerWorkflow
+	jA, err := s.AddJob(jA)
+	assert.NoError(t, err)
+	jB := getJob()
+	jB.Name = "B"
+	jB.Func = runSchedulerWorkflow
+	jB.Upstreams = []string{jA.Id}
+	jB, err = s.AddJob(jB)
+	assert.NoError(t, err)
+	jC := getJob()
+	jC.Name = "C"
+	jC.Func = runSchedulerWorkflow
+	jC.Upstreams = []string{jA.Id}
+	jC, err = s.AddJob(jC)
+	assert.NoError(t, err)
+	jE := getJob()
+	jE.Name = "E"
+	jE.Interval = "1s"
+	jE.Func = runSchedulerWorkflow
+	jE, err = s.AddJob(jE)
+	assert.NoError(t, err)
+	jD := getJob()
+	jD.Name = "D"
+	jD.Func = runSchedulerWorkflow
+	jD.Upstreams = []string{jB.Id, jC.Id, jE.Id}
+	jD, err = s.AddJob(jD)
+	assert.NoError(t, err)
+
+	ws, err := s.GetAllWorkflows()
+	assert.NoError(t, err)
+	assert.Len(t, ws, 1)
+	assert.ElementsMatch(t, []string{jA.Id, jE.Id}, ws[0].RootJobIds)
+	assert.Len(t, ws[0].Steps, 5)
+
+	s.Start()
+	time.Sleep(1500 * time.Millisecond)
+
+	wrs, err := s.GetAllWorkflowRuns()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, wrs)
+	wr, err := s.GetWorkflowRun(wrs[len(wrs)-1].Id)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.WORKFLOW_RUN_STATUS_COMPLETED, wr.Status)
+	assert.Len(t, wr.Steps, 5)
+	assert.Equal(t, "1", wr.Steps[jB.Id].Result)
+	assert.Equal(t, "3", wr.Steps[jD.Id].Result)
+}
+
+func TestSchedulerWorkflowSkipped(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+
+	jA := getJob()
+	jA.Name = "A"
+	jA.Interval = "1s"
+	jA.Func = nil
+	jA.TypedFunc = runSchedulerWorkflowSkip
+	jA, err := s.AddJob(jA)
+	assert.NoError(t, err)
+	jB := getJob()
+	jB.Name = "B"
+	jB.Func = runSchedulerWorkflow
+	jB.Upstreams = []string{jA.Id}
+	jB, err = s.AddJob(jB)
+	assert.NoError(t, err)
+
+	s.Start()
+	time.Sleep(1500 * time.Millisecond)
+
+	wrs, err := s.GetAllWorkflowRuns()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, wrs)
+	wr := wrs[len(wrs)-1]
+	assert.Equal(t, agscheduler.WORKFLOW_RUN_STATUS_COMPLETED, wr.Status)
+	assert.Equal(t, agscheduler.RECORD_STATUS_SKIPPED, wr.Steps[jA.Id].Status)
+	assert.Equal(t, "0", wr.Steps[jB.Id].Result)
+}
+
+func TestSchedulerWorkflowUpstreamsError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+
+	jA := getJob()
+	jA.Name = "A"
+	jA.Upstreams = []string{"1"}
+	_, err := s.AddJob(jA)
+	assert.Contains(t, err.Error(), "Upstreams jobId `1` not found")
+
+	jA.Upstreams = nil
+	jA, err = s.AddJob(jA)
+	assert.NoError(t, err)
+	jB := getJob()
+	jB.Name = "B"
+	jB.Upstreams = []string{jA.Id}
+	jB, err = s.AddJob(jB)
+	assert.NoError(t, err)
+
+	jA.Upstreams = []string{jB.Id}
+	_, err = s.UpdateJob(jA)
+	assert.Contains(t, err.Error(), "Upstreams form a cycle")
+}
+
+func TestSchedulerGetWorkflowRunError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+
+	_, err := s.GetWorkflowRun("1")
+	assert.ErrorIs(t, err, agscheduler.WorkflowRunNotFoundError("1"))
+}
+
 func TestSchedulerAddJobUnregisteredError(t *testing.T) {
 	s := getSchedulerWithStore(t)
 	j := getJobWithoutFunc()
 
-	_, err := s.AddJob(j)
-	assert.ErrorIs(t, err, agscheduler.FuncUnregisteredError(""))
+	_, err := s.AddJob(j)
+	assert.ErrorIs(t, err, agscheduler.FuncUnregisteredError(""))
+}
+
+func TestSchedulerAddJobTimeoutError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Timeout = "errorTimeout"
+
+	_, err := s.AddJob(j)
+	assert.Contains(t, err.Error(), "Timeout `"+j.Timeout+"` error")
+}
+
+func TestSchedulerRunJobPanic(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+	j.Func = runSchedulerPanic
+
+	_, err := s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Start()
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestSchedulerGetJob(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+
+	assert.Empty(t, j.Id)
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, j.Id)
+}
+
+func TestSchedulerGetAllJobs(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+
+	js, err := s.GetAllJobs()
+	assert.NoError(t, err)
+	assert.Len(t, js, 0)
+
+	_, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	js, err = s.GetAllJobs()
+	assert.NoError(t, err)
+	assert.Len(t, js, 1)
+}
+
+func TestSchedulerUpdateJob(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+
+	interval := "2s"
+	j.Interval = interval
+	j, err = s.UpdateJob(j)
+	assert.NoError(t, err)
+
+	assert.Equal(t, interval, j.Interval)
+}
+
+func TestSchedulerMigrateFuncNames(t *testing.T) {
+	migratedFunc := func(ctx context.Context, j agscheduler.Job) (result string) { return }
+	agscheduler.RegisterFuncs(
+		agscheduler.FuncPkg{Func: migratedFunc, Name: "migrated", Aliases: []string{"main.oldMigratedFunc"}},
+	)
+
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Func = migratedFunc
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+	assert.Equal(t, "migrated", j.FuncName)
+
+	j.FuncName = "main.oldMigratedFunc"
+	err = agscheduler.GetStore(s).UpdateJob(j)
+	assert.NoError(t, err)
+
+	count, err := s.MigrateFuncNames()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "migrated", j.FuncName)
+
+	count, err = s.MigrateFuncNames()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSchedulerFuncRegistry(t *testing.T) {
+	fr := &agscheduler.FuncRegistry{}
+	fr.RegisterFuncs(agscheduler.FuncPkg{
+		Func: func(ctx context.Context, j agscheduler.Job) (result string) { return "registry" },
+		Name: "registry",
+	})
+
+	rec := getRecorder()
+	s := getSchedulerWithStore(t)
+	s.SetFuncRegistry(fr)
+	assert.Equal(t, fr, agscheduler.GetFuncRegistry(s))
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+
+	j := getJob()
+	_, err = s.AddJob(j)
+	assert.ErrorIs(t, err, agscheduler.FuncUnregisteredError("github.com/agscheduler/agscheduler_test.dryRunScheduler"))
+
+	j.Func = nil
+	j.FuncName = "registry"
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, "registry", rs[0].Result)
+
+	_, err = getSchedulerWithStore(t).AddJob(j)
+	assert.ErrorIs(t, err, agscheduler.FuncUnregisteredError("registry"))
+}
+
+func TestSchedulerDeleteJob(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+	err = s.DeleteJob(j.Id)
+	assert.NoError(t, err)
+
+	_, err = s.GetJob(j.Id)
+	assert.ErrorIs(t, err, agscheduler.JobNotFoundError(j.Id))
+}
+
+func TestSchedulerDeleteAllJobs(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+
+	_, err := s.AddJob(j)
+	assert.NoError(t, err)
+	err = s.DeleteAllJobs()
+	assert.NoError(t, err)
+
+	js, err := s.GetAllJobs()
+	assert.NoError(t, err)
+	assert.Len(t, js, 0)
+}
+
+func TestSchedulerPauseJob(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Start()
+
+	_, err = s.PauseJob(j.Id)
+	assert.NoError(t, err)
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.JOB_STATUS_PAUSED, j.Status)
+}
+
+func TestSchedulerPauseJobEndAt(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+	j.Interval = "1s"
+	j.EndAt = time.Now().UTC().Add(2 * time.Second).Format(time.DateTime)
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+	_, err = s.PauseJob(j.Id)
+	assert.NoError(t, err)
+
+	// The paused job is ended at its `EndAt`.
+	s.Start()
+	time.Sleep(3500 * time.Millisecond)
+
+	_, err = s.GetJob(j.Id)
+	assert.ErrorIs(t, err, agscheduler.JobNotFoundError(j.Id))
+}
+
+func TestSchedulerPauseJobError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	_, err := s.PauseJob("1")
+	assert.Error(t, err)
+}
+
+func TestSchedulerResumeJob(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	defer s.Stop()
+	j := getJob()
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Start()
+
+	_, err = s.PauseJob(j.Id)
+	assert.NoError(t, err)
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.JOB_STATUS_PAUSED, j.Status)
+
+	_, err = s.ResumeJob(j.Id)
+	assert.NoError(t, err)
+	j, err = s.GetJob(j.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.JOB_STATUS_RUNNING, j.Status)
+}
+
+func TestSchedulerResumeJobEndAt(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Interval = "1s"
+	j.EndAt = time.Now().UTC().Add(2 * time.Second).Format(time.DateTime)
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+	s.Stop()
+	_, err = s.PauseJob(j.Id)
+	assert.NoError(t, err)
+
+	time.Sleep(3 * time.Second)
+
+	_, err = s.ResumeJob(j.Id)
+	var jeErr agscheduler.JobEndedError
+	assert.ErrorAs(t, err, &jeErr)
+	_, err = s.GetJob(j.Id)
+	assert.ErrorIs(t, err, agscheduler.JobNotFoundError(j.Id))
+}
+
+func TestSchedulerResumeJobError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	_, err := s.ResumeJob("1")
+	assert.Error(t, err)
+}
+
+func TestSchedulerRunJob(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+
+	j, err := s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+}
+
+func TestSchedulerRunJobRetries(t *testing.T) {
+	rec := getRecorder()
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Func = runSchedulerPanic
+	j.MaxAttempts = 3
+	j.RetryBackoff = "10ms"
+
+	exhaustedChan := make(chan struct{}, 1)
+	lis := &agscheduler.Listener{
+		Callbacks: []agscheduler.CallbackPkg{
+			{
+				Callback: func(ep agscheduler.EventPkg) { exhaustedChan <- struct{}{} },
+				Event:    agscheduler.EVENT_JOB_RETRIES_EXHAUSTED,
+			},
+		},
+	}
+
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	err = s.SetListener(lis)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+
+	select {
+	case <-exhaustedChan:
+	case <-time.After(time.Second):
+		assert.Fail(t, "retries exhausted event not received")
+	}
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 3)
+	for i, r := range rs {
+		assert.Equal(t, agscheduler.RECORD_STATUS_ERROR, r.Status)
+		assert.Equal(t, 3-i, r.Attempt)
+	}
+}
+
+func TestSchedulerRunJobTypedFunc(t *testing.T) {
+	for _, c := range []struct {
+		f        func(context.Context, agscheduler.Job) (any, error)
+		status   string
+		result   string
+		attempts int
+	}{
+		{
+			func(ctx context.Context, j agscheduler.Job) (any, error) { return map[string]int{"count": 1}, nil },
+			agscheduler.RECORD_STATUS_COMPLETED, `{"count":1}`, 1,
+		},
+		{
+			func(ctx context.Context, j agscheduler.Job) (any, error) { return "ok", nil },
+			agscheduler.RECORD_STATUS_COMPLETED, "ok", 1,
+		},
+		{
+			func(ctx context.Context, j agscheduler.Job) (any, error) { return nil, errors.New("err") },
+			agscheduler.RECORD_STATUS_ERROR, "err", 2,
+		},
+		{
+			func(ctx context.Context, j agscheduler.Job) (any, error) {
+				return nil, &agscheduler.NonRetryableError{Err: errors.New("err")}
+			},
+			agscheduler.RECORD_STATUS_ERROR, "non-retryable error: err", 1,
+		},
+		{
+			func(ctx context.Context, j agscheduler.Job) (any, error) {
+				return nil, fmt.Errorf("wrapped: %w", agscheduler.SkipError("nothing to do"))
+			},
+			agscheduler.RECORD_STATUS_SKIPPED, "wrapped: skipped: nothing to do", 1,
+		},
+	} {
+		agscheduler.RegisterFuncs(agscheduler.FuncPkg{TypedFunc: c.f})
+
+		rec := getRecorder()
+		s := getSchedulerWithStore(t)
+		j := getJob()
+		j.Func = nil
+		j.TypedFunc = c.f
+		j.MaxAttempts = 2
+		j.RetryBackoff = "10ms"
+
+		err := s.SetRecorder(rec)
+		assert.NoError(t, err)
+		j, err = s.AddJob(j)
+		assert.NoError(t, err)
+
+		s.Stop()
+
+		err = s.RunJob(j)
+		assert.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+
+		rs, _, err := rec.GetRecords(j.Id, 1, 10)
+		assert.NoError(t, err)
+		assert.Len(t, rs, c.attempts)
+		assert.Equal(t, c.status, rs[0].Status)
+		assert.Equal(t, c.result, rs[0].Result)
+	}
+}
+
+func TestSchedulerMiddleware(t *testing.T) {
+	rec := getRecorder()
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Func = runSchedulerWorkflow
+
+	type ctxKey struct{}
+	calls := []string{}
+	s.Use(
+		func(next agscheduler.RunHandler) agscheduler.RunHandler {
+			return func(ctx context.Context, j agscheduler.Job, recordId uint64) (string, string) {
+				calls = append(calls, "outer")
+				assert.NotZero(t, recordId)
+				status, result := next(context.WithValue(ctx, ctxKey{}, "tenant"), j, recordId)
+				return status, result + ":outer"
+			}
+		},
+		func(next agscheduler.RunHandler) agscheduler.RunHandler {
+			return func(ctx context.Context, j agscheduler.Job, recordId uint64) (string, string) {
+				calls = append(calls, "inner")
+				assert.Equal(t, "tenant", ctx.Value(ctxKey{}))
+				if j.Args["skip"] == true {
+					return agscheduler.RECORD_STATUS_SKIPPED, "short-circuit"
+				}
+				j.Name = "Decrypted"
+				return next(ctx, j, recordId)
+			}
+		},
+	)
+
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, agscheduler.RECORD_STATUS_COMPLETED, rs[0].Status)
+	assert.Equal(t, "Decrypted:outer", rs[0].Result)
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+
+	j.Args = map[string]any{"skip": true}
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, agscheduler.RECORD_STATUS_SKIPPED, rs[0].Status)
+	assert.Equal(t, "short-circuit:outer", rs[0].Result)
+}
+
+func TestSchedulerRunInfo(t *testing.T) {
+	rec := getRecorder()
+	brk := getBroker()
+	s := getSchedulerWithStore(t)
+	j := getJob()
+
+	ris := make(chan agscheduler.RunInfo, 1)
+	s.Use(func(next agscheduler.RunHandler) agscheduler.RunHandler {
+		return func(ctx context.Context, j agscheduler.Job, recordId uint64) (string, string) {
+			ri, ok := agscheduler.RunInfoFromContext(ctx)
+			assert.True(t, ok)
+			select {
+			case ris <- ri:
+			default:
+			}
+			return next(ctx, j, recordId)
+		}
+	})
+
+	_, ok := agscheduler.RunInfoFromContext(context.Background())
+	assert.False(t, ok)
+
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	ri := <-ris
+	assert.NotZero(t, ri.RecordId)
+	assert.Equal(t, 1, ri.Attempt)
+	assert.Equal(t, ri.StartAt, ri.ScheduledAt)
+	hostname, _ := os.Hostname()
+	assert.Equal(t, fmt.Sprintf("%s:%d", hostname, os.Getpid()), ri.Node)
+	assert.Empty(t, ri.Queue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = s.SetBroker(ctx, brk)
+	assert.NoError(t, err)
+
+	s.Start()
+	ri = <-ris
+	s.Stop()
+	assert.NotZero(t, ri.RecordId)
+	assert.True(t, ri.ScheduledAt.Before(ri.StartAt))
+	assert.Equal(t, fmt.Sprintf("%s:%d", hostname, os.Getpid()), ri.Node)
+	assert.Equal(t, "default", ri.Queue)
+}
+
+var schedulerProgressErrs = make(chan error, 1)
+
+func runSchedulerProgress(ctx context.Context, j agscheduler.Job) (result string) {
+	schedulerProgressErrs <- agscheduler.ReportProgress(ctx, 50, "half", map[string]any{"offset": 10, "ch": make(chan int)})
+	_ = agscheduler.ReportProgress(ctx, 50, "half", map[string]any{"offset": 10})
+	time.Sleep(300 * time.Millisecond)
+	_ = agscheduler.Heartbeat(ctx)
+	return
+}
+
+func TestSchedulerReportProgress(t *testing.T) {
+	agscheduler.RegisterFuncs(agscheduler.FuncPkg{Func: runSchedulerProgress})
+	rec := getRecorder()
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Func = runSchedulerProgress
+	j.Timeout = "1s"
+	j.HeartbeatTimeout = "100ms"
+
+	events := make(chan agscheduler.EventPkg, 2)
+	lis := &agscheduler.Listener{
+		Callbacks: []agscheduler.CallbackPkg{
+			{
+				Callback: func(ep agscheduler.EventPkg) { events <- ep },
+				Event:    agscheduler.EVENT_JOB_PROGRESS | agscheduler.EVENT_JOB_STALLED,
+			},
+		},
+	}
+
+	err := agscheduler.ReportProgress(context.Background(), 50, "", nil)
+	assert.ErrorIs(t, err, agscheduler.ErrNotInRun)
+	err = agscheduler.Heartbeat(context.Background())
+	assert.ErrorIs(t, err, agscheduler.ErrNotInRun)
+
+	err = s.SetRecorder(rec)
+	assert.NoError(t, err)
+	err = s.SetListener(lis)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	assert.Error(t, <-schedulerProgressErrs)
+	ep := <-events
+	assert.Equal(t, agscheduler.EVENT_JOB_PROGRESS, ep.Event)
+	assert.Equal(t, 50.0, ep.Data.(agscheduler.Progress).Percent)
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "half", rs[0].Progress.Message)
+	assert.Equal(t, map[string]any{"offset": 10.0}, rs[0].Progress.Fields)
+
+	ep = <-events
+	assert.Equal(t, agscheduler.EVENT_JOB_STALLED, ep.Event)
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.True(t, rs[0].Progress.Stalled)
+
+	time.Sleep(300 * time.Millisecond)
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.RECORD_STATUS_COMPLETED, rs[0].Status)
+	assert.False(t, rs[0].Progress.Stalled)
+	assert.Equal(t, 50.0, rs[0].Progress.Percent)
+}
+
+func TestSchedulerHeartbeatTimeoutError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Timeout = "1s"
+	j.HeartbeatTimeout = "1s"
+
+	_, err := s.AddJob(j)
+	assert.Error(t, err)
+
+	j.HeartbeatTimeout = "x"
+	_, err = s.AddJob(j)
+	assert.Error(t, err)
+}
+
+func runSchedulerSoftTimeout(ctx context.Context, j agscheduler.Job) (result string) {
+	time.Sleep(200 * time.Millisecond)
+	return
+}
+
+func TestSchedulerSoftTimeout(t *testing.T) {
+	agscheduler.RegisterFuncs(agscheduler.FuncPkg{Func: runSchedulerSoftTimeout})
+	rec := getRecorder()
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Func = runSchedulerSoftTimeout
+	j.Timeout = "1s"
+	j.SoftTimeout = "50ms"
+
+	softTimeoutChan := make(chan agscheduler.EventPkg, 1)
+	lis := &agscheduler.Listener{
+		Callbacks: []agscheduler.CallbackPkg{
+			{
+				Callback: func(ep agscheduler.EventPkg) { softTimeoutChan <- ep },
+				Event:    agscheduler.EVENT_JOB_SOFT_TIMEOUT,
+			},
+		},
+	}
+
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	err = s.SetListener(lis)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	ep := <-softTimeoutChan
+	assert.Equal(t, j.Id, ep.JobId)
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.RECORD_STATUS_RUNNING, rs[0].Status)
+	assert.True(t, rs[0].SoftTimedOut)
+
+	time.Sleep(300 * time.Millisecond)
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.RECORD_STATUS_COMPLETED, rs[0].Status)
+	assert.True(t, rs[0].SoftTimedOut)
+}
+
+func TestSchedulerSoftTimeoutSLAError(t *testing.T) {
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Timeout = "1s"
+	j.SoftTimeout = "2s"
+
+	_, err := s.AddJob(j)
+	assert.Error(t, err)
+
+	j.SoftTimeout = "500ms"
+	j.SLA = "25:00"
+	_, err = s.AddJob(j)
+	assert.Error(t, err)
+
+	j.SLA = "06:30"
+	_, err = s.AddJob(j)
+	assert.NoError(t, err)
+}
+
+func TestSchedulerExecutorPool(t *testing.T) {
+	agscheduler.RegisterFuncs(agscheduler.FuncPkg{Func: runSchedulerSoftTimeout})
+	rec := getRecorder()
+	s := getSchedulerWithStore(t)
+	j := getJob()
+	j.Func = runSchedulerSoftTimeout
+	j.MaxInstances = 2
+
+	err := s.SetExecutorPool(&agscheduler.ExecutorPool{MaxWorkers: -1})
+	assert.Error(t, err)
+	assert.False(t, s.HasExecutorPool())
+
+	err = s.SetExecutorPool(&agscheduler.ExecutorPool{MaxWorkers: 1})
+	assert.NoError(t, err)
+	assert.NotNil(t, agscheduler.GetExecutorPool(s))
+	err = s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	info := s.Info()["executor_pool"].(map[string]any)
+	assert.Equal(t, true, info["has_executor_pool"])
+	assert.Equal(t, 1, info["running"])
+	assert.Equal(t, 1, info["waiting"])
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+
+	time.Sleep(400 * time.Millisecond)
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	for _, r := range rs {
+		assert.Equal(t, agscheduler.RECORD_STATUS_COMPLETED, r.Status)
+	}
 }
 
-func TestSchedulerAddJobTimeoutError(t *testing.T) {
+func TestSchedulerMaxInstances(t *testing.T) {
+	agscheduler.RegisterFuncs(agscheduler.FuncPkg{Func: runSchedulerSoftTimeout})
+	rec := getRecorder()
 	s := getSchedulerWithStore(t)
 	j := getJob()
-	j.Timeout = "errorTimeout"
+	j.Func = runSchedulerSoftTimeout
 
-	_, err := s.AddJob(j)
-	assert.Contains(t, err.Error(), "Timeout `"+j.Timeout+"` error")
-}
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
 
-func TestSchedulerRunJobPanic(t *testing.T) {
-	s := getSchedulerWithStore(t)
-	defer s.Stop()
-	j := getJob()
-	j.Func = runSchedulerPanic
+	s.Stop()
 
-	_, err := s.AddJob(j)
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	err = s.RunJob(j)
 	assert.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
 
-	s.Start()
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, agscheduler.RECORD_STATUS_SKIPPED, rs[0].Status)
+	assert.Contains(t, rs[0].Result, "max_instances")
+
+	// The lease is released when the run ends.
+	time.Sleep(300 * time.Millisecond)
+	err = s.RunJob(j)
+	assert.NoError(t, err)
 	time.Sleep(50 * time.Millisecond)
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.RECORD_STATUS_RUNNING, rs[0].Status)
 }
 
-func TestSchedulerGetJob(t *testing.T) {
+func TestSchedulerRateLimiterWait(t *testing.T) {
+	rec := getRecorder()
 	s := getSchedulerWithStore(t)
 	j := getJob()
+	j.RateLimiter = "api"
 
-	assert.Empty(t, j.Id)
-
-	j, err := s.AddJob(j)
+	_, err := s.AddRateLimiter(agscheduler.RateLimiter{Name: "api", Limit: 1, Period: "300ms"})
 	assert.NoError(t, err)
-	j, err = s.GetJob(j.Id)
+	err = s.SetRecorder(rec)
 	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.RATE_LIMIT_POLICY_WAIT, j.RateLimitPolicy)
 
-	assert.NotEmpty(t, j.Id)
-}
-
-func TestSchedulerGetAllJobs(t *testing.T) {
-	s := getSchedulerWithStore(t)
-	j := getJob()
+	s.Stop()
 
-	js, err := s.GetAllJobs()
+	err = s.RunJob(j)
 	assert.NoError(t, err)
-	assert.Len(t, js, 0)
+	time.Sleep(50 * time.Millisecond)
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
 
-	_, err = s.AddJob(j)
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
 	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, agscheduler.RECORD_STATUS_DEFERRED, rs[0].Status)
+	assert.Contains(t, rs[0].Result, "rate limiter `api`")
 
-	js, err = s.GetAllJobs()
+	// The deferred run starts when a token is added.
+	time.Sleep(300 * time.Millisecond)
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
 	assert.NoError(t, err)
-	assert.Len(t, js, 1)
+	assert.Len(t, rs, 3)
+	assert.Equal(t, agscheduler.RECORD_STATUS_COMPLETED, rs[0].Status)
 }
 
-func TestSchedulerUpdateJob(t *testing.T) {
+func TestSchedulerRateLimiterWaitCancel(t *testing.T) {
+	rec := getRecorder()
 	s := getSchedulerWithStore(t)
 	j := getJob()
+	j.Interval = "1h"
+	j.RateLimiter = "api"
 
-	j, err := s.AddJob(j)
+	_, err := s.AddRateLimiter(agscheduler.RateLimiter{Name: "api", Limit: 1, Period: "1h"})
 	assert.NoError(t, err)
-
-	interval := "2s"
-	j.Interval = interval
-	j, err = s.UpdateJob(j)
+	err = s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
 	assert.NoError(t, err)
 
-	assert.Equal(t, interval, j.Interval)
-}
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
 
-func TestSchedulerDeleteJob(t *testing.T) {
-	s := getSchedulerWithStore(t)
-	j := getJob()
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 3)
 
-	j, err := s.AddJob(j)
+	// The wait is interrupted by `CancelRun` with the record id of the deferred run.
+	err = s.CancelRun(rs[0].Id)
 	assert.NoError(t, err)
-	err = s.DeleteJob(j.Id)
+	time.Sleep(50 * time.Millisecond)
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
 	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.RECORD_STATUS_CANCELLED, rs[0].Status)
+	assert.Equal(t, agscheduler.RECORD_STATUS_DEFERRED, rs[1].Status)
 
-	_, err = s.GetJob(j.Id)
-	assert.ErrorIs(t, err, agscheduler.JobNotFoundError(j.Id))
+	// The other wait is interrupted by `Stop`.
+	s.Start()
+	s.Stop()
+	time.Sleep(50 * time.Millisecond)
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.RECORD_STATUS_CANCELLED, rs[1].Status)
 }
 
-func TestSchedulerDeleteAllJobs(t *testing.T) {
-	s := getSchedulerWithStore(t)
-	j := getJob()
+func TestSchedulerAddRateLimiterBeforeSetStore(t *testing.T) {
+	s := &agscheduler.Scheduler{}
 
-	_, err := s.AddJob(j)
+	_, err := s.AddRateLimiter(agscheduler.RateLimiter{Name: "api", Limit: 1})
 	assert.NoError(t, err)
-	err = s.DeleteAllJobs()
+	err = s.SetStore(&stores.MemoryStore{})
 	assert.NoError(t, err)
 
-	js, err := s.GetAllJobs()
+	_, err = s.GetRateLimiter("api")
 	assert.NoError(t, err)
-	assert.Len(t, js, 0)
 }
 
-func TestSchedulerPauseJob(t *testing.T) {
+func TestSchedulerRateLimiterSkip(t *testing.T) {
+	rec := getRecorder()
 	s := getSchedulerWithStore(t)
-	defer s.Stop()
 	j := getJob()
+	j.RateLimiter = "api"
+	j.RateLimitPolicy = agscheduler.RATE_LIMIT_POLICY_SKIP
 
-	j, err := s.AddJob(j)
+	_, err := s.AddRateLimiter(agscheduler.RateLimiter{Name: "api", Limit: 1, Period: "1h"})
+	assert.NoError(t, err)
+	err = s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
 	assert.NoError(t, err)
 
-	s.Start()
+	s.Stop()
 
-	_, err = s.PauseJob(j.Id)
+	err = s.RunJob(j)
 	assert.NoError(t, err)
-	j, err = s.GetJob(j.Id)
+	time.Sleep(50 * time.Millisecond)
+	err = s.RunJob(j)
 	assert.NoError(t, err)
-	assert.Equal(t, agscheduler.JOB_STATUS_PAUSED, j.Status)
+	time.Sleep(50 * time.Millisecond)
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, agscheduler.RECORD_STATUS_SKIPPED, rs[0].Status)
+	assert.Equal(t, agscheduler.RECORD_STATUS_COMPLETED, rs[1].Status)
 }
 
-func TestSchedulerPauseJobError(t *testing.T) {
+func TestSchedulerAddJobRateLimitPolicyError(t *testing.T) {
 	s := getSchedulerWithStore(t)
-	_, err := s.PauseJob("1")
-	assert.Error(t, err)
+	j := getJob()
+	j.RateLimitPolicy = "errorRateLimitPolicy"
+
+	_, err := s.AddJob(j)
+	assert.Contains(t, err.Error(), "RateLimitPolicy `"+j.RateLimitPolicy+"` unknown")
 }
 
-func TestSchedulerResumeJob(t *testing.T) {
+func runSchedulerLogs(ctx context.Context, j agscheduler.Job) (result string) {
+	agscheduler.LoggerFromContext(ctx).Info("backfilling", "day", 1)
+	return
+}
+
+func TestSchedulerRunLogs(t *testing.T) {
+	agscheduler.RegisterFuncs(agscheduler.FuncPkg{Func: runSchedulerLogs})
+	rec := getRecorder()
 	s := getSchedulerWithStore(t)
-	defer s.Stop()
 	j := getJob()
+	j.Func = runSchedulerLogs
 
-	j, err := s.AddJob(j)
+	assert.Equal(t, slog.Default(), agscheduler.LoggerFromContext(context.Background()))
+
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
 	assert.NoError(t, err)
 
-	s.Start()
+	s.Stop()
 
-	_, err = s.PauseJob(j.Id)
-	assert.NoError(t, err)
-	j, err = s.GetJob(j.Id)
+	err = s.RunJob(j)
 	assert.NoError(t, err)
-	assert.Equal(t, agscheduler.JOB_STATUS_PAUSED, j.Status)
+	time.Sleep(50 * time.Millisecond)
 
-	_, err = s.ResumeJob(j.Id)
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
 	assert.NoError(t, err)
-	j, err = s.GetJob(j.Id)
+	assert.Len(t, rs, 1)
+	logs, err := rec.GetRecordLogs(rs[0].Id, 0)
 	assert.NoError(t, err)
-	assert.Equal(t, agscheduler.JOB_STATUS_RUNNING, j.Status)
+	assert.Contains(t, logs, "msg=backfilling day=1")
 }
 
-func TestSchedulerResumeJobError(t *testing.T) {
+func TestSchedulerRunJobShell(t *testing.T) {
+	rec := getRecorder()
 	s := getSchedulerWithStore(t)
-	_, err := s.ResumeJob("1")
-	assert.Error(t, err)
+	j := getJob()
+	j.Func = nil
+	j.TypedFunc = agscheduler.RunShell
+	j.Args = map[string]any{agscheduler.SHELL_ARG_ARGV: []any{"sh", "-c", "echo out; exit 1"}}
+
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
+	assert.NoError(t, err)
+
+	s.Stop()
+
+	err = s.RunJob(j)
+	assert.NoError(t, err)
+	time.Sleep(200 * time.Millisecond)
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, agscheduler.RECORD_STATUS_ERROR, rs[0].Status)
+	assert.JSONEq(t, `{"exit_code":1,"stdout":"out\n","stderr":""}`, rs[0].Result)
 }
 
-func TestSchedulerRunJob(t *testing.T) {
+func TestSchedulerCancelRun(t *testing.T) {
+	rec := getRecorder()
 	s := getSchedulerWithStore(t)
 	j := getJob()
+	j.Func = runSchedulerSleep
+	j.MaxAttempts = 3
 
-	j, err := s.AddJob(j)
+	cancelledChan := make(chan struct{}, 1)
+	lis := &agscheduler.Listener{
+		Callbacks: []agscheduler.CallbackPkg{
+			{
+				Callback: func(ep agscheduler.EventPkg) { cancelledChan <- struct{}{} },
+				Event:    agscheduler.EVENT_JOB_CANCELLED,
+			},
+		},
+	}
+
+	err := s.SetRecorder(rec)
+	assert.NoError(t, err)
+	err = s.SetListener(lis)
+	assert.NoError(t, err)
+	j, err = s.AddJob(j)
 	assert.NoError(t, err)
 
 	s.Stop()
 
 	err = s.RunJob(j)
 	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	rs, _, err := rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, agscheduler.RECORD_STATUS_RUNNING, rs[0].Status)
+
+	err = s.CancelRun(rs[0].Id)
+	assert.NoError(t, err)
+
+	select {
+	case <-cancelledChan:
+	case <-time.After(time.Second):
+		assert.Fail(t, "cancelled event not received")
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	rs, _, err = rec.GetRecords(j.Id, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, rs, 1)
+	assert.Equal(t, agscheduler.RECORD_STATUS_CANCELLED, rs[0].Status)
+
+	err = s.CancelRun(rs[0].Id)
+	assert.ErrorIs(t, err, agscheduler.RunNotFoundError(rs[0].Id))
+}
+
+func TestSchedulerCancelRunNoRecorder(t *testing.T) {
+	s := getSchedulerWithStore(t)
+
+	err := s.CancelRun(1)
+	assert.ErrorIs(t, err, agscheduler.ErrNoRecorder)
 }
 
 func TestSchedulerScheduleJobLocal(t *testing.T) {
@@ -562,6 +1791,144 @@ func TestCalcNextRunTime(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCalcNextRunTimeIntervalAnchored(t *testing.T) {
+	j := agscheduler.Job{
+		Name:     "Job",
+		Type:     agscheduler.JOB_TYPE_INTERVAL,
+		Interval: "15m",
+		Timezone: "UTC",
+		Status:   agscheduler.JOB_STATUS_RUNNING,
+	}
+
+	j.StartAt = "2023-09-22 07:30:00"
+	nextRunTime, err := agscheduler.CalcNextRunTime(j)
+	assert.NoError(t, err)
+	assert.True(t, nextRunTime.After(time.Now()))
+	assert.Equal(t, 0, nextRunTime.Minute()%15)
+	assert.Equal(t, 0, nextRunTime.Second())
+
+	j.StartAt = time.Now().UTC().Add(time.Hour).Format(time.DateTime)
+	nextRunTime, err = agscheduler.CalcNextRunTime(j)
+	assert.NoError(t, err)
+	assert.Equal(t, j.StartAt, nextRunTime.Format(time.DateTime))
+
+	j.StartAt = ""
+	j.LastRunTime = time.Date(2023, 9, 22, 7, 37, 0, 0, time.UTC)
+	nextRunTime, err = agscheduler.CalcNextRunTime(j)
+	assert.NoError(t, err)
+	assert.Zero(t, nextRunTime.Sub(j.LastRunTime)%(15*time.Minute))
+
+	j.IntervalMode = agscheduler.INTERVAL_MODE_FIXED_DELAY
+	nextRunTime, err = agscheduler.CalcNextRunTime(j)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(time.Now().Add(15*time.Minute).Unix(), 0).UTC(), nextRunTime)
+}
+
+func TestCalcNextRunTimeCombined(t *testing.T) {
+	j := agscheduler.Job{
+		Name:     "Job",
+		Type:     agscheduler.JOB_TYPE_COMBINED,
+		Timezone: "UTC",
+		Triggers: []agscheduler.Trigger{
+			{Type: agscheduler.JOB_TYPE_INTERVAL, StartAt: "2023-09-22 07:30:00", Interval: "15m"},
+			{Type: agscheduler.JOB_TYPE_INTERVAL, StartAt: "2023-09-22 07:30:00", Interval: "10m"},
+			{Type: agscheduler.JOB_TYPE_DATETIME, StartAt: "2023-09-22 07:30:08"},
+		},
+		Status: agscheduler.JOB_STATUS_RUNNING,
+	}
+	now := time.Now()
+
+	j.TriggerMode = agscheduler.TRIGGER_MODE_OR
+	nextRunTime, err := agscheduler.CalcNextRunTime(j)
+	assert.NoError(t, err)
+	assert.True(t, nextRunTime.After(now))
+	assert.True(t, nextRunTime.Before(now.Add(10*time.Minute)))
+	assert.True(t, nextRunTime.Minute()%15 == 0 || nextRunTime.Minute()%10 == 0)
+	assert.Equal(t, 0, nextRunTime.Second())
+
+	j.Triggers = j.Triggers[:2]
+	j.TriggerMode = agscheduler.TRIGGER_MODE_AND
+	nextRunTime, err = agscheduler.CalcNextRunTime(j)
+	assert.NoError(t, err)
+	assert.True(t, nextRunTime.After(now))
+	assert.True(t, nextRunTime.Before(now.Add(30*time.Minute)))
+	assert.Equal(t, 0, nextRunTime.Minute()%30)
+	assert.Equal(t, 0, nextRunTime.Second())
+}
+
+func TestCalcNextRunTimeCombinedEnded(t *testing.T) {
+	j := agscheduler.Job{
+		Name:     "Job",
+		Type:     agscheduler.JOB_TYPE_COMBINED,
+		Timezone: "UTC",
+		Triggers: []agscheduler.Trigger{
+			{Type: agscheduler.JOB_TYPE_DATETIME, StartAt: "2023-09-22 07:30:08"},
+		},
+		Status: agscheduler.JOB_STATUS_RUNNING,
+	}
+
+	_, err := agscheduler.CalcNextRunTime(j)
+	var jeErr agscheduler.JobEndedError
+	assert.ErrorAs(t, err, &jeErr)
+
+	j.TriggerMode = agscheduler.TRIGGER_MODE_AND
+	j.Triggers = append(j.Triggers, agscheduler.Trigger{Type: agscheduler.JOB_TYPE_CRON, CronExpr: "*/1 * * * *"})
+	_, err = agscheduler.CalcNextRunTime(j)
+	assert.ErrorAs(t, err, &jeErr)
+}
+
+func TestCalcNextRunTimeCombinedError(t *testing.T) {
+	j := agscheduler.Job{
+		Name:     "Job",
+		Type:     agscheduler.JOB_TYPE_COMBINED,
+		Timezone: "UTC",
+		Status:   agscheduler.JOB_STATUS_RUNNING,
+	}
+
+	_, err := agscheduler.CalcNextRunTime(j)
+	assert.Error(t, err)
+
+	j.Triggers = []agscheduler.Trigger{{Type: agscheduler.JOB_TYPE_COMBINED}}
+	_, err = agscheduler.CalcNextRunTime(j)
+	assert.Error(t, err)
+
+	j.Triggers = []agscheduler.Trigger{{Type: agscheduler.JOB_TYPE_CRON, CronExpr: "*/1 * * * *"}}
+	j.TriggerMode = "unknown"
+	_, err = agscheduler.CalcNextRunTime(j)
+	assert.Error(t, err)
+}
+
+func TestCalcNextRunTimeRRule(t *testing.T) {
+	j := agscheduler.Job{
+		Name:     "Job",
+		Type:     agscheduler.JOB_TYPE_RRULE,
+		RRule:    "DTSTART:20230922T093000\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		Timezone: "America/New_York",
+		Status:   agscheduler.JOB_STATUS_RUNNING,
+	}
+	timezone, err := time.LoadLocation(j.Timezone)
+	assert.NoError(t, err)
+
+	nextRunTime, err := agscheduler.CalcNextRunTime(j)
+	assert.NoError(t, err)
+	nextRunTime = nextRunTime.In(timezone)
+	assert.True(t, nextRunTime.After(time.Now()))
+	assert.Equal(t, 9, nextRunTime.Hour())
+	assert.Equal(t, 30, nextRunTime.Minute())
+	assert.NotEqual(t, time.Saturday, nextRunTime.Weekday())
+	assert.NotEqual(t, time.Sunday, nextRunTime.Weekday())
+	assert.NotEqual(t, nextRunTime.Month(), nextRunTime.AddDate(0, 0, 3).Month())
+
+	j.RRule = "DTSTART:20230922T093000\nRRULE:FREQ=DAILY;COUNT=3"
+	_, err = agscheduler.CalcNextRunTime(j)
+	var jeErr agscheduler.JobEndedError
+	assert.ErrorAs(t, err, &jeErr)
+
+	j.RRule = "RRULE:FREQ=DAILY"
+	_, err = agscheduler.CalcNextRunTime(j)
+	assert.Error(t, err)
+}
+
 func TestCalcNextRunTimeTimezoneUnknown(t *testing.T) {
 	j := agscheduler.Job{Timezone: "unknown"}
 
@@ -589,6 +1956,17 @@ func TestCalcNextRunTimeIntervalError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCalcNextRunTimeEndAtError(t *testing.T) {
+	j := agscheduler.Job{
+		Type:     agscheduler.JOB_TYPE_INTERVAL,
+		Interval: "1s",
+		EndAt:    "2023-10-22T07:30:08",
+	}
+
+	_, err := agscheduler.CalcNextRunTime(j)
+	assert.Error(t, err)
+}
+
 func TestCalcNextRunTimeCronExprError(t *testing.T) {
 	j := agscheduler.Job{
 		Type:     agscheduler.JOB_TYPE_CRON,
@@ -613,9 +1991,11 @@ func TestInfo(t *testing.T) {
 	assert.NoError(t, err)
 	err = s.SetRecorder(rec)
 	assert.NoError(t, err)
+	err = s.SetExecutorPool(&agscheduler.ExecutorPool{MaxWorkers: 2, QueueMaxWorkers: map[string]int{"default": 1}})
+	assert.NoError(t, err)
 
 	info := s.Info()
 
-	assert.Len(t, info, 5)
+	assert.Len(t, info, 6)
 	assert.Equal(t, info["version"], agscheduler.Version)
 }
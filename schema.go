@@ -0,0 +1,233 @@
+package agscheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Derive the JSON Schema of the argument struct of a function registered with `FuncPkg.Args`.
+// Fields are named by their `json` tags, the fields of embedded structs are flattened as `encoding/json` does,
+// fields without `omitempty` are required unless they are pointers, pointers are nullable,
+// and unknown properties are not allowed.
+func argsSchemaOf(args any) map[string]any {
+	return typeSchema(reflect.TypeOf(args))
+}
+
+func typeSchema(t reflect.Type) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+	if t.Kind() == reflect.Pointer {
+		return nullableSchema(typeSchema(t.Elem()))
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		required := []string{}
+		structFieldsSchema(t, properties, &required)
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	default:
+		return map[string]any{}
+	}
+}
+
+// Add the properties of the struct fields, the fields of embedded structs without a `json` name are added in place,
+// and the fields of the outer struct take precedence over them.
+func structFieldsSchema(t reflect.Type, properties map[string]any, required *[]string) {
+	embedded := []reflect.Type{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = typeSchema(f.Type)
+		if f.Type.Kind() != reflect.Pointer && !slices.Contains(strings.Split(opts, ","), "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+
+	for _, et := range embedded {
+		eProperties := map[string]any{}
+		eRequired := []string{}
+		structFieldsSchema(et, eProperties, &eRequired)
+		for name, p := range eProperties {
+			if _, ok := properties[name]; !ok {
+				properties[name] = p
+				if slices.Contains(eRequired, name) {
+					*required = append(*required, name)
+				}
+			}
+		}
+	}
+}
+
+// Allow `null` in addition to the type of the schema.
+func nullableSchema(schema map[string]any) map[string]any {
+	if t, ok := schema["type"].(string); ok {
+		schema["type"] = []string{t, "null"}
+	}
+
+	return schema
+}
+
+// Validate the value decoded from JSON against the schema derived by `typeSchema`.
+func validateSchema(schema map[string]any, v any, path string) error {
+	t := schema["type"]
+	if ts, ok := t.([]string); ok {
+		if v == nil && slices.Contains(ts, "null") {
+			return nil
+		}
+		t = ts[0]
+	}
+
+	switch t {
+	case nil:
+		return nil
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("`%s` must be a string", path)
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return fmt.Errorf("`%s` must be a date-time", path)
+			}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("`%s` must be a boolean", path)
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("`%s` must be an integer", path)
+		}
+		if minimum, ok := schema["minimum"].(int); ok && n < float64(minimum) {
+			return fmt.Errorf("`%s` must be at least %d", path, minimum)
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("`%s` must be a number", path)
+		}
+	case "array":
+		if v == nil {
+			return nil
+		}
+		a, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("`%s` must be an array", path)
+		}
+		for i, e := range a {
+			if err := validateSchema(schema["items"].(map[string]any), e, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		if v == nil {
+			return nil
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("`%s` must be an object", path)
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]string)
+		for _, name := range required {
+			if _, ok := m[name]; !ok {
+				return fmt.Errorf("`%s.%s` is required", path, name)
+			}
+		}
+		for k, e := range m {
+			var eSchema map[string]any
+			if properties != nil {
+				s, ok := properties[k]
+				if !ok {
+					return fmt.Errorf("`%s.%s` is not allowed", path, k)
+				}
+				eSchema = s.(map[string]any)
+			} else {
+				eSchema = schema["additionalProperties"].(map[string]any)
+			}
+			if err := validateSchema(eSchema, e, path+"."+k); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Called by `check` when the function is registered with `FuncPkg.Args`.
+func (j *Job) checkArgs(schema map[string]any) error {
+	bArgs, err := json.Marshal(j.Args)
+	if err != nil {
+		return fmt.Errorf("job `%s` Args error: %s", j.FullName(), err)
+	}
+	var args any
+	if err := json.Unmarshal(bArgs, &args); err != nil {
+		return fmt.Errorf("job `%s` Args error: %s", j.FullName(), err)
+	}
+	if args == nil {
+		args = map[string]any{}
+	}
+
+	if err := validateSchema(schema, args, "Args"); err != nil {
+		return fmt.Errorf("job `%s` Args error: %s", j.FullName(), err)
+	}
+
+	return nil
+}
+
+// Decode `Args` into the argument struct pointed to by `v`, e.g. the one registered with `FuncPkg.Args`.
+func (j Job) DecodeArgs(v any) error {
+	bArgs, err := json.Marshal(j.Args)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bArgs, v)
+}
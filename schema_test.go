@@ -0,0 +1,107 @@
+package agscheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaTestBase struct {
+	Url  string `json:"url"`
+	Name string `json:"name,omitempty"`
+}
+
+type schemaTestArgs struct {
+	schemaTestBase
+	Name    string            `json:"name"`
+	Count   uint              `json:"count,omitempty"`
+	Limit   *int              `json:"limit"`
+	Retries int               `json:"retries,omitempty"`
+	Ratio   float64           `json:"ratio,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Since   time.Time         `json:"since,omitempty"`
+	Debug   *bool             `json:"debug,omitempty"`
+	Ignored string            `json:"-"`
+	secret  string
+}
+
+func TestArgsSchemaOf(t *testing.T) {
+	schema := argsSchemaOf(schemaTestArgs{})
+
+	assert.Equal(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"url":     map[string]any{"type": "string"},
+			"name":    map[string]any{"type": "string"},
+			"count":   map[string]any{"type": "integer", "minimum": 0},
+			"limit":   map[string]any{"type": []string{"integer", "null"}},
+			"retries": map[string]any{"type": "integer"},
+			"ratio":   map[string]any{"type": "number"},
+			"tags":    map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"headers": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"since":   map[string]any{"type": "string", "format": "date-time"},
+			"debug":   map[string]any{"type": []string{"boolean", "null"}},
+		},
+		"required":             []string{"name", "url"},
+		"additionalProperties": false,
+	}, schema)
+}
+
+func TestJobCheckArgs(t *testing.T) {
+	schema := argsSchemaOf(schemaTestArgs{})
+
+	for _, args := range []map[string]any{
+		{"url": "http://127.0.0.1", "name": "a"},
+		{"url": "http://127.0.0.1", "name": "a", "count": 1, "limit": nil, "retries": 3, "ratio": 0.5, "tags": []string{"a"},
+			"headers": map[string]any{"a": "b"}, "since": "2024-01-01T00:00:00Z", "debug": true},
+	} {
+		j := Job{Args: args}
+		assert.NoError(t, j.checkArgs(schema), args)
+	}
+
+	for _, args := range []map[string]any{
+		nil,
+		{"url": "http://127.0.0.1"},
+		{"url": 1, "name": "a"},
+		{"url": "http://127.0.0.1", "name": "a", "retry": 3},
+		{"url": "http://127.0.0.1", "name": "a", "retries": 0.5},
+		{"url": "http://127.0.0.1", "name": "a", "count": -1},
+		{"url": "http://127.0.0.1", "name": "a", "limit": "1"},
+		{"url": "http://127.0.0.1", "name": "a", "tags": []any{1}},
+		{"url": "http://127.0.0.1", "name": "a", "headers": map[string]any{"a": 1}},
+		{"url": "http://127.0.0.1", "name": "a", "since": "2024-01-01"},
+	} {
+		j := Job{Args: args}
+		assert.Error(t, j.checkArgs(schema), args)
+	}
+}
+
+func TestJobCheckArgsRegistered(t *testing.T) {
+	f := func(ctx context.Context, j Job) (result string) { return }
+	RegisterFuncs(FuncPkg{Func: f, Args: schemaTestArgs{}})
+
+	j := Job{Name: "Job", Type: JOB_TYPE_INTERVAL, Interval: "1s", Func: f, Args: map[string]any{"url": "http://127.0.0.1", "name": "a"}}
+	assert.NoError(t, j.init())
+	assert.NoError(t, j.checkFunc(DefaultFuncRegistry))
+
+	j.Args = map[string]any{"uri": "http://127.0.0.1"}
+	assert.Error(t, j.checkFunc(DefaultFuncRegistry))
+
+	for _, f := range FuncMapReadable() {
+		if f["name"] == getFuncName(j.Func) {
+			assert.Equal(t, []string{"name", "url"}, f["args_schema"].(map[string]any)["required"])
+		}
+	}
+}
+
+func TestJobDecodeArgs(t *testing.T) {
+	j := Job{Args: map[string]any{"url": "http://127.0.0.1", "retries": float64(3), "tags": []any{"a"}}}
+
+	var args schemaTestArgs
+	err := j.DecodeArgs(&args)
+	assert.NoError(t, err)
+	assert.Equal(t, schemaTestArgs{schemaTestBase: schemaTestBase{Url: "http://127.0.0.1"}, Retries: 3, Tags: []string{"a"}}, args)
+}
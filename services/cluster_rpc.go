@@ -31,8 +31,31 @@ func (crs *CRPCService) Heartbeat(args *agscheduler.Node, reply *agscheduler.Nod
 	return nil
 }
 
-func (crs *CRPCService) RunJob(j agscheduler.Job, reply *any) error {
-	return crs.cn.Scheduler.RunJob(j)
+func (crs *CRPCService) RunJob(jr agscheduler.JobRun, reply *any) error {
+	return crs.cn.RPCRunJob(jr)
+}
+
+func (crs *CRPCService) CompleteJob(r agscheduler.JobResult, reply *any) error {
+	return crs.cn.Scheduler.CompleteJob(r)
+}
+
+func (crs *CRPCService) CancelRun(recordId uint64, reply *any) error {
+	return crs.cn.Scheduler.CancelLocalRun(recordId)
+}
+
+func (crs *CRPCService) AcquireLease(l agscheduler.Lease, reply *bool) error {
+	crs.cn.RPCAcquireLease(l, reply)
+	return nil
+}
+
+func (crs *CRPCService) RenewLease(l agscheduler.Lease, reply *any) error {
+	crs.cn.RPCRenewLease(l)
+	return nil
+}
+
+func (crs *CRPCService) ReleaseLease(l agscheduler.Lease, reply *any) error {
+	crs.cn.RPCReleaseLease(l)
+	return nil
 }
 
 func (crs *CRPCService) RaftRequestVote(args agscheduler.VoteArgs, reply *agscheduler.VoteReply) error {
@@ -59,6 +82,8 @@ type clusterRPCService struct {
 
 func (s *clusterRPCService) Start() error {
 	gob.Register(time.Time{})
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
 
 	crs := &CRPCService{cn: s.Cn}
 	rpcServer := rpc.NewServer()
@@ -4,6 +4,7 @@ import (
 	"encoding/gob"
 	"net/rpc"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -18,7 +19,24 @@ func testClusterRPC(t *testing.T, c *rpc.Client) {
 
 	err := c.Call("CRPCService.GetInfo", filters, &info)
 	assert.NoError(t, err)
-	assert.Len(t, info, 5)
+	assert.Len(t, info, 6)
 
 	assert.Equal(t, info["version"], agscheduler.Version)
+
+	l := agscheduler.Lease{JobId: "1", LeaseId: "1", Max: 1, TTL: time.Minute}
+	var ok bool
+	err = c.Call("CRPCService.AcquireLease", l, &ok)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	err = c.Call("CRPCService.AcquireLease", agscheduler.Lease{JobId: "1", LeaseId: "2", Max: 1, TTL: time.Minute}, &ok)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	var reply any
+	err = c.Call("CRPCService.RenewLease", l, &reply)
+	assert.NoError(t, err)
+	err = c.Call("CRPCService.ReleaseLease", l, &reply)
+	assert.NoError(t, err)
+	err = c.Call("CRPCService.AcquireLease", agscheduler.Lease{JobId: "1", LeaseId: "2", Max: 1, TTL: time.Minute}, &ok)
+	assert.NoError(t, err)
+	assert.True(t, ok)
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net"
@@ -32,9 +33,19 @@ func (bgrs *bGRPCService) GetInfo(ctx context.Context, in *emptypb.Empty) (*pb.I
 func (bgrs *bGRPCService) GetFuncs(ctx context.Context, in *emptypb.Empty) (*pb.FuncsResp, error) {
 	pbFs := []*pb.Func{}
 
-	fs := agscheduler.FuncMapReadable()
+	fs := agscheduler.GetFuncRegistry(bgrs.scheduler).FuncMapReadable()
 	for _, f := range fs {
-		pbF := &pb.Func{Name: f["name"], Info: f["Info"]}
+		pbF := &pb.Func{
+			Name: f["name"].(string), Info: f["info"].(string),
+			Version: f["version"].(string), Aliases: f["aliases"].([]string),
+		}
+		if argsSchema := f["args_schema"].(map[string]any); argsSchema != nil {
+			bSchema, err := json.Marshal(argsSchema)
+			if err != nil {
+				return nil, err
+			}
+			pbF.ArgsSchema = string(bSchema)
+		}
 		pbFs = append(pbFs, pbF)
 	}
 
@@ -23,12 +23,12 @@ func testGRPC(t *testing.T, c pb.BaseClient) {
 
 	iResp, err := c.GetInfo(ctx, &emptypb.Empty{})
 	assert.NoError(t, err)
-	assert.Len(t, iResp.Info.AsMap(), 5)
+	assert.Len(t, iResp.Info.AsMap(), 6)
 	assert.Equal(t, iResp.Info.AsMap()["version"], agscheduler.Version)
 
 	fsResp, err := c.GetFuncs(ctx, &emptypb.Empty{})
 	assert.NoError(t, err)
-	funcLen := len(agscheduler.FuncMap)
+	funcLen := len(agscheduler.FuncMapReadable())
 	assert.Len(t, fsResp.Funcs, funcLen)
 }
 
@@ -20,7 +20,7 @@ func (bhs *bHTTPService) info(c *gin.Context) {
 }
 
 func (bhs *bHTTPService) funcs(c *gin.Context) {
-	c.JSON(200, gin.H{"data": agscheduler.FuncMapReadable(), "error": ""})
+	c.JSON(200, gin.H{"data": agscheduler.GetFuncRegistry(bhs.scheduler).FuncMapReadable(), "error": ""})
 }
 
 func (bhs *bHTTPService) registerRoutes(r *gin.Engine) {
@@ -35,9 +35,14 @@ func testHTTP(t *testing.T, baseUrl string) {
 	rJ := &result{}
 	err = json.Unmarshal(body, &rJ)
 	assert.NoError(t, err)
-	assert.Len(t, rJ.Data.(map[string]any), 5)
+	assert.Len(t, rJ.Data.(map[string]any), 6)
 	assert.Equal(t, agscheduler.Version, rJ.Data.(map[string]any)["version"])
 
+	agscheduler.RegisterFuncs(agscheduler.FuncPkg{
+		Func: dryRunHTTP, Name: "dry_run_http_args", Args: struct {
+			Url string `json:"url"`
+		}{},
+	})
 	resp, err = http.Get(baseUrl + "/funcs")
 	assert.NoError(t, err)
 	assert.Equal(t, 200, resp.StatusCode)
@@ -46,8 +51,17 @@ func testHTTP(t *testing.T, baseUrl string) {
 	rJ = &result{}
 	err = json.Unmarshal(body, &rJ)
 	assert.NoError(t, err)
-	funcLen := len(agscheduler.FuncMap)
+	funcLen := len(agscheduler.FuncMapReadable())
 	assert.Len(t, rJ.Data, funcLen)
+	fNames := []any{}
+	for _, f := range rJ.Data.([]any) {
+		fNames = append(fNames, f.(map[string]any)["name"])
+		if f.(map[string]any)["name"] == "dry_run_http_args" {
+			assert.Equal(t, []any{"url"}, f.(map[string]any)["args_schema"].(map[string]any)["required"])
+		}
+	}
+	// The built-in functions are usable without Go code.
+	assert.Contains(t, fNames, "github.com/agscheduler/agscheduler.RunWebhook")
 }
 
 func TestHTTPService(t *testing.T) {
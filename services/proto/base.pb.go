@@ -71,6 +71,9 @@ type Func struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Info          string                 `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	ArgsSchema    string                 `protobuf:"bytes,3,opt,name=args_schema,json=argsSchema,proto3" json:"args_schema,omitempty"`
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Aliases       []string               `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -119,6 +122,27 @@ func (x *Func) GetInfo() string {
 	return ""
 }
 
+func (x *Func) GetArgsSchema() string {
+	if x != nil {
+		return x.ArgsSchema
+	}
+	return ""
+}
+
+func (x *Func) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Func) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
 type FuncsResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Funcs         []*Func                `protobuf:"bytes,1,rep,name=funcs,proto3" json:"funcs,omitempty"`
@@ -170,10 +194,14 @@ const file_base_proto_rawDesc = "" +
 	"\n" +
 	"base.proto\x12\bservices\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\"7\n" +
 	"\bInfoResp\x12+\n" +
-	"\x04info\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x04info\".\n" +
+	"\x04info\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x04info\"\x83\x01\n" +
 	"\x04Func\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04info\x18\x02 \x01(\tR\x04info\"1\n" +
+	"\x04info\x18\x02 \x01(\tR\x04info\x12\x1f\n" +
+	"\vargs_schema\x18\x03 \x01(\tR\n" +
+	"argsSchema\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x18\n" +
+	"\aaliases\x18\x05 \x03(\tR\aaliases\"1\n" +
 	"\tFuncsResp\x12$\n" +
 	"\x05funcs\x18\x01 \x03(\v2\x0e.services.FuncR\x05funcs2z\n" +
 	"\x04Base\x127\n" +
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -144,6 +145,9 @@ type Record struct {
 	Result        string                 `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
 	StartAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
 	EndAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	Attempt       int32                  `protobuf:"varint,8,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Progress      *Progress              `protobuf:"bytes,9,opt,name=progress,proto3" json:"progress,omitempty"`
+	SoftTimedOut  bool                   `protobuf:"varint,10,opt,name=soft_timed_out,json=softTimedOut,proto3" json:"soft_timed_out,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -227,6 +231,199 @@ func (x *Record) GetEndAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Record) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *Record) GetProgress() *Progress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *Record) GetSoftTimedOut() bool {
+	if x != nil {
+		return x.SoftTimedOut
+	}
+	return false
+}
+
+type Progress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percent       float64                `protobuf:"fixed64,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Fields        *structpb.Struct       `protobuf:"bytes,3,opt,name=fields,proto3" json:"fields,omitempty"`
+	HeartbeatAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=heartbeat_at,json=heartbeatAt,proto3" json:"heartbeat_at,omitempty"`
+	Stalled       bool                   `protobuf:"varint,5,opt,name=stalled,proto3" json:"stalled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_recorder_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_recorder_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_recorder_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Progress) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *Progress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Progress) GetFields() *structpb.Struct {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Progress) GetHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HeartbeatAt
+	}
+	return nil
+}
+
+func (x *Progress) GetStalled() bool {
+	if x != nil {
+		return x.Stalled
+	}
+	return false
+}
+
+type RecordLogsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tail          int32                  `protobuf:"varint,2,opt,name=tail,proto3" json:"tail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordLogsReq) Reset() {
+	*x = RecordLogsReq{}
+	mi := &file_recorder_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordLogsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordLogsReq) ProtoMessage() {}
+
+func (x *RecordLogsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_recorder_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordLogsReq.ProtoReflect.Descriptor instead.
+func (*RecordLogsReq) Descriptor() ([]byte, []int) {
+	return file_recorder_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecordLogsReq) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RecordLogsReq) GetTail() int32 {
+	if x != nil {
+		return x.Tail
+	}
+	return 0
+}
+
+type RecordLogsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          string                 `protobuf:"bytes,1,opt,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordLogsResp) Reset() {
+	*x = RecordLogsResp{}
+	mi := &file_recorder_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordLogsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordLogsResp) ProtoMessage() {}
+
+func (x *RecordLogsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_recorder_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordLogsResp.ProtoReflect.Descriptor instead.
+func (*RecordLogsResp) Descriptor() ([]byte, []int) {
+	return file_recorder_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RecordLogsResp) GetLogs() string {
+	if x != nil {
+		return x.Logs
+	}
+	return ""
+}
+
 type RecordsResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Records       []*Record              `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
@@ -239,7 +436,7 @@ type RecordsResp struct {
 
 func (x *RecordsResp) Reset() {
 	*x = RecordsResp{}
-	mi := &file_recorder_proto_msgTypes[3]
+	mi := &file_recorder_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -251,7 +448,7 @@ func (x *RecordsResp) String() string {
 func (*RecordsResp) ProtoMessage() {}
 
 func (x *RecordsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_recorder_proto_msgTypes[3]
+	mi := &file_recorder_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +461,7 @@ func (x *RecordsResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordsResp.ProtoReflect.Descriptor instead.
 func (*RecordsResp) Descriptor() ([]byte, []int) {
-	return file_recorder_proto_rawDescGZIP(), []int{3}
+	return file_recorder_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RecordsResp) GetRecords() []*Record {
@@ -299,7 +496,7 @@ var File_recorder_proto protoreflect.FileDescriptor
 
 const file_recorder_proto_rawDesc = "" +
 	"\n" +
-	"\x0erecorder.proto\x12\bservices\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0fscheduler.proto\"T\n" +
+	"\x0erecorder.proto\x12\bservices\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0fscheduler.proto\"T\n" +
 	"\n" +
 	"RecordsReq\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x12\n" +
@@ -307,7 +504,7 @@ const file_recorder_proto_rawDesc = "" +
 	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"@\n" +
 	"\rRecordsAllReq\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\xe4\x01\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\xd4\x02\n" +
 	"\x06Record\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x15\n" +
 	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x19\n" +
@@ -315,16 +512,32 @@ const file_recorder_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
 	"\x06result\x18\x05 \x01(\tR\x06result\x125\n" +
 	"\bstart_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
-	"\x06end_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\"\x80\x01\n" +
+	"\x06end_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\x12\x18\n" +
+	"\aattempt\x18\b \x01(\x05R\aattempt\x12.\n" +
+	"\bprogress\x18\t \x01(\v2\x12.services.ProgressR\bprogress\x12$\n" +
+	"\x0esoft_timed_out\x18\n" +
+	" \x01(\bR\fsoftTimedOut\"\xc8\x01\n" +
+	"\bProgress\x12\x18\n" +
+	"\apercent\x18\x01 \x01(\x01R\apercent\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
+	"\x06fields\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x06fields\x12=\n" +
+	"\fheartbeat_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vheartbeatAt\x12\x18\n" +
+	"\astalled\x18\x05 \x01(\bR\astalled\"3\n" +
+	"\rRecordLogsReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04tail\x18\x02 \x01(\x05R\x04tail\"$\n" +
+	"\x0eRecordLogsResp\x12\x12\n" +
+	"\x04logs\x18\x01 \x01(\tR\x04logs\"\x80\x01\n" +
 	"\vRecordsResp\x12*\n" +
 	"\arecords\x18\x01 \x03(\v2\x10.services.RecordR\arecords\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x14\n" +
-	"\x05total\x18\x04 \x01(\x03R\x05total2\x8d\x02\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total2\xd3\x02\n" +
 	"\bRecorder\x12;\n" +
 	"\n" +
 	"GetRecords\x12\x14.services.RecordsReq\x1a\x15.services.RecordsResp\"\x00\x12A\n" +
-	"\rGetAllRecords\x12\x17.services.RecordsAllReq\x1a\x15.services.RecordsResp\"\x00\x12;\n" +
+	"\rGetAllRecords\x12\x17.services.RecordsAllReq\x1a\x15.services.RecordsResp\"\x00\x12D\n" +
+	"\rGetRecordLogs\x12\x17.services.RecordLogsReq\x1a\x18.services.RecordLogsResp\"\x00\x12;\n" +
 	"\rDeleteRecords\x12\x10.services.JobReq\x1a\x16.google.protobuf.Empty\"\x00\x12D\n" +
 	"\x10DeleteAllRecords\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00B\rZ\v./;servicesb\x06proto3"
 
@@ -340,33 +553,42 @@ func file_recorder_proto_rawDescGZIP() []byte {
 	return file_recorder_proto_rawDescData
 }
 
-var file_recorder_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_recorder_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_recorder_proto_goTypes = []any{
 	(*RecordsReq)(nil),            // 0: services.RecordsReq
 	(*RecordsAllReq)(nil),         // 1: services.RecordsAllReq
 	(*Record)(nil),                // 2: services.Record
-	(*RecordsResp)(nil),           // 3: services.RecordsResp
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
-	(*JobReq)(nil),                // 5: services.JobReq
-	(*emptypb.Empty)(nil),         // 6: google.protobuf.Empty
+	(*Progress)(nil),              // 3: services.Progress
+	(*RecordLogsReq)(nil),         // 4: services.RecordLogsReq
+	(*RecordLogsResp)(nil),        // 5: services.RecordLogsResp
+	(*RecordsResp)(nil),           // 6: services.RecordsResp
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 8: google.protobuf.Struct
+	(*JobReq)(nil),                // 9: services.JobReq
+	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
 }
 var file_recorder_proto_depIdxs = []int32{
-	4, // 0: services.Record.start_at:type_name -> google.protobuf.Timestamp
-	4, // 1: services.Record.end_at:type_name -> google.protobuf.Timestamp
-	2, // 2: services.RecordsResp.records:type_name -> services.Record
-	0, // 3: services.Recorder.GetRecords:input_type -> services.RecordsReq
-	1, // 4: services.Recorder.GetAllRecords:input_type -> services.RecordsAllReq
-	5, // 5: services.Recorder.DeleteRecords:input_type -> services.JobReq
-	6, // 6: services.Recorder.DeleteAllRecords:input_type -> google.protobuf.Empty
-	3, // 7: services.Recorder.GetRecords:output_type -> services.RecordsResp
-	3, // 8: services.Recorder.GetAllRecords:output_type -> services.RecordsResp
-	6, // 9: services.Recorder.DeleteRecords:output_type -> google.protobuf.Empty
-	6, // 10: services.Recorder.DeleteAllRecords:output_type -> google.protobuf.Empty
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	7,  // 0: services.Record.start_at:type_name -> google.protobuf.Timestamp
+	7,  // 1: services.Record.end_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: services.Record.progress:type_name -> services.Progress
+	8,  // 3: services.Progress.fields:type_name -> google.protobuf.Struct
+	7,  // 4: services.Progress.heartbeat_at:type_name -> google.protobuf.Timestamp
+	2,  // 5: services.RecordsResp.records:type_name -> services.Record
+	0,  // 6: services.Recorder.GetRecords:input_type -> services.RecordsReq
+	1,  // 7: services.Recorder.GetAllRecords:input_type -> services.RecordsAllReq
+	4,  // 8: services.Recorder.GetRecordLogs:input_type -> services.RecordLogsReq
+	9,  // 9: services.Recorder.DeleteRecords:input_type -> services.JobReq
+	10, // 10: services.Recorder.DeleteAllRecords:input_type -> google.protobuf.Empty
+	6,  // 11: services.Recorder.GetRecords:output_type -> services.RecordsResp
+	6,  // 12: services.Recorder.GetAllRecords:output_type -> services.RecordsResp
+	5,  // 13: services.Recorder.GetRecordLogs:output_type -> services.RecordLogsResp
+	10, // 14: services.Recorder.DeleteRecords:output_type -> google.protobuf.Empty
+	10, // 15: services.Recorder.DeleteAllRecords:output_type -> google.protobuf.Empty
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_recorder_proto_init() }
@@ -381,7 +603,7 @@ func file_recorder_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recorder_proto_rawDesc), len(file_recorder_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Recorder_GetRecords_FullMethodName       = "/services.Recorder/GetRecords"
 	Recorder_GetAllRecords_FullMethodName    = "/services.Recorder/GetAllRecords"
+	Recorder_GetRecordLogs_FullMethodName    = "/services.Recorder/GetRecordLogs"
 	Recorder_DeleteRecords_FullMethodName    = "/services.Recorder/DeleteRecords"
 	Recorder_DeleteAllRecords_FullMethodName = "/services.Recorder/DeleteAllRecords"
 )
@@ -32,6 +33,7 @@ const (
 type RecorderClient interface {
 	GetRecords(ctx context.Context, in *RecordsReq, opts ...grpc.CallOption) (*RecordsResp, error)
 	GetAllRecords(ctx context.Context, in *RecordsAllReq, opts ...grpc.CallOption) (*RecordsResp, error)
+	GetRecordLogs(ctx context.Context, in *RecordLogsReq, opts ...grpc.CallOption) (*RecordLogsResp, error)
 	DeleteRecords(ctx context.Context, in *JobReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteAllRecords(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
@@ -64,6 +66,16 @@ func (c *recorderClient) GetAllRecords(ctx context.Context, in *RecordsAllReq, o
 	return out, nil
 }
 
+func (c *recorderClient) GetRecordLogs(ctx context.Context, in *RecordLogsReq, opts ...grpc.CallOption) (*RecordLogsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordLogsResp)
+	err := c.cc.Invoke(ctx, Recorder_GetRecordLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *recorderClient) DeleteRecords(ctx context.Context, in *JobReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -90,6 +102,7 @@ func (c *recorderClient) DeleteAllRecords(ctx context.Context, in *emptypb.Empty
 type RecorderServer interface {
 	GetRecords(context.Context, *RecordsReq) (*RecordsResp, error)
 	GetAllRecords(context.Context, *RecordsAllReq) (*RecordsResp, error)
+	GetRecordLogs(context.Context, *RecordLogsReq) (*RecordLogsResp, error)
 	DeleteRecords(context.Context, *JobReq) (*emptypb.Empty, error)
 	DeleteAllRecords(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	mustEmbedUnimplementedRecorderServer()
@@ -108,6 +121,9 @@ func (UnimplementedRecorderServer) GetRecords(context.Context, *RecordsReq) (*Re
 func (UnimplementedRecorderServer) GetAllRecords(context.Context, *RecordsAllReq) (*RecordsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllRecords not implemented")
 }
+func (UnimplementedRecorderServer) GetRecordLogs(context.Context, *RecordLogsReq) (*RecordLogsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecordLogs not implemented")
+}
 func (UnimplementedRecorderServer) DeleteRecords(context.Context, *JobReq) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecords not implemented")
 }
@@ -171,6 +187,24 @@ func _Recorder_GetAllRecords_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Recorder_GetRecordLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordLogsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecorderServer).GetRecordLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recorder_GetRecordLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecorderServer).GetRecordLogs(ctx, req.(*RecordLogsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Recorder_DeleteRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(JobReq)
 	if err := dec(in); err != nil {
@@ -222,6 +256,10 @@ var Recorder_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllRecords",
 			Handler:    _Recorder_GetAllRecords_Handler,
 		},
+		{
+			MethodName: "GetRecordLogs",
+			Handler:    _Recorder_GetRecordLogs_Handler,
+		},
 		{
 			MethodName: "DeleteRecords",
 			Handler:    _Recorder_DeleteRecords_Handler,
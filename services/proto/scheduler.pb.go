@@ -68,31 +68,128 @@ func (x *JobReq) GetId() string {
 	return ""
 }
 
-type Job struct {
+type Trigger struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	StartAt       string                 `protobuf:"bytes,4,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
-	EndAt         string                 `protobuf:"bytes,5,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
-	Interval      string                 `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`
-	CronExpr      string                 `protobuf:"bytes,7,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
-	Timezone      string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
-	FuncName      string                 `protobuf:"bytes,9,opt,name=func_name,json=funcName,proto3" json:"func_name,omitempty"`
-	Args          *structpb.Struct       `protobuf:"bytes,10,opt,name=args,proto3" json:"args,omitempty"`
-	Timeout       string                 `protobuf:"bytes,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Queues        []string               `protobuf:"bytes,12,rep,name=queues,proto3" json:"queues,omitempty"`
-	MaxInstances  int32                  `protobuf:"varint,13,opt,name=max_instances,json=maxInstances,proto3" json:"max_instances,omitempty"`
-	LastRunTime   *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=last_run_time,json=lastRunTime,proto3" json:"last_run_time,omitempty"`
-	NextRunTime   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
-	Status        string                 `protobuf:"bytes,16,opt,name=status,proto3" json:"status,omitempty"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	StartAt       string                 `protobuf:"bytes,2,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	Interval      string                 `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	CronExpr      string                 `protobuf:"bytes,4,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
+	Rrule         string                 `protobuf:"bytes,5,opt,name=rrule,proto3" json:"rrule,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Trigger) Reset() {
+	*x = Trigger{}
+	mi := &file_scheduler_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trigger) ProtoMessage() {}
+
+func (x *Trigger) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trigger.ProtoReflect.Descriptor instead.
+func (*Trigger) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Trigger) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Trigger) GetStartAt() string {
+	if x != nil {
+		return x.StartAt
+	}
+	return ""
+}
+
+func (x *Trigger) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *Trigger) GetCronExpr() string {
+	if x != nil {
+		return x.CronExpr
+	}
+	return ""
+}
+
+func (x *Trigger) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+type Job struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type             string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	StartAt          string                 `protobuf:"bytes,4,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt            string                 `protobuf:"bytes,5,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	Interval         string                 `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	IntervalMode     string                 `protobuf:"bytes,19,opt,name=interval_mode,json=intervalMode,proto3" json:"interval_mode,omitempty"`
+	CronExpr         string                 `protobuf:"bytes,7,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
+	Rrule            string                 `protobuf:"bytes,29,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	Triggers         []*Trigger             `protobuf:"bytes,27,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	TriggerMode      string                 `protobuf:"bytes,28,opt,name=trigger_mode,json=triggerMode,proto3" json:"trigger_mode,omitempty"`
+	Calendar         string                 `protobuf:"bytes,30,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	Timezone         string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	FuncName         string                 `protobuf:"bytes,9,opt,name=func_name,json=funcName,proto3" json:"func_name,omitempty"`
+	Args             *structpb.Struct       `protobuf:"bytes,10,opt,name=args,proto3" json:"args,omitempty"`
+	Timeout          string                 `protobuf:"bytes,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Queues           []string               `protobuf:"bytes,12,rep,name=queues,proto3" json:"queues,omitempty"`
+	MaxInstances     int32                  `protobuf:"varint,13,opt,name=max_instances,json=maxInstances,proto3" json:"max_instances,omitempty"`
+	MisfireGraceTime string                 `protobuf:"bytes,17,opt,name=misfire_grace_time,json=misfireGraceTime,proto3" json:"misfire_grace_time,omitempty"`
+	Coalesce         *bool                  `protobuf:"varint,18,opt,name=coalesce,proto3,oneof" json:"coalesce,omitempty"`
+	MaxAttempts      int32                  `protobuf:"varint,20,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	RetryBackoff     string                 `protobuf:"bytes,21,opt,name=retry_backoff,json=retryBackoff,proto3" json:"retry_backoff,omitempty"`
+	RetryBackoffMax  string                 `protobuf:"bytes,38,opt,name=retry_backoff_max,json=retryBackoffMax,proto3" json:"retry_backoff_max,omitempty"`
+	RetryOnTimeout   bool                   `protobuf:"varint,22,opt,name=retry_on_timeout,json=retryOnTimeout,proto3" json:"retry_on_timeout,omitempty"`
+	Upstreams        []string               `protobuf:"bytes,23,rep,name=upstreams,proto3" json:"upstreams,omitempty"`
+	WorkflowRunId    string                 `protobuf:"bytes,24,opt,name=workflow_run_id,json=workflowRunId,proto3" json:"workflow_run_id,omitempty"`
+	HeartbeatTimeout string                 `protobuf:"bytes,32,opt,name=heartbeat_timeout,json=heartbeatTimeout,proto3" json:"heartbeat_timeout,omitempty"`
+	SoftTimeout      string                 `protobuf:"bytes,33,opt,name=soft_timeout,json=softTimeout,proto3" json:"soft_timeout,omitempty"`
+	Sla              string                 `protobuf:"bytes,34,opt,name=sla,proto3" json:"sla,omitempty"`
+	Priority         int32                  `protobuf:"varint,35,opt,name=priority,proto3" json:"priority,omitempty"`
+	RateLimiter      string                 `protobuf:"bytes,36,opt,name=rate_limiter,json=rateLimiter,proto3" json:"rate_limiter,omitempty"`
+	RateLimitPolicy  string                 `protobuf:"bytes,37,opt,name=rate_limit_policy,json=rateLimitPolicy,proto3" json:"rate_limit_policy,omitempty"`
+	MaxRuns          int32                  `protobuf:"varint,25,opt,name=max_runs,json=maxRuns,proto3" json:"max_runs,omitempty"`
+	Runs             int32                  `protobuf:"varint,26,opt,name=runs,proto3" json:"runs,omitempty"`
+	LastRunTime      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=last_run_time,json=lastRunTime,proto3" json:"last_run_time,omitempty"`
+	NextRunTime      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	Status           string                 `protobuf:"bytes,16,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
 func (x *Job) Reset() {
 	*x = Job{}
-	mi := &file_scheduler_proto_msgTypes[1]
+	mi := &file_scheduler_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -104,7 +201,7 @@ func (x *Job) String() string {
 func (*Job) ProtoMessage() {}
 
 func (x *Job) ProtoReflect() protoreflect.Message {
-	mi := &file_scheduler_proto_msgTypes[1]
+	mi := &file_scheduler_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -117,7 +214,7 @@ func (x *Job) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Job.ProtoReflect.Descriptor instead.
 func (*Job) Descriptor() ([]byte, []int) {
-	return file_scheduler_proto_rawDescGZIP(), []int{1}
+	return file_scheduler_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Job) GetId() string {
@@ -162,6 +259,13 @@ func (x *Job) GetInterval() string {
 	return ""
 }
 
+func (x *Job) GetIntervalMode() string {
+	if x != nil {
+		return x.IntervalMode
+	}
+	return ""
+}
+
 func (x *Job) GetCronExpr() string {
 	if x != nil {
 		return x.CronExpr
@@ -169,6 +273,34 @@ func (x *Job) GetCronExpr() string {
 	return ""
 }
 
+func (x *Job) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *Job) GetTriggers() []*Trigger {
+	if x != nil {
+		return x.Triggers
+	}
+	return nil
+}
+
+func (x *Job) GetTriggerMode() string {
+	if x != nil {
+		return x.TriggerMode
+	}
+	return ""
+}
+
+func (x *Job) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
 func (x *Job) GetTimezone() string {
 	if x != nil {
 		return x.Timezone
@@ -211,6 +343,118 @@ func (x *Job) GetMaxInstances() int32 {
 	return 0
 }
 
+func (x *Job) GetMisfireGraceTime() string {
+	if x != nil {
+		return x.MisfireGraceTime
+	}
+	return ""
+}
+
+func (x *Job) GetCoalesce() bool {
+	if x != nil && x.Coalesce != nil {
+		return *x.Coalesce
+	}
+	return false
+}
+
+func (x *Job) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Job) GetRetryBackoff() string {
+	if x != nil {
+		return x.RetryBackoff
+	}
+	return ""
+}
+
+func (x *Job) GetRetryBackoffMax() string {
+	if x != nil {
+		return x.RetryBackoffMax
+	}
+	return ""
+}
+
+func (x *Job) GetRetryOnTimeout() bool {
+	if x != nil {
+		return x.RetryOnTimeout
+	}
+	return false
+}
+
+func (x *Job) GetUpstreams() []string {
+	if x != nil {
+		return x.Upstreams
+	}
+	return nil
+}
+
+func (x *Job) GetWorkflowRunId() string {
+	if x != nil {
+		return x.WorkflowRunId
+	}
+	return ""
+}
+
+func (x *Job) GetHeartbeatTimeout() string {
+	if x != nil {
+		return x.HeartbeatTimeout
+	}
+	return ""
+}
+
+func (x *Job) GetSoftTimeout() string {
+	if x != nil {
+		return x.SoftTimeout
+	}
+	return ""
+}
+
+func (x *Job) GetSla() string {
+	if x != nil {
+		return x.Sla
+	}
+	return ""
+}
+
+func (x *Job) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Job) GetRateLimiter() string {
+	if x != nil {
+		return x.RateLimiter
+	}
+	return ""
+}
+
+func (x *Job) GetRateLimitPolicy() string {
+	if x != nil {
+		return x.RateLimitPolicy
+	}
+	return ""
+}
+
+func (x *Job) GetMaxRuns() int32 {
+	if x != nil {
+		return x.MaxRuns
+	}
+	return 0
+}
+
+func (x *Job) GetRuns() int32 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
 func (x *Job) GetLastRunTime() *timestamppb.Timestamp {
 	if x != nil {
 		return x.LastRunTime
@@ -241,7 +485,7 @@ type JobsResp struct {
 
 func (x *JobsResp) Reset() {
 	*x = JobsResp{}
-	mi := &file_scheduler_proto_msgTypes[2]
+	mi := &file_scheduler_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -253,7 +497,7 @@ func (x *JobsResp) String() string {
 func (*JobsResp) ProtoMessage() {}
 
 func (x *JobsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_scheduler_proto_msgTypes[2]
+	mi := &file_scheduler_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +510,7 @@ func (x *JobsResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobsResp.ProtoReflect.Descriptor instead.
 func (*JobsResp) Descriptor() ([]byte, []int) {
-	return file_scheduler_proto_rawDescGZIP(), []int{2}
+	return file_scheduler_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *JobsResp) GetJobs() []*Job {
@@ -276,33 +520,745 @@ func (x *JobsResp) GetJobs() []*Job {
 	return nil
 }
 
-var File_scheduler_proto protoreflect.FileDescriptor
+type CalendarReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_scheduler_proto_rawDesc = "" +
-	"\n" +
-	"\x0fscheduler.proto\x12\bservices\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x18\n" +
-	"\x06JobReq\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xfd\x03\n" +
-	"\x03Job\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\x12\x19\n" +
-	"\bstart_at\x18\x04 \x01(\tR\astartAt\x12\x15\n" +
-	"\x06end_at\x18\x05 \x01(\tR\x05endAt\x12\x1a\n" +
-	"\binterval\x18\x06 \x01(\tR\binterval\x12\x1b\n" +
-	"\tcron_expr\x18\a \x01(\tR\bcronExpr\x12\x1a\n" +
-	"\btimezone\x18\b \x01(\tR\btimezone\x12\x1b\n" +
-	"\tfunc_name\x18\t \x01(\tR\bfuncName\x12+\n" +
-	"\x04args\x18\n" +
-	" \x01(\v2\x17.google.protobuf.StructR\x04args\x12\x18\n" +
-	"\atimeout\x18\v \x01(\tR\atimeout\x12\x16\n" +
-	"\x06queues\x18\f \x03(\tR\x06queues\x12#\n" +
-	"\rmax_instances\x18\r \x01(\x05R\fmaxInstances\x12>\n" +
-	"\rlast_run_time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vlastRunTime\x12>\n" +
-	"\rnext_run_time\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vnextRunTime\x12\x16\n" +
-	"\x06status\x18\x10 \x01(\tR\x06status\"-\n" +
-	"\bJobsResp\x12!\n" +
-	"\x04jobs\x18\x01 \x03(\v2\r.services.JobR\x04jobs2\x86\x05\n" +
+func (x *CalendarReq) Reset() {
+	*x = CalendarReq{}
+	mi := &file_scheduler_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarReq) ProtoMessage() {}
+
+func (x *CalendarReq) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarReq.ProtoReflect.Descriptor instead.
+func (*CalendarReq) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CalendarReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CalendarRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarRange) Reset() {
+	*x = CalendarRange{}
+	mi := &file_scheduler_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarRange) ProtoMessage() {}
+
+func (x *CalendarRange) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarRange.ProtoReflect.Descriptor instead.
+func (*CalendarRange) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CalendarRange) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *CalendarRange) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+type Calendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Excludes      []*CalendarRange       `protobuf:"bytes,3,rep,name=excludes,proto3" json:"excludes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Calendar) Reset() {
+	*x = Calendar{}
+	mi := &file_scheduler_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Calendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calendar) ProtoMessage() {}
+
+func (x *Calendar) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calendar.ProtoReflect.Descriptor instead.
+func (*Calendar) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Calendar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Calendar) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Calendar) GetExcludes() []*CalendarRange {
+	if x != nil {
+		return x.Excludes
+	}
+	return nil
+}
+
+type CalendarsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendars     []*Calendar            `protobuf:"bytes,1,rep,name=calendars,proto3" json:"calendars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarsResp) Reset() {
+	*x = CalendarsResp{}
+	mi := &file_scheduler_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarsResp) ProtoMessage() {}
+
+func (x *CalendarsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarsResp.ProtoReflect.Descriptor instead.
+func (*CalendarsResp) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CalendarsResp) GetCalendars() []*Calendar {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+type RunReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      uint64                 `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunReq) Reset() {
+	*x = RunReq{}
+	mi := &file_scheduler_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunReq) ProtoMessage() {}
+
+func (x *RunReq) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunReq.ProtoReflect.Descriptor instead.
+func (*RunReq) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RunReq) GetRecordId() uint64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+type WorkflowStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobName       string                 `protobuf:"bytes,2,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Upstreams     []string               `protobuf:"bytes,3,rep,name=upstreams,proto3" json:"upstreams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowStep) Reset() {
+	*x = WorkflowStep{}
+	mi := &file_scheduler_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowStep) ProtoMessage() {}
+
+func (x *WorkflowStep) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowStep.ProtoReflect.Descriptor instead.
+func (*WorkflowStep) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WorkflowStep) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *WorkflowStep) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *WorkflowStep) GetUpstreams() []string {
+	if x != nil {
+		return x.Upstreams
+	}
+	return nil
+}
+
+type Workflow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RootJobIds    []string               `protobuf:"bytes,1,rep,name=root_job_ids,json=rootJobIds,proto3" json:"root_job_ids,omitempty"`
+	Steps         []*WorkflowStep        `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workflow) Reset() {
+	*x = Workflow{}
+	mi := &file_scheduler_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workflow) ProtoMessage() {}
+
+func (x *Workflow) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workflow.ProtoReflect.Descriptor instead.
+func (*Workflow) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Workflow) GetRootJobIds() []string {
+	if x != nil {
+		return x.RootJobIds
+	}
+	return nil
+}
+
+func (x *Workflow) GetSteps() []*WorkflowStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type WorkflowsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflows     []*Workflow            `protobuf:"bytes,1,rep,name=workflows,proto3" json:"workflows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowsResp) Reset() {
+	*x = WorkflowsResp{}
+	mi := &file_scheduler_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowsResp) ProtoMessage() {}
+
+func (x *WorkflowsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowsResp.ProtoReflect.Descriptor instead.
+func (*WorkflowsResp) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WorkflowsResp) GetWorkflows() []*Workflow {
+	if x != nil {
+		return x.Workflows
+	}
+	return nil
+}
+
+type WorkflowRunReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowRunReq) Reset() {
+	*x = WorkflowRunReq{}
+	mi := &file_scheduler_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowRunReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowRunReq) ProtoMessage() {}
+
+func (x *WorkflowRunReq) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowRunReq.ProtoReflect.Descriptor instead.
+func (*WorkflowRunReq) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WorkflowRunReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WorkflowRunStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobName       string                 `protobuf:"bytes,2,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Result        string                 `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowRunStep) Reset() {
+	*x = WorkflowRunStep{}
+	mi := &file_scheduler_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowRunStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowRunStep) ProtoMessage() {}
+
+func (x *WorkflowRunStep) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowRunStep.ProtoReflect.Descriptor instead.
+func (*WorkflowRunStep) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WorkflowRunStep) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *WorkflowRunStep) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *WorkflowRunStep) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WorkflowRunStep) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type WorkflowRun struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Id            string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RootJobIds    []string                    `protobuf:"bytes,2,rep,name=root_job_ids,json=rootJobIds,proto3" json:"root_job_ids,omitempty"`
+	Status        string                      `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Steps         map[string]*WorkflowRunStep `protobuf:"bytes,4,rep,name=steps,proto3" json:"steps,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StartAt       *timestamppb.Timestamp      `protobuf:"bytes,5,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt         *timestamppb.Timestamp      `protobuf:"bytes,6,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowRun) Reset() {
+	*x = WorkflowRun{}
+	mi := &file_scheduler_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowRun) ProtoMessage() {}
+
+func (x *WorkflowRun) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowRun.ProtoReflect.Descriptor instead.
+func (*WorkflowRun) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WorkflowRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkflowRun) GetRootJobIds() []string {
+	if x != nil {
+		return x.RootJobIds
+	}
+	return nil
+}
+
+func (x *WorkflowRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WorkflowRun) GetSteps() map[string]*WorkflowRunStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *WorkflowRun) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *WorkflowRun) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+type WorkflowRunsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowRuns  []*WorkflowRun         `protobuf:"bytes,1,rep,name=workflow_runs,json=workflowRuns,proto3" json:"workflow_runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowRunsResp) Reset() {
+	*x = WorkflowRunsResp{}
+	mi := &file_scheduler_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowRunsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowRunsResp) ProtoMessage() {}
+
+func (x *WorkflowRunsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowRunsResp.ProtoReflect.Descriptor instead.
+func (*WorkflowRunsResp) Descriptor() ([]byte, []int) {
+	return file_scheduler_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WorkflowRunsResp) GetWorkflowRuns() []*WorkflowRun {
+	if x != nil {
+		return x.WorkflowRuns
+	}
+	return nil
+}
+
+var File_scheduler_proto protoreflect.FileDescriptor
+
+const file_scheduler_proto_rawDesc = "" +
+	"\n" +
+	"\x0fscheduler.proto\x12\bservices\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x18\n" +
+	"\x06JobReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x87\x01\n" +
+	"\aTrigger\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x19\n" +
+	"\bstart_at\x18\x02 \x01(\tR\astartAt\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\tR\binterval\x12\x1b\n" +
+	"\tcron_expr\x18\x04 \x01(\tR\bcronExpr\x12\x14\n" +
+	"\x05rrule\x18\x05 \x01(\tR\x05rrule\"\xf6\t\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x19\n" +
+	"\bstart_at\x18\x04 \x01(\tR\astartAt\x12\x15\n" +
+	"\x06end_at\x18\x05 \x01(\tR\x05endAt\x12\x1a\n" +
+	"\binterval\x18\x06 \x01(\tR\binterval\x12#\n" +
+	"\rinterval_mode\x18\x13 \x01(\tR\fintervalMode\x12\x1b\n" +
+	"\tcron_expr\x18\a \x01(\tR\bcronExpr\x12\x14\n" +
+	"\x05rrule\x18\x1d \x01(\tR\x05rrule\x12-\n" +
+	"\btriggers\x18\x1b \x03(\v2\x11.services.TriggerR\btriggers\x12!\n" +
+	"\ftrigger_mode\x18\x1c \x01(\tR\vtriggerMode\x12\x1a\n" +
+	"\bcalendar\x18\x1e \x01(\tR\bcalendar\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12\x1b\n" +
+	"\tfunc_name\x18\t \x01(\tR\bfuncName\x12+\n" +
+	"\x04args\x18\n" +
+	" \x01(\v2\x17.google.protobuf.StructR\x04args\x12\x18\n" +
+	"\atimeout\x18\v \x01(\tR\atimeout\x12\x16\n" +
+	"\x06queues\x18\f \x03(\tR\x06queues\x12#\n" +
+	"\rmax_instances\x18\r \x01(\x05R\fmaxInstances\x12,\n" +
+	"\x12misfire_grace_time\x18\x11 \x01(\tR\x10misfireGraceTime\x12\x1f\n" +
+	"\bcoalesce\x18\x12 \x01(\bH\x00R\bcoalesce\x88\x01\x01\x12!\n" +
+	"\fmax_attempts\x18\x14 \x01(\x05R\vmaxAttempts\x12#\n" +
+	"\rretry_backoff\x18\x15 \x01(\tR\fretryBackoff\x12*\n" +
+	"\x11retry_backoff_max\x18& \x01(\tR\x0fretryBackoffMax\x12(\n" +
+	"\x10retry_on_timeout\x18\x16 \x01(\bR\x0eretryOnTimeout\x12\x1c\n" +
+	"\tupstreams\x18\x17 \x03(\tR\tupstreams\x12&\n" +
+	"\x0fworkflow_run_id\x18\x18 \x01(\tR\rworkflowRunId\x12+\n" +
+	"\x11heartbeat_timeout\x18  \x01(\tR\x10heartbeatTimeout\x12!\n" +
+	"\fsoft_timeout\x18! \x01(\tR\vsoftTimeout\x12\x10\n" +
+	"\x03sla\x18\" \x01(\tR\x03sla\x12\x1a\n" +
+	"\bpriority\x18# \x01(\x05R\bpriority\x12!\n" +
+	"\frate_limiter\x18$ \x01(\tR\vrateLimiter\x12*\n" +
+	"\x11rate_limit_policy\x18% \x01(\tR\x0frateLimitPolicy\x12\x19\n" +
+	"\bmax_runs\x18\x19 \x01(\x05R\amaxRuns\x12\x12\n" +
+	"\x04runs\x18\x1a \x01(\x05R\x04runs\x12>\n" +
+	"\rlast_run_time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vlastRunTime\x12>\n" +
+	"\rnext_run_time\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vnextRunTime\x12\x16\n" +
+	"\x06status\x18\x10 \x01(\tR\x06statusB\v\n" +
+	"\t_coalesceJ\x04\b\x1f\x10 R\fscheduled_at\"-\n" +
+	"\bJobsResp\x12!\n" +
+	"\x04jobs\x18\x01 \x03(\v2\r.services.JobR\x04jobs\"!\n" +
+	"\vCalendarReq\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"7\n" +
+	"\rCalendarRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\"u\n" +
+	"\bCalendar\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x123\n" +
+	"\bexcludes\x18\x03 \x03(\v2\x17.services.CalendarRangeR\bexcludes\"A\n" +
+	"\rCalendarsResp\x120\n" +
+	"\tcalendars\x18\x01 \x03(\v2\x12.services.CalendarR\tcalendars\"%\n" +
+	"\x06RunReq\x12\x1b\n" +
+	"\trecord_id\x18\x01 \x01(\x04R\brecordId\"^\n" +
+	"\fWorkflowStep\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x19\n" +
+	"\bjob_name\x18\x02 \x01(\tR\ajobName\x12\x1c\n" +
+	"\tupstreams\x18\x03 \x03(\tR\tupstreams\"Z\n" +
+	"\bWorkflow\x12 \n" +
+	"\froot_job_ids\x18\x01 \x03(\tR\n" +
+	"rootJobIds\x12,\n" +
+	"\x05steps\x18\x02 \x03(\v2\x16.services.WorkflowStepR\x05steps\"A\n" +
+	"\rWorkflowsResp\x120\n" +
+	"\tworkflows\x18\x01 \x03(\v2\x12.services.WorkflowR\tworkflows\" \n" +
+	"\x0eWorkflowRunReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"s\n" +
+	"\x0fWorkflowRunStep\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x19\n" +
+	"\bjob_name\x18\x02 \x01(\tR\ajobName\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\"\xce\x02\n" +
+	"\vWorkflowRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\froot_job_ids\x18\x02 \x03(\tR\n" +
+	"rootJobIds\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x126\n" +
+	"\x05steps\x18\x04 \x03(\v2 .services.WorkflowRun.StepsEntryR\x05steps\x125\n" +
+	"\bstart_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
+	"\x06end_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\x1aS\n" +
+	"\n" +
+	"StepsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.services.WorkflowRunStepR\x05value:\x028\x01\"N\n" +
+	"\x10WorkflowRunsResp\x12:\n" +
+	"\rworkflow_runs\x18\x01 \x03(\v2\x15.services.WorkflowRunR\fworkflowRuns2\x94\t\n" +
 	"\tScheduler\x12(\n" +
 	"\x06AddJob\x12\r.services.Job\x1a\r.services.Job\"\x00\x12+\n" +
 	"\x06GetJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x12:\n" +
@@ -314,7 +1270,15 @@ const file_scheduler_proto_rawDesc = "" +
 	"\bPauseJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x12.\n" +
 	"\tResumeJob\x12\x10.services.JobReq\x1a\r.services.Job\"\x00\x121\n" +
 	"\x06RunJob\x12\r.services.Job\x1a\x16.google.protobuf.Empty\"\x00\x126\n" +
-	"\vScheduleJob\x12\r.services.Job\x1a\x16.google.protobuf.Empty\"\x00\x129\n" +
+	"\vScheduleJob\x12\r.services.Job\x1a\x16.google.protobuf.Empty\"\x00\x127\n" +
+	"\tCancelRun\x12\x10.services.RunReq\x1a\x16.google.protobuf.Empty\"\x00\x12D\n" +
+	"\x0fGetAllWorkflows\x12\x16.google.protobuf.Empty\x1a\x17.services.WorkflowsResp\"\x00\x12C\n" +
+	"\x0eGetWorkflowRun\x12\x18.services.WorkflowRunReq\x1a\x15.services.WorkflowRun\"\x00\x12J\n" +
+	"\x12GetAllWorkflowRuns\x12\x16.google.protobuf.Empty\x1a\x1a.services.WorkflowRunsResp\"\x00\x127\n" +
+	"\vAddCalendar\x12\x12.services.Calendar\x1a\x12.services.Calendar\"\x00\x12:\n" +
+	"\vGetCalendar\x12\x15.services.CalendarReq\x1a\x12.services.Calendar\"\x00\x12D\n" +
+	"\x0fGetAllCalendars\x12\x16.google.protobuf.Empty\x1a\x17.services.CalendarsResp\"\x00\x12A\n" +
+	"\x0eDeleteCalendar\x12\x15.services.CalendarReq\x1a\x16.google.protobuf.Empty\"\x00\x129\n" +
 	"\x05Start\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x128\n" +
 	"\x04Stop\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00B\rZ\v./;servicesb\x06proto3"
 
@@ -330,49 +1294,89 @@ func file_scheduler_proto_rawDescGZIP() []byte {
 	return file_scheduler_proto_rawDescData
 }
 
-var file_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_scheduler_proto_goTypes = []any{
 	(*JobReq)(nil),                // 0: services.JobReq
-	(*Job)(nil),                   // 1: services.Job
-	(*JobsResp)(nil),              // 2: services.JobsResp
-	(*structpb.Struct)(nil),       // 3: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 5: google.protobuf.Empty
+	(*Trigger)(nil),               // 1: services.Trigger
+	(*Job)(nil),                   // 2: services.Job
+	(*JobsResp)(nil),              // 3: services.JobsResp
+	(*CalendarReq)(nil),           // 4: services.CalendarReq
+	(*CalendarRange)(nil),         // 5: services.CalendarRange
+	(*Calendar)(nil),              // 6: services.Calendar
+	(*CalendarsResp)(nil),         // 7: services.CalendarsResp
+	(*RunReq)(nil),                // 8: services.RunReq
+	(*WorkflowStep)(nil),          // 9: services.WorkflowStep
+	(*Workflow)(nil),              // 10: services.Workflow
+	(*WorkflowsResp)(nil),         // 11: services.WorkflowsResp
+	(*WorkflowRunReq)(nil),        // 12: services.WorkflowRunReq
+	(*WorkflowRunStep)(nil),       // 13: services.WorkflowRunStep
+	(*WorkflowRun)(nil),           // 14: services.WorkflowRun
+	(*WorkflowRunsResp)(nil),      // 15: services.WorkflowRunsResp
+	nil,                           // 16: services.WorkflowRun.StepsEntry
+	(*structpb.Struct)(nil),       // 17: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 19: google.protobuf.Empty
 }
 var file_scheduler_proto_depIdxs = []int32{
-	3,  // 0: services.Job.args:type_name -> google.protobuf.Struct
-	4,  // 1: services.Job.last_run_time:type_name -> google.protobuf.Timestamp
-	4,  // 2: services.Job.next_run_time:type_name -> google.protobuf.Timestamp
-	1,  // 3: services.JobsResp.jobs:type_name -> services.Job
-	1,  // 4: services.Scheduler.AddJob:input_type -> services.Job
-	0,  // 5: services.Scheduler.GetJob:input_type -> services.JobReq
-	5,  // 6: services.Scheduler.GetAllJobs:input_type -> google.protobuf.Empty
-	1,  // 7: services.Scheduler.UpdateJob:input_type -> services.Job
-	0,  // 8: services.Scheduler.DeleteJob:input_type -> services.JobReq
-	5,  // 9: services.Scheduler.DeleteAllJobs:input_type -> google.protobuf.Empty
-	0,  // 10: services.Scheduler.PauseJob:input_type -> services.JobReq
-	0,  // 11: services.Scheduler.ResumeJob:input_type -> services.JobReq
-	1,  // 12: services.Scheduler.RunJob:input_type -> services.Job
-	1,  // 13: services.Scheduler.ScheduleJob:input_type -> services.Job
-	5,  // 14: services.Scheduler.Start:input_type -> google.protobuf.Empty
-	5,  // 15: services.Scheduler.Stop:input_type -> google.protobuf.Empty
-	1,  // 16: services.Scheduler.AddJob:output_type -> services.Job
-	1,  // 17: services.Scheduler.GetJob:output_type -> services.Job
-	2,  // 18: services.Scheduler.GetAllJobs:output_type -> services.JobsResp
-	1,  // 19: services.Scheduler.UpdateJob:output_type -> services.Job
-	5,  // 20: services.Scheduler.DeleteJob:output_type -> google.protobuf.Empty
-	5,  // 21: services.Scheduler.DeleteAllJobs:output_type -> google.protobuf.Empty
-	1,  // 22: services.Scheduler.PauseJob:output_type -> services.Job
-	1,  // 23: services.Scheduler.ResumeJob:output_type -> services.Job
-	5,  // 24: services.Scheduler.RunJob:output_type -> google.protobuf.Empty
-	5,  // 25: services.Scheduler.ScheduleJob:output_type -> google.protobuf.Empty
-	5,  // 26: services.Scheduler.Start:output_type -> google.protobuf.Empty
-	5,  // 27: services.Scheduler.Stop:output_type -> google.protobuf.Empty
-	16, // [16:28] is the sub-list for method output_type
-	4,  // [4:16] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	1,  // 0: services.Job.triggers:type_name -> services.Trigger
+	17, // 1: services.Job.args:type_name -> google.protobuf.Struct
+	18, // 2: services.Job.last_run_time:type_name -> google.protobuf.Timestamp
+	18, // 3: services.Job.next_run_time:type_name -> google.protobuf.Timestamp
+	2,  // 4: services.JobsResp.jobs:type_name -> services.Job
+	5,  // 5: services.Calendar.excludes:type_name -> services.CalendarRange
+	6,  // 6: services.CalendarsResp.calendars:type_name -> services.Calendar
+	9,  // 7: services.Workflow.steps:type_name -> services.WorkflowStep
+	10, // 8: services.WorkflowsResp.workflows:type_name -> services.Workflow
+	16, // 9: services.WorkflowRun.steps:type_name -> services.WorkflowRun.StepsEntry
+	18, // 10: services.WorkflowRun.start_at:type_name -> google.protobuf.Timestamp
+	18, // 11: services.WorkflowRun.end_at:type_name -> google.protobuf.Timestamp
+	14, // 12: services.WorkflowRunsResp.workflow_runs:type_name -> services.WorkflowRun
+	13, // 13: services.WorkflowRun.StepsEntry.value:type_name -> services.WorkflowRunStep
+	2,  // 14: services.Scheduler.AddJob:input_type -> services.Job
+	0,  // 15: services.Scheduler.GetJob:input_type -> services.JobReq
+	19, // 16: services.Scheduler.GetAllJobs:input_type -> google.protobuf.Empty
+	2,  // 17: services.Scheduler.UpdateJob:input_type -> services.Job
+	0,  // 18: services.Scheduler.DeleteJob:input_type -> services.JobReq
+	19, // 19: services.Scheduler.DeleteAllJobs:input_type -> google.protobuf.Empty
+	0,  // 20: services.Scheduler.PauseJob:input_type -> services.JobReq
+	0,  // 21: services.Scheduler.ResumeJob:input_type -> services.JobReq
+	2,  // 22: services.Scheduler.RunJob:input_type -> services.Job
+	2,  // 23: services.Scheduler.ScheduleJob:input_type -> services.Job
+	8,  // 24: services.Scheduler.CancelRun:input_type -> services.RunReq
+	19, // 25: services.Scheduler.GetAllWorkflows:input_type -> google.protobuf.Empty
+	12, // 26: services.Scheduler.GetWorkflowRun:input_type -> services.WorkflowRunReq
+	19, // 27: services.Scheduler.GetAllWorkflowRuns:input_type -> google.protobuf.Empty
+	6,  // 28: services.Scheduler.AddCalendar:input_type -> services.Calendar
+	4,  // 29: services.Scheduler.GetCalendar:input_type -> services.CalendarReq
+	19, // 30: services.Scheduler.GetAllCalendars:input_type -> google.protobuf.Empty
+	4,  // 31: services.Scheduler.DeleteCalendar:input_type -> services.CalendarReq
+	19, // 32: services.Scheduler.Start:input_type -> google.protobuf.Empty
+	19, // 33: services.Scheduler.Stop:input_type -> google.protobuf.Empty
+	2,  // 34: services.Scheduler.AddJob:output_type -> services.Job
+	2,  // 35: services.Scheduler.GetJob:output_type -> services.Job
+	3,  // 36: services.Scheduler.GetAllJobs:output_type -> services.JobsResp
+	2,  // 37: services.Scheduler.UpdateJob:output_type -> services.Job
+	19, // 38: services.Scheduler.DeleteJob:output_type -> google.protobuf.Empty
+	19, // 39: services.Scheduler.DeleteAllJobs:output_type -> google.protobuf.Empty
+	2,  // 40: services.Scheduler.PauseJob:output_type -> services.Job
+	2,  // 41: services.Scheduler.ResumeJob:output_type -> services.Job
+	19, // 42: services.Scheduler.RunJob:output_type -> google.protobuf.Empty
+	19, // 43: services.Scheduler.ScheduleJob:output_type -> google.protobuf.Empty
+	19, // 44: services.Scheduler.CancelRun:output_type -> google.protobuf.Empty
+	11, // 45: services.Scheduler.GetAllWorkflows:output_type -> services.WorkflowsResp
+	14, // 46: services.Scheduler.GetWorkflowRun:output_type -> services.WorkflowRun
+	15, // 47: services.Scheduler.GetAllWorkflowRuns:output_type -> services.WorkflowRunsResp
+	6,  // 48: services.Scheduler.AddCalendar:output_type -> services.Calendar
+	6,  // 49: services.Scheduler.GetCalendar:output_type -> services.Calendar
+	7,  // 50: services.Scheduler.GetAllCalendars:output_type -> services.CalendarsResp
+	19, // 51: services.Scheduler.DeleteCalendar:output_type -> google.protobuf.Empty
+	19, // 52: services.Scheduler.Start:output_type -> google.protobuf.Empty
+	19, // 53: services.Scheduler.Stop:output_type -> google.protobuf.Empty
+	34, // [34:54] is the sub-list for method output_type
+	14, // [14:34] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_scheduler_proto_init() }
@@ -380,13 +1384,14 @@ func file_scheduler_proto_init() {
 	if File_scheduler_proto != nil {
 		return
 	}
+	file_scheduler_proto_msgTypes[2].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scheduler_proto_rawDesc), len(file_scheduler_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
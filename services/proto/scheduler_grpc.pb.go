@@ -20,18 +20,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Scheduler_AddJob_FullMethodName        = "/services.Scheduler/AddJob"
-	Scheduler_GetJob_FullMethodName        = "/services.Scheduler/GetJob"
-	Scheduler_GetAllJobs_FullMethodName    = "/services.Scheduler/GetAllJobs"
-	Scheduler_UpdateJob_FullMethodName     = "/services.Scheduler/UpdateJob"
-	Scheduler_DeleteJob_FullMethodName     = "/services.Scheduler/DeleteJob"
-	Scheduler_DeleteAllJobs_FullMethodName = "/services.Scheduler/DeleteAllJobs"
-	Scheduler_PauseJob_FullMethodName      = "/services.Scheduler/PauseJob"
-	Scheduler_ResumeJob_FullMethodName     = "/services.Scheduler/ResumeJob"
-	Scheduler_RunJob_FullMethodName        = "/services.Scheduler/RunJob"
-	Scheduler_ScheduleJob_FullMethodName   = "/services.Scheduler/ScheduleJob"
-	Scheduler_Start_FullMethodName         = "/services.Scheduler/Start"
-	Scheduler_Stop_FullMethodName          = "/services.Scheduler/Stop"
+	Scheduler_AddJob_FullMethodName             = "/services.Scheduler/AddJob"
+	Scheduler_GetJob_FullMethodName             = "/services.Scheduler/GetJob"
+	Scheduler_GetAllJobs_FullMethodName         = "/services.Scheduler/GetAllJobs"
+	Scheduler_UpdateJob_FullMethodName          = "/services.Scheduler/UpdateJob"
+	Scheduler_DeleteJob_FullMethodName          = "/services.Scheduler/DeleteJob"
+	Scheduler_DeleteAllJobs_FullMethodName      = "/services.Scheduler/DeleteAllJobs"
+	Scheduler_PauseJob_FullMethodName           = "/services.Scheduler/PauseJob"
+	Scheduler_ResumeJob_FullMethodName          = "/services.Scheduler/ResumeJob"
+	Scheduler_RunJob_FullMethodName             = "/services.Scheduler/RunJob"
+	Scheduler_ScheduleJob_FullMethodName        = "/services.Scheduler/ScheduleJob"
+	Scheduler_CancelRun_FullMethodName          = "/services.Scheduler/CancelRun"
+	Scheduler_GetAllWorkflows_FullMethodName    = "/services.Scheduler/GetAllWorkflows"
+	Scheduler_GetWorkflowRun_FullMethodName     = "/services.Scheduler/GetWorkflowRun"
+	Scheduler_GetAllWorkflowRuns_FullMethodName = "/services.Scheduler/GetAllWorkflowRuns"
+	Scheduler_AddCalendar_FullMethodName        = "/services.Scheduler/AddCalendar"
+	Scheduler_GetCalendar_FullMethodName        = "/services.Scheduler/GetCalendar"
+	Scheduler_GetAllCalendars_FullMethodName    = "/services.Scheduler/GetAllCalendars"
+	Scheduler_DeleteCalendar_FullMethodName     = "/services.Scheduler/DeleteCalendar"
+	Scheduler_Start_FullMethodName              = "/services.Scheduler/Start"
+	Scheduler_Stop_FullMethodName               = "/services.Scheduler/Stop"
 )
 
 // SchedulerClient is the client API for Scheduler service.
@@ -48,6 +56,14 @@ type SchedulerClient interface {
 	ResumeJob(ctx context.Context, in *JobReq, opts ...grpc.CallOption) (*Job, error)
 	RunJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ScheduleJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CancelRun(ctx context.Context, in *RunReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAllWorkflows(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WorkflowsResp, error)
+	GetWorkflowRun(ctx context.Context, in *WorkflowRunReq, opts ...grpc.CallOption) (*WorkflowRun, error)
+	GetAllWorkflowRuns(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WorkflowRunsResp, error)
+	AddCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Calendar, error)
+	GetCalendar(ctx context.Context, in *CalendarReq, opts ...grpc.CallOption) (*Calendar, error)
+	GetAllCalendars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CalendarsResp, error)
+	DeleteCalendar(ctx context.Context, in *CalendarReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Start(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Stop(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
@@ -160,6 +176,86 @@ func (c *schedulerClient) ScheduleJob(ctx context.Context, in *Job, opts ...grpc
 	return out, nil
 }
 
+func (c *schedulerClient) CancelRun(ctx context.Context, in *RunReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Scheduler_CancelRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetAllWorkflows(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WorkflowsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowsResp)
+	err := c.cc.Invoke(ctx, Scheduler_GetAllWorkflows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetWorkflowRun(ctx context.Context, in *WorkflowRunReq, opts ...grpc.CallOption) (*WorkflowRun, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowRun)
+	err := c.cc.Invoke(ctx, Scheduler_GetWorkflowRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetAllWorkflowRuns(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WorkflowRunsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowRunsResp)
+	err := c.cc.Invoke(ctx, Scheduler_GetAllWorkflowRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) AddCalendar(ctx context.Context, in *Calendar, opts ...grpc.CallOption) (*Calendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Calendar)
+	err := c.cc.Invoke(ctx, Scheduler_AddCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetCalendar(ctx context.Context, in *CalendarReq, opts ...grpc.CallOption) (*Calendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Calendar)
+	err := c.cc.Invoke(ctx, Scheduler_GetCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetAllCalendars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CalendarsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalendarsResp)
+	err := c.cc.Invoke(ctx, Scheduler_GetAllCalendars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) DeleteCalendar(ctx context.Context, in *CalendarReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Scheduler_DeleteCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *schedulerClient) Start(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -194,6 +290,14 @@ type SchedulerServer interface {
 	ResumeJob(context.Context, *JobReq) (*Job, error)
 	RunJob(context.Context, *Job) (*emptypb.Empty, error)
 	ScheduleJob(context.Context, *Job) (*emptypb.Empty, error)
+	CancelRun(context.Context, *RunReq) (*emptypb.Empty, error)
+	GetAllWorkflows(context.Context, *emptypb.Empty) (*WorkflowsResp, error)
+	GetWorkflowRun(context.Context, *WorkflowRunReq) (*WorkflowRun, error)
+	GetAllWorkflowRuns(context.Context, *emptypb.Empty) (*WorkflowRunsResp, error)
+	AddCalendar(context.Context, *Calendar) (*Calendar, error)
+	GetCalendar(context.Context, *CalendarReq) (*Calendar, error)
+	GetAllCalendars(context.Context, *emptypb.Empty) (*CalendarsResp, error)
+	DeleteCalendar(context.Context, *CalendarReq) (*emptypb.Empty, error)
 	Start(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	Stop(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	mustEmbedUnimplementedSchedulerServer()
@@ -236,6 +340,30 @@ func (UnimplementedSchedulerServer) RunJob(context.Context, *Job) (*emptypb.Empt
 func (UnimplementedSchedulerServer) ScheduleJob(context.Context, *Job) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ScheduleJob not implemented")
 }
+func (UnimplementedSchedulerServer) CancelRun(context.Context, *RunReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRun not implemented")
+}
+func (UnimplementedSchedulerServer) GetAllWorkflows(context.Context, *emptypb.Empty) (*WorkflowsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllWorkflows not implemented")
+}
+func (UnimplementedSchedulerServer) GetWorkflowRun(context.Context, *WorkflowRunReq) (*WorkflowRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowRun not implemented")
+}
+func (UnimplementedSchedulerServer) GetAllWorkflowRuns(context.Context, *emptypb.Empty) (*WorkflowRunsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllWorkflowRuns not implemented")
+}
+func (UnimplementedSchedulerServer) AddCalendar(context.Context, *Calendar) (*Calendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCalendar not implemented")
+}
+func (UnimplementedSchedulerServer) GetCalendar(context.Context, *CalendarReq) (*Calendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendar not implemented")
+}
+func (UnimplementedSchedulerServer) GetAllCalendars(context.Context, *emptypb.Empty) (*CalendarsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllCalendars not implemented")
+}
+func (UnimplementedSchedulerServer) DeleteCalendar(context.Context, *CalendarReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCalendar not implemented")
+}
 func (UnimplementedSchedulerServer) Start(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
 }
@@ -443,6 +571,150 @@ func _Scheduler_ScheduleJob_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Scheduler_CancelRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).CancelRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_CancelRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).CancelRun(ctx, req.(*RunReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetAllWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetAllWorkflows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GetAllWorkflows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetAllWorkflows(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetWorkflowRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowRunReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetWorkflowRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GetWorkflowRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetWorkflowRun(ctx, req.(*WorkflowRunReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetAllWorkflowRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetAllWorkflowRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GetAllWorkflowRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetAllWorkflowRuns(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_AddCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Calendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).AddCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_AddCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).AddCalendar(ctx, req.(*Calendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GetCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetCalendar(ctx, req.(*CalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetAllCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetAllCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GetAllCalendars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetAllCalendars(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_DeleteCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).DeleteCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_DeleteCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).DeleteCalendar(ctx, req.(*CalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Scheduler_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -526,6 +798,38 @@ var Scheduler_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ScheduleJob",
 			Handler:    _Scheduler_ScheduleJob_Handler,
 		},
+		{
+			MethodName: "CancelRun",
+			Handler:    _Scheduler_CancelRun_Handler,
+		},
+		{
+			MethodName: "GetAllWorkflows",
+			Handler:    _Scheduler_GetAllWorkflows_Handler,
+		},
+		{
+			MethodName: "GetWorkflowRun",
+			Handler:    _Scheduler_GetWorkflowRun_Handler,
+		},
+		{
+			MethodName: "GetAllWorkflowRuns",
+			Handler:    _Scheduler_GetAllWorkflowRuns_Handler,
+		},
+		{
+			MethodName: "AddCalendar",
+			Handler:    _Scheduler_AddCalendar_Handler,
+		},
+		{
+			MethodName: "GetCalendar",
+			Handler:    _Scheduler_GetCalendar_Handler,
+		},
+		{
+			MethodName: "GetAllCalendars",
+			Handler:    _Scheduler_GetAllCalendars_Handler,
+		},
+		{
+			MethodName: "DeleteCalendar",
+			Handler:    _Scheduler_DeleteCalendar_Handler,
+		},
 		{
 			MethodName: "Start",
 			Handler:    _Scheduler_Start_Handler,
@@ -47,6 +47,11 @@ func (rgrs *rGRPCService) GetAllRecords(ctx context.Context, req *pb.RecordsAllR
 	return rgrs._getRecords("", int(req.GetPage()), int(req.GetPageSize()))
 }
 
+func (rgrs *rGRPCService) GetRecordLogs(ctx context.Context, req *pb.RecordLogsReq) (*pb.RecordLogsResp, error) {
+	logs, err := rgrs.recorder.GetRecordLogs(req.GetId(), int(req.GetTail()))
+	return &pb.RecordLogsResp{Logs: logs}, err
+}
+
 func (rgrs *rGRPCService) DeleteRecords(ctx context.Context, req *pb.JobReq) (*emptypb.Empty, error) {
 	err := rgrs.recorder.DeleteRecords(req.GetId())
 	return &emptypb.Empty{}, err
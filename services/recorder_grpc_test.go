@@ -40,6 +40,10 @@ func testRecorderGRPC(t *testing.T, sc pb.SchedulerClient, rc pb.RecorderClient)
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, 1, int(resp.Total))
+	assert.NotNil(t, resp.Records[0].Progress)
+
+	_, err = rc.GetRecordLogs(ctx, &pb.RecordLogsReq{Id: resp.Records[0].Id, Tail: 10})
+	assert.NoError(t, err)
 
 	_, err = sc.AddJob(ctx, pbJ)
 	assert.NoError(t, err)
@@ -2,6 +2,7 @@ package services
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -13,6 +14,10 @@ type req struct {
 	PageSize int `form:"page_size"`
 }
 
+type logsReq struct {
+	Tail int `form:"tail"`
+}
+
 type rHTTPService struct {
 	recorder *agscheduler.Recorder
 }
@@ -59,6 +64,22 @@ func (rhs *rHTTPService) getRecords(c *gin.Context) {
 	})
 }
 
+func (rhs *rHTTPService) getRecordLogs(c *gin.Context) {
+	var r logsReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": rhs.handleErr(err)})
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": rhs.handleErr(err)})
+		return
+	}
+
+	logs, err := rhs.recorder.GetRecordLogs(id, r.Tail)
+	c.JSON(http.StatusOK, gin.H{"data": logs, "error": rhs.handleErr(err)})
+}
+
 func (rhs *rHTTPService) deleteRecords(c *gin.Context) {
 	err := rhs.recorder.DeleteRecords(c.Param("job_id"))
 	c.JSON(200, gin.H{"data": nil, "error": rhs.handleErr(err)})
@@ -72,6 +93,7 @@ func (rhs *rHTTPService) deleteAllRecords(c *gin.Context) {
 func (rhs *rHTTPService) registerRoutes(r *gin.Engine) {
 	r.GET("/recorder/records/:job_id", rhs.getRecords)
 	r.GET("/recorder/records", rhs.getRecords)
+	r.GET("/recorder/records/logs/:id", rhs.getRecordLogs)
 	r.DELETE("/recorder/records/:job_id", rhs.deleteRecords)
 	r.DELETE("/recorder/records", rhs.deleteAllRecords)
 }
@@ -3,6 +3,7 @@ package services
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"testing"
@@ -55,6 +56,24 @@ func testRecorderHTTP(t *testing.T, baseUrl string) {
 	assert.NoError(t, err)
 	total := int(rJ.Data.(map[string]any)["total"].(float64))
 	assert.Equal(t, 1, total)
+	assert.Contains(t, rJ.Data.(map[string]any)["res"].([]any)[0], "progress")
+	rRs := struct {
+		Data struct {
+			Res []agscheduler.Record `json:"res"`
+		} `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &rRs)
+	assert.NoError(t, err)
+
+	resp, err = http.Get(baseUrl + fmt.Sprintf("/recorder/records/logs/%d?tail=10", rRs.Data.Res[0].Id))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	rJ = &result{}
+	err = json.Unmarshal(body, &rJ)
+	assert.NoError(t, err)
+	assert.Empty(t, rJ.Error)
 
 	resp, err = http.Post(baseUrl+"/scheduler/job", CONTENT_TYPE, bytes.NewReader(bJ))
 	assert.NoError(t, err)
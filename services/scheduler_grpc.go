@@ -98,6 +98,70 @@ func (sgrs *sGRPCService) ScheduleJob(ctx context.Context, pbJob *pb.Job) (*empt
 	return &emptypb.Empty{}, err
 }
 
+func (sgrs *sGRPCService) CancelRun(ctx context.Context, req *pb.RunReq) (*emptypb.Empty, error) {
+	err := sgrs.scheduler.CancelRun(req.GetRecordId())
+	return &emptypb.Empty{}, err
+}
+
+func (sgrs *sGRPCService) GetAllWorkflows(ctx context.Context, in *emptypb.Empty) (*pb.WorkflowsResp, error) {
+	ws, err := sgrs.scheduler.GetAllWorkflows()
+	if err != nil {
+		return &pb.WorkflowsResp{}, err
+	}
+
+	return &pb.WorkflowsResp{Workflows: agscheduler.WorkflowsToPbWorkflowsPtr(ws)}, nil
+}
+
+func (sgrs *sGRPCService) GetWorkflowRun(ctx context.Context, req *pb.WorkflowRunReq) (*pb.WorkflowRun, error) {
+	wr, err := sgrs.scheduler.GetWorkflowRun(req.GetId())
+	if err != nil {
+		return &pb.WorkflowRun{}, err
+	}
+
+	return agscheduler.WorkflowRunToPbWorkflowRunPtr(wr), nil
+}
+
+func (sgrs *sGRPCService) GetAllWorkflowRuns(ctx context.Context, in *emptypb.Empty) (*pb.WorkflowRunsResp, error) {
+	wrs, err := sgrs.scheduler.GetAllWorkflowRuns()
+	if err != nil {
+		return &pb.WorkflowRunsResp{}, err
+	}
+
+	return &pb.WorkflowRunsResp{WorkflowRuns: agscheduler.WorkflowRunsToPbWorkflowRunsPtr(wrs)}, nil
+}
+
+func (sgrs *sGRPCService) AddCalendar(ctx context.Context, pbC *pb.Calendar) (*pb.Calendar, error) {
+	c, err := sgrs.scheduler.AddCalendar(agscheduler.PbCalendarPtrToCalendar(pbC))
+	if err != nil {
+		return &pb.Calendar{}, err
+	}
+
+	return agscheduler.CalendarToPbCalendarPtr(c), nil
+}
+
+func (sgrs *sGRPCService) GetCalendar(ctx context.Context, req *pb.CalendarReq) (*pb.Calendar, error) {
+	c, err := sgrs.scheduler.GetCalendar(req.GetName())
+	if err != nil {
+		return &pb.Calendar{}, err
+	}
+
+	return agscheduler.CalendarToPbCalendarPtr(c), nil
+}
+
+func (sgrs *sGRPCService) GetAllCalendars(ctx context.Context, in *emptypb.Empty) (*pb.CalendarsResp, error) {
+	cs, err := sgrs.scheduler.GetAllCalendars()
+	if err != nil {
+		return &pb.CalendarsResp{}, err
+	}
+
+	return &pb.CalendarsResp{Calendars: agscheduler.CalendarsToPbCalendarsPtr(cs)}, nil
+}
+
+func (sgrs *sGRPCService) DeleteCalendar(ctx context.Context, req *pb.CalendarReq) (*emptypb.Empty, error) {
+	err := sgrs.scheduler.DeleteCalendar(req.GetName())
+	return &emptypb.Empty{}, err
+}
+
 func (sgrs *sGRPCService) Start(ctx context.Context, in *emptypb.Empty) (*emptypb.Empty, error) {
 	sgrs.scheduler.Start()
 	return &emptypb.Empty{}, nil
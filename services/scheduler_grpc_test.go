@@ -63,6 +63,34 @@ func testSchedulerGRPC(t *testing.T, c pb.SchedulerClient) {
 	_, err = c.ScheduleJob(ctx, pbJ)
 	assert.NoError(t, err)
 
+	wsResp, err := c.GetAllWorkflows(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, wsResp.Workflows, 0)
+	_, err = c.GetAllWorkflowRuns(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	_, err = c.GetWorkflowRun(ctx, &pb.WorkflowRunReq{Id: "1"})
+	assert.Contains(t, err.Error(), agscheduler.WorkflowRunNotFoundError("1").Error())
+
+	_, err = c.CancelRun(ctx, &pb.RunReq{RecordId: 1})
+	assert.Contains(t, err.Error(), agscheduler.RunNotFoundError(1).Error())
+
+	pbC, err := c.AddCalendar(ctx, &pb.Calendar{
+		Name:     "holidays",
+		Excludes: []*pb.CalendarRange{{Start: "2023-12-25", End: "2023-12-26"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "holidays", pbC.GetName())
+	pbC, err = c.GetCalendar(ctx, &pb.CalendarReq{Name: "holidays"})
+	assert.NoError(t, err)
+	assert.Len(t, pbC.GetExcludes(), 1)
+	csResp, err := c.GetAllCalendars(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, csResp.Calendars, 1)
+	_, err = c.DeleteCalendar(ctx, &pb.CalendarReq{Name: "holidays"})
+	assert.NoError(t, err)
+	_, err = c.GetCalendar(ctx, &pb.CalendarReq{Name: "holidays"})
+	assert.Contains(t, err.Error(), agscheduler.CalendarNotFoundError("holidays").Error())
+
 	_, err = c.DeleteJob(ctx, &pb.JobReq{Id: j.Id})
 	assert.NoError(t, err)
 	_, err = c.GetJob(ctx, &pb.JobReq{Id: j.Id})
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/agscheduler/agscheduler"
@@ -104,6 +106,71 @@ func (shs *sHTTPService) scheduleJob(c *gin.Context) {
 	c.JSON(200, gin.H{"data": nil, "error": shs.handleErr(err)})
 }
 
+func (shs *sHTTPService) cancelRun(c *gin.Context) {
+	recordId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(400, gin.H{"data": nil, "error": shs.handleErr(err)})
+		return
+	}
+
+	err = shs.scheduler.CancelRun(recordId)
+	c.JSON(200, gin.H{"data": nil, "error": shs.handleErr(err)})
+}
+
+func (shs *sHTTPService) getAllWorkflows(c *gin.Context) {
+	ws, err := shs.scheduler.GetAllWorkflows()
+	c.JSON(200, gin.H{"data": ws, "error": shs.handleErr(err)})
+}
+
+func (shs *sHTTPService) getWorkflowRun(c *gin.Context) {
+	wr, err := shs.scheduler.GetWorkflowRun(c.Param("id"))
+	if err != nil {
+		c.JSON(200, gin.H{"data": nil, "error": shs.handleErr(err)})
+		return
+	}
+	c.JSON(200, gin.H{"data": wr, "error": ""})
+}
+
+func (shs *sHTTPService) getAllWorkflowRuns(c *gin.Context) {
+	wrs, err := shs.scheduler.GetAllWorkflowRuns()
+	c.JSON(200, gin.H{"data": wrs, "error": shs.handleErr(err)})
+}
+
+func (shs *sHTTPService) addCalendar(c *gin.Context) {
+	cal := agscheduler.Calendar{}
+	err := c.BindJSON(&cal)
+	if err != nil {
+		c.JSON(400, gin.H{"data": nil, "error": shs.handleErr(err)})
+		return
+	}
+
+	cal, err = shs.scheduler.AddCalendar(cal)
+	if err != nil {
+		c.JSON(200, gin.H{"data": nil, "error": shs.handleErr(err)})
+		return
+	}
+	c.JSON(200, gin.H{"data": cal, "error": ""})
+}
+
+func (shs *sHTTPService) getCalendar(c *gin.Context) {
+	cal, err := shs.scheduler.GetCalendar(c.Param("name"))
+	if err != nil {
+		c.JSON(200, gin.H{"data": nil, "error": shs.handleErr(err)})
+		return
+	}
+	c.JSON(200, gin.H{"data": cal, "error": ""})
+}
+
+func (shs *sHTTPService) getAllCalendars(c *gin.Context) {
+	cals, err := shs.scheduler.GetAllCalendars()
+	c.JSON(200, gin.H{"data": cals, "error": shs.handleErr(err)})
+}
+
+func (shs *sHTTPService) deleteCalendar(c *gin.Context) {
+	err := shs.scheduler.DeleteCalendar(c.Param("name"))
+	c.JSON(200, gin.H{"data": nil, "error": shs.handleErr(err)})
+}
+
 func (shs *sHTTPService) start(c *gin.Context) {
 	shs.scheduler.Start()
 	c.JSON(200, gin.H{"data": nil, "error": ""})
@@ -125,6 +192,14 @@ func (shs *sHTTPService) registerRoutes(r *gin.Engine) {
 	r.POST("/scheduler/job/:id/resume", shs.resumeJob)
 	r.POST("/scheduler/job/run", shs.runJob)
 	r.POST("/scheduler/job/schedule", shs.scheduleJob)
+	r.POST("/scheduler/run/:id/cancel", shs.cancelRun)
+	r.GET("/scheduler/workflows", shs.getAllWorkflows)
+	r.GET("/scheduler/workflow/run/:id", shs.getWorkflowRun)
+	r.GET("/scheduler/workflow/runs", shs.getAllWorkflowRuns)
+	r.POST("/scheduler/calendar", shs.addCalendar)
+	r.GET("/scheduler/calendar/:name", shs.getCalendar)
+	r.GET("/scheduler/calendars", shs.getAllCalendars)
+	r.DELETE("/scheduler/calendar/:name", shs.deleteCalendar)
 	r.POST("/scheduler/start", shs.start)
 	r.POST("/scheduler/stop", shs.stop)
 }
@@ -114,6 +114,77 @@ func testSchedulerHTTP(t *testing.T, baseUrl string) {
 	assert.NoError(t, err)
 	assert.Empty(t, rJ.Error)
 
+	resp, err = http.Get(baseUrl + "/scheduler/workflows")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	rJ = &result{}
+	err = json.Unmarshal(body, &rJ)
+	assert.NoError(t, err)
+	assert.Empty(t, rJ.Data)
+	assert.Empty(t, rJ.Error)
+
+	resp, err = http.Get(baseUrl + "/scheduler/workflow/runs")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, err = http.Get(baseUrl + "/scheduler/workflow/run/1")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	rJ = &result{}
+	err = json.Unmarshal(body, &rJ)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.WorkflowRunNotFoundError("1").Error(), rJ.Error)
+
+	resp, err = http.Post(baseUrl+"/scheduler/run/1/cancel", CONTENT_TYPE, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	rJ = &result{}
+	err = json.Unmarshal(body, &rJ)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.RunNotFoundError(1).Error(), rJ.Error)
+
+	bC := []byte(`{"name":"holidays","excludes":[{"start":"2023-12-25","end":"2023-12-26"}]}`)
+	resp, err = http.Post(baseUrl+"/scheduler/calendar", CONTENT_TYPE, bytes.NewReader(bC))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	rJ = &result{}
+	err = json.Unmarshal(body, &rJ)
+	assert.NoError(t, err)
+	assert.Empty(t, rJ.Error)
+
+	resp, err = http.Get(baseUrl + "/scheduler/calendars")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	rJs := &result{}
+	err = json.Unmarshal(body, &rJs)
+	assert.NoError(t, err)
+	assert.Len(t, rJs.Data, 1)
+
+	req, err = http.NewRequest(http.MethodDelete, baseUrl+"/scheduler/calendar/holidays", nil)
+	assert.NoError(t, err)
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	resp, err = http.Get(baseUrl + "/scheduler/calendar/holidays")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	rJ = &result{}
+	err = json.Unmarshal(body, &rJ)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.CalendarNotFoundError("holidays").Error(), rJ.Error)
+
 	req, err = http.NewRequest(http.MethodDelete, baseUrl+"/scheduler/job"+"/"+id, nil)
 	assert.NoError(t, err)
 	resp, err = client.Do(req)
@@ -139,7 +210,7 @@ func testSchedulerHTTP(t *testing.T, baseUrl string) {
 	assert.Equal(t, 200, resp.StatusCode)
 	body, err = io.ReadAll(resp.Body)
 	assert.NoError(t, err)
-	rJs := &result{}
+	rJs = &result{}
 	err = json.Unmarshal(body, &rJs)
 	assert.NoError(t, err)
 	assert.Empty(t, rJs.Data)
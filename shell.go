@@ -0,0 +1,172 @@
+package agscheduler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// The keys of `Args` used by `RunShell`.
+const (
+	// Required, the command and its arguments.
+	// def: []string
+	SHELL_ARG_ARGV = "argv"
+	// Optional, the environment variables added to those of the scheduler process.
+	// def: map[string]string
+	SHELL_ARG_ENV = "env"
+	// Optional, the working directory of the command.
+	// def: string
+	SHELL_ARG_DIR = "dir"
+	// Optional, the standard input of the command.
+	// def: string
+	SHELL_ARG_STDIN = "stdin"
+)
+
+// Maximum number of bytes of stdout and stderr kept in the result, the rest is dropped.
+const shellOutputMax = 64 << 10
+
+// How long to wait for the output pipes after the process group is killed.
+const shellWaitDelay = 5 * time.Second
+
+// The result of `RunShell`, it is saved as JSON in `Record.Result`.
+type ShellResult struct {
+	ExitCode int    `json:"exit_code"`
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	// Set when the command cannot be started or is killed.
+	Error string `json:"error,omitempty"`
+}
+
+var shellFuncPkg = FuncPkg{
+	TypedFunc: RunShell,
+	Info:      "Run the command of Args `argv`, with optional `env`, `dir` and `stdin`.",
+}
+
+func init() {
+	RegisterFuncs(shellFuncPkg)
+}
+
+// Built-in `TypedFunc` that runs an external command, registered as `github.com/agscheduler/agscheduler.RunShell`
+// out of the box, call `UnregisterBuiltinFuncs` to opt out.
+// The process group of the command is killed when the job times out or the run is cancelled.
+// The stdout, stderr and exit code are returned as `ShellResult`,
+// when the exit code is not 0, it returns a `ShellError` with the result, so the run is recorded as `RECORD_STATUS_ERROR`.
+func RunShell(ctx context.Context, j Job) (any, error) {
+	argv, err := argStrings(j.Args[SHELL_ARG_ARGV])
+	if err != nil || len(argv) == 0 {
+		return nil, &NonRetryableError{Err: fmt.Errorf("job `%s` Args `%s` must be a non-empty list of strings", j.FullName(), SHELL_ARG_ARGV)}
+	}
+	env, err := argStringMap(j.Args[SHELL_ARG_ENV])
+	if err != nil {
+		return nil, &NonRetryableError{Err: fmt.Errorf("job `%s` Args `%s` error: %s", j.FullName(), SHELL_ARG_ENV, err)}
+	}
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	if dir, ok := j.Args[SHELL_ARG_DIR].(string); ok {
+		cmd.Dir = dir
+	}
+	if stdin, ok := j.Args[SHELL_ARG_STDIN].(string); ok {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	stdout := &shellOutput{}
+	stderr := &shellOutput{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	setShellProcessGroup(cmd)
+	cmd.WaitDelay = shellWaitDelay
+
+	r := ShellResult{}
+	err = cmd.Run()
+	r.Stdout = stdout.String()
+	r.Stderr = stderr.String()
+	r.ExitCode = cmd.ProcessState.ExitCode()
+	var exitErr *exec.ExitError
+	if ctx.Err() != nil {
+		r.Error = ctx.Err().Error()
+	} else if err != nil && !errors.As(err, &exitErr) {
+		r.Error = err.Error()
+	}
+
+	if err != nil {
+		return r, &ShellError{Result: r}
+	}
+
+	return r, nil
+}
+
+// Values of `Args` decoded from JSON are `[]any` and `map[string]any`.
+func argStrings(v any) ([]string, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return v, nil
+	case []any:
+		ss := make([]string, 0, len(v))
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("`%v` is not a string", e)
+			}
+			ss = append(ss, s)
+		}
+		return ss, nil
+	default:
+		return nil, fmt.Errorf("`%v` is not a list", v)
+	}
+}
+
+func argStringMap(v any) (map[string]string, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]string:
+		return v, nil
+	case map[string]any:
+		m := make(map[string]string, len(v))
+		for k, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("`%s` is not a string", k)
+			}
+			m[k] = s
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("`%v` is not a map", v)
+	}
+}
+
+// Keep the first `shellOutputMax` bytes written.
+type shellOutput struct {
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (o *shellOutput) Write(p []byte) (int, error) {
+	if n := shellOutputMax - o.buf.Len(); n < len(p) {
+		o.truncated = true
+		o.buf.Write(p[:max(n, 0)])
+	} else {
+		o.buf.Write(p)
+	}
+
+	return len(p), nil
+}
+
+func (o *shellOutput) String() string {
+	if o.truncated {
+		return o.buf.String() + "...(truncated)"
+	}
+
+	return o.buf.String()
+}
@@ -0,0 +1,87 @@
+package agscheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runShellErrorResult(t *testing.T, ctx context.Context, j Job) ShellResult {
+	_, err := RunShell(ctx, j)
+	var shellErr *ShellError
+	assert.ErrorAs(t, err, &shellErr)
+
+	return shellErr.Result
+}
+
+func TestRunShell(t *testing.T) {
+	dir := t.TempDir()
+	j := Job{Args: map[string]any{
+		SHELL_ARG_ARGV:  []any{"sh", "-c", "cat; echo $SHELL_TEST; pwd; echo err >&2"},
+		SHELL_ARG_ENV:   map[string]any{"SHELL_TEST": "env"},
+		SHELL_ARG_DIR:   dir,
+		SHELL_ARG_STDIN: "in\n",
+	}}
+
+	result, err := RunShell(context.Background(), j)
+	assert.NoError(t, err)
+
+	r := result.(ShellResult)
+	assert.Equal(t, 0, r.ExitCode)
+	assert.Equal(t, "in\nenv\n"+dir+"\n", r.Stdout)
+	assert.Equal(t, "err\n", r.Stderr)
+	assert.Empty(t, r.Error)
+}
+
+func TestRunShellExitCode(t *testing.T) {
+	j := Job{Args: map[string]any{SHELL_ARG_ARGV: []string{"sh", "-c", "echo out; exit 3"}}}
+
+	r := runShellErrorResult(t, context.Background(), j)
+
+	assert.Equal(t, 3, r.ExitCode)
+	assert.Equal(t, "out\n", r.Stdout)
+	assert.Empty(t, r.Error)
+}
+
+func TestRunShellTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	j := Job{Args: map[string]any{SHELL_ARG_ARGV: []string{"sh", "-c", "sleep 10 & sleep 10; wait"}}}
+
+	start := time.Now()
+	r := runShellErrorResult(t, ctx, j)
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Equal(t, -1, r.ExitCode)
+	assert.Equal(t, context.DeadlineExceeded.Error(), r.Error)
+}
+
+func TestRunShellOutputTruncated(t *testing.T) {
+	j := Job{Args: map[string]any{SHELL_ARG_ARGV: []string{"head", "-c", fmt.Sprintf("%d", shellOutputMax+1), "/dev/zero"}}}
+
+	result, err := RunShell(context.Background(), j)
+	assert.NoError(t, err)
+	r := result.(ShellResult)
+	assert.True(t, strings.HasSuffix(r.Stdout, "...(truncated)"))
+	assert.Len(t, r.Stdout, shellOutputMax+len("...(truncated)"))
+}
+
+func TestRunShellError(t *testing.T) {
+	for _, args := range []map[string]any{
+		{},
+		{SHELL_ARG_ARGV: []any{"sh", 1}},
+		{SHELL_ARG_ARGV: []string{"sh"}, SHELL_ARG_ENV: "env"},
+	} {
+		_, err := RunShell(context.Background(), Job{Args: args})
+		var nonRetryableErr *NonRetryableError
+		assert.ErrorAs(t, err, &nonRetryableErr)
+	}
+
+	r := runShellErrorResult(t, context.Background(), Job{Args: map[string]any{SHELL_ARG_ARGV: []string{"/not/exist"}}})
+	assert.Equal(t, -1, r.ExitCode)
+	assert.NotEmpty(t, r.Error)
+}
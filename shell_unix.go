@@ -0,0 +1,17 @@
+//go:build !windows
+
+package agscheduler
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// Run the command in its own process group and kill the whole group when the context is done,
+// so that the child processes of the command are killed too.
+func setShellProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
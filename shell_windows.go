@@ -0,0 +1,10 @@
+//go:build windows
+
+package agscheduler
+
+import (
+	"os/exec"
+)
+
+// Process groups are not supported, only the command process is killed when the context is done.
+func setShellProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,104 @@
+package agscheduler
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// The layout of `Job.SLA`.
+const slaLayout = "15:04"
+
+// Number of records fetched per page when looking for a successful run.
+const slaRecordsPageSize = 100
+
+// Return the last deadline not after `now` and the next one after it.
+func (j Job) slaDeadlines(now time.Time) (time.Time, time.Time) {
+	timezone, _ := time.LoadLocation(j.Timezone)
+	t, _ := time.Parse(slaLayout, j.SLA)
+
+	nowL := now.In(timezone)
+	deadline := time.Date(nowL.Year(), nowL.Month(), nowL.Day(), t.Hour(), t.Minute(), 0, 0, timezone)
+	if deadline.After(nowL) {
+		deadline = deadline.AddDate(0, 0, -1)
+	}
+
+	return deadline.UTC(), deadline.AddDate(0, 0, 1).UTC()
+}
+
+// Return the interval to the next SLA deadline of the jobs, 0 if there is no SLA.
+func nextSLAInterval(js []Job, now time.Time) time.Duration {
+	var nextDeadlineMin time.Time
+	for _, j := range js {
+		if j.SLA == "" || j.Status == JOB_STATUS_PAUSED {
+			continue
+		}
+
+		_, nextDeadline := j.slaDeadlines(now)
+		if nextDeadlineMin.IsZero() || nextDeadline.Before(nextDeadlineMin) {
+			nextDeadlineMin = nextDeadline
+		}
+	}
+
+	if nextDeadlineMin.IsZero() {
+		return 0
+	}
+	return nextDeadlineMin.Sub(now)
+}
+
+// Called by the scheduler loop on the main node in a new goroutine, so the store is not locked meanwhile.
+// Check the SLA deadlines of the jobs passed since the last check, only the most recent one of each job,
+// so the deadlines passed before the scheduler starts, or while it is stopped or not the main node, are also checked.
+// It returns at once if another check is running, the deadlines are checked by the next one.
+func (s *Scheduler) checkSLAs(js []Job, now time.Time) {
+	if !s.slaM.TryLock() {
+		return
+	}
+	defer s.slaM.Unlock()
+
+	for _, j := range js {
+		if j.SLA == "" || j.Status == JOB_STATUS_PAUSED {
+			continue
+		}
+
+		deadline, _ := j.slaDeadlines(now)
+		if !deadline.After(s.slaCheckedAt) {
+			continue
+		}
+
+		if !s.HasRecorder() {
+			slog.Warn(fmt.Sprintf("Job `%s` SLA `%s` cannot be checked without recorder", j.FullName(), j.SLA))
+			continue
+		}
+		ok, err := s.hasCompletedRun(j, deadline.Add(-24*time.Hour), deadline)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Job `%s` check SLA error: %s", j.FullName(), err))
+			continue
+		}
+		if !ok {
+			slog.Warn(fmt.Sprintf("Job `%s` SLA `%s` missed, no successful run recorded by `%s`", j.FullName(), j.SLA, deadline))
+			s.dispatchEvent(EventPkg{EVENT_JOB_SLA_MISSED, j.Id, deadline})
+		}
+	}
+	s.slaCheckedAt = now
+}
+
+// Whether a run of the job is completed between `start` and `end`.
+func (s *Scheduler) hasCompletedRun(j Job, start, end time.Time) (bool, error) {
+	for page := 1; ; page++ {
+		rs, _, err := s.recorder.GetRecords(j.Id, page, slaRecordsPageSize)
+		if err != nil {
+			return false, err
+		}
+
+		for _, r := range rs {
+			if r.Status == RECORD_STATUS_COMPLETED && !r.EndAt.Before(start) && !r.EndAt.After(end) {
+				return true, nil
+			}
+		}
+		// Records are sorted by `StartAt` in descending order.
+		if len(rs) < slaRecordsPageSize || rs[len(rs)-1].StartAt.Before(start) {
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package agscheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type slaBackend struct {
+	Backend
+	records []Record
+}
+
+func (b *slaBackend) Init() error { return nil }
+
+func (b *slaBackend) GetRecords(jId string, page, pageSize int) ([]Record, int64, error) {
+	if page > 1 {
+		return []Record{}, int64(len(b.records)), nil
+	}
+	return b.records, int64(len(b.records)), nil
+}
+
+func TestJobSLADeadlines(t *testing.T) {
+	j := Job{SLA: "06:30", Timezone: "Asia/Shanghai"}
+
+	deadline, nextDeadline := j.slaDeadlines(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 10, 17, 22, 30, 0, 0, time.UTC), deadline)
+	assert.Equal(t, time.Date(2026, 10, 18, 22, 30, 0, 0, time.UTC), nextDeadline)
+
+	deadline, _ = j.slaDeadlines(time.Date(2026, 10, 17, 22, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 10, 17, 22, 30, 0, 0, time.UTC), deadline)
+}
+
+func TestSchedulerCheckSLAs(t *testing.T) {
+	missed := make(chan EventPkg, 1)
+	backend := &slaBackend{}
+	s := &Scheduler{}
+	err := s.SetRecorder(&Recorder{Backend: backend})
+	assert.NoError(t, err)
+	err = s.SetListener(&Listener{
+		Callbacks: []CallbackPkg{{Callback: func(ep EventPkg) { missed <- ep }, Event: EVENT_JOB_SLA_MISSED}},
+	})
+	assert.NoError(t, err)
+
+	j := Job{Id: "1", Name: "Job", SLA: "06:30", Timezone: "UTC", Status: JOB_STATUS_RUNNING}
+	deadline := time.Date(2026, 10, 18, 6, 30, 0, 0, time.UTC)
+	now := deadline.Add(time.Minute)
+
+	assert.Equal(t, 24*time.Hour-time.Minute, nextSLAInterval([]Job{j}, now))
+	assert.Equal(t, time.Duration(0), nextSLAInterval([]Job{{Id: "2"}}, now))
+
+	// The most recent deadline is checked when the scheduler has not checked yet.
+	s.checkSLAs([]Job{j}, now)
+	select {
+	case ep := <-missed:
+		assert.Equal(t, j.Id, ep.JobId)
+		assert.Equal(t, deadline, ep.Data)
+	case <-time.After(time.Second):
+		assert.Fail(t, "SLA missed event not dispatched")
+	}
+
+	// Each deadline is checked once.
+	s.checkSLAs([]Job{j}, now.Add(time.Minute))
+
+	backend.records = []Record{{JobId: j.Id, Status: RECORD_STATUS_COMPLETED, StartAt: deadline.Add(-time.Hour), EndAt: deadline.Add(-30 * time.Minute)}}
+	s.slaCheckedAt = deadline.Add(-time.Minute)
+	s.checkSLAs([]Job{j}, now)
+	select {
+	case <-missed:
+		assert.Fail(t, "SLA missed event dispatched")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
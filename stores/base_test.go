@@ -3,6 +3,7 @@ package stores
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -64,6 +65,22 @@ func runTest(t *testing.T, sto agscheduler.Store) {
 	err = s.RunJob(j)
 	assert.NoError(t, err)
 
+	c, err := s.AddCalendar(agscheduler.Calendar{
+		Name:     "holidays",
+		Excludes: []agscheduler.CalendarRange{{Start: "2023-12-25", End: "2023-12-26"}},
+	})
+	assert.NoError(t, err)
+	c, err = s.GetCalendar(c.Name)
+	assert.NoError(t, err)
+	assert.Len(t, c.Excludes, 1)
+	cs, err := s.GetAllCalendars()
+	assert.NoError(t, err)
+	assert.Len(t, cs, 1)
+	err = s.DeleteCalendar(c.Name)
+	assert.NoError(t, err)
+	_, err = s.GetCalendar(c.Name)
+	assert.ErrorIs(t, err, agscheduler.CalendarNotFoundError(c.Name))
+
 	err = s.DeleteJob(j.Id)
 	assert.NoError(t, err)
 	_, err = s.GetJob(j.Id)
@@ -80,3 +97,100 @@ func runTest(t *testing.T, sto agscheduler.Store) {
 	err = sto.Clear()
 	assert.NoError(t, err)
 }
+
+func runLeaseTest(t *testing.T, ls agscheduler.LeaseStore) {
+	jId := "lease_test"
+
+	ok, err := ls.AcquireLease(jId, "1", 2, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = ls.AcquireLease(jId, "2", 2, 2*time.Second)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = ls.AcquireLease(jId, "3", 2, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	err = ls.RenewLease(jId, "1", time.Minute)
+	assert.NoError(t, err)
+	err = ls.ReleaseLease(jId, "1")
+	assert.NoError(t, err)
+	ok, err = ls.AcquireLease(jId, "3", 2, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// The expired lease is released.
+	time.Sleep(3 * time.Second)
+	ok, err = ls.AcquireLease(jId, "4", 2, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// All the held leases count when the limit is lowered.
+	err = ls.ReleaseLease(jId, "3")
+	assert.NoError(t, err)
+	ok, err = ls.AcquireLease(jId, "5", 1, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	err = ls.ReleaseLease(jId, "4")
+	assert.NoError(t, err)
+}
+
+func runRateLimitTest(t *testing.T, rs agscheduler.RateLimitStore) {
+	name := "rate_limit_test"
+
+	for range 2 {
+		wait, err := rs.TakeToken(name, 2, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+	}
+	wait, err := rs.TakeToken(name, 2, time.Second)
+	assert.NoError(t, err)
+	assert.Greater(t, wait, time.Duration(0))
+	assert.LessOrEqual(t, wait, time.Second)
+
+	// A token is added after the interval.
+	time.Sleep(wait + 100*time.Millisecond)
+	wait, err = rs.TakeToken(name, 2, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+}
+
+func runWorkflowRunTest(t *testing.T, ws agscheduler.WorkflowRunStore) {
+	wr := agscheduler.WorkflowRun{
+		Id:         "workflow_run_test",
+		RootJobIds: []string{"1"},
+		Status:     agscheduler.WORKFLOW_RUN_STATUS_RUNNING,
+		Steps:      map[string]agscheduler.WorkflowRunStep{"1": {JobId: "1", Status: agscheduler.RECORD_STATUS_RUNNING}},
+		StartAt:    time.Now().UTC(),
+	}
+
+	err := ws.AddWorkflowRun(wr)
+	assert.NoError(t, err)
+	wr, err = ws.GetWorkflowRun(wr.Id)
+	assert.NoError(t, err)
+	assert.Len(t, wr.Steps, 1)
+	wrs, err := ws.GetAllWorkflowRuns()
+	assert.NoError(t, err)
+	assert.Len(t, wrs, 1)
+
+	wr.Status = agscheduler.WORKFLOW_RUN_STATUS_COMPLETED
+	wr.Version++
+	ok, err := ws.UpdateWorkflowRun(wr)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	// The workflow run has been updated since the version.
+	ok, err = ws.UpdateWorkflowRun(wr)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	wr, err = ws.GetWorkflowRun(wr.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, agscheduler.WORKFLOW_RUN_STATUS_COMPLETED, wr.Status)
+
+	err = ws.DeleteWorkflowRun(wr.Id)
+	assert.NoError(t, err)
+	_, err = ws.GetWorkflowRun(wr.Id)
+	assert.ErrorIs(t, err, agscheduler.WorkflowRunNotFoundError(wr.Id))
+	_, err = ws.UpdateWorkflowRun(wr)
+	assert.ErrorIs(t, err, agscheduler.WorkflowRunNotFoundError(wr.Id))
+}
@@ -15,13 +15,15 @@ import (
 )
 
 const (
-	ES_INDEX = "agscheduler_jobs"
+	ES_INDEX          = "agscheduler_jobs"
+	ES_CALENDAR_INDEX = "agscheduler_calendars"
 )
 
 // Stores jobs in a Elasticsearch database.
 type ElasticsearchStore struct {
-	TClient *es8.TypedClient
-	Index   string
+	TClient       *es8.TypedClient
+	Index         string
+	CalendarIndex string
 }
 
 type doc struct {
@@ -29,6 +31,10 @@ type doc struct {
 	Data        []byte `json:"data"`
 }
 
+type calendarDoc struct {
+	Data []byte `json:"data"`
+}
+
 func (s *ElasticsearchStore) Name() string {
 	return "Elasticsearch"
 }
@@ -37,15 +43,20 @@ func (s *ElasticsearchStore) Init() error {
 	if s.Index == "" {
 		s.Index = ES_INDEX
 	}
-
-	exists, err := s.TClient.Indices.Exists(s.Index).Do(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to check index exist: %s", err)
+	if s.CalendarIndex == "" {
+		s.CalendarIndex = ES_CALENDAR_INDEX
 	}
-	if !exists {
-		_, err := s.TClient.Indices.Create(s.Index).Do(ctx)
+
+	for _, index := range []string{s.Index, s.CalendarIndex} {
+		exists, err := s.TClient.Indices.Exists(index).Do(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to create index: %s", err)
+			return fmt.Errorf("failed to check index exist: %s", err)
+		}
+		if !exists {
+			_, err := s.TClient.Indices.Create(index).Do(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create index: %s", err)
+			}
 		}
 	}
 
@@ -172,7 +183,74 @@ func (s *ElasticsearchStore) GetNextRunTime() (time.Time, error) {
 	return nextRunTimeMin, nil
 }
 
+func (s *ElasticsearchStore) AddCalendar(c agscheduler.Calendar) error {
+	bC, err := agscheduler.CalendarMarshal(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.TClient.Index(s.CalendarIndex).Id(c.Name).Request(
+		calendarDoc{bC},
+	).Refresh(refresh.True).Do(ctx)
+
+	return err
+}
+
+func (s *ElasticsearchStore) GetCalendar(name string) (agscheduler.Calendar, error) {
+	resp, err := s.TClient.Get(s.CalendarIndex, name).Do(ctx)
+	if err != nil {
+		return agscheduler.Calendar{}, err
+	}
+	if !resp.Found {
+		return agscheduler.Calendar{}, agscheduler.CalendarNotFoundError(name)
+	}
+
+	var d calendarDoc
+	err = json.Unmarshal(resp.Source_, &d)
+	if err != nil {
+		return agscheduler.Calendar{}, err
+	}
+
+	return agscheduler.CalendarUnmarshal(d.Data)
+}
+
+func (s *ElasticsearchStore) GetAllCalendars() ([]agscheduler.Calendar, error) {
+	resp, err := s.TClient.Search().Index(s.CalendarIndex).Request(
+		&search.Request{
+			Query: &types.Query{MatchAll: &types.MatchAllQuery{}},
+		},
+	).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarList := []agscheduler.Calendar{}
+	for _, h := range resp.Hits.Hits {
+		var d calendarDoc
+		err = json.Unmarshal(h.Source_, &d)
+		if err != nil {
+			return nil, err
+		}
+		c, err := agscheduler.CalendarUnmarshal(d.Data)
+		if err != nil {
+			return nil, err
+		}
+		calendarList = append(calendarList, c)
+	}
+
+	return calendarList, nil
+}
+
+func (s *ElasticsearchStore) DeleteCalendar(name string) error {
+	_, err := s.TClient.Delete(s.CalendarIndex, name).Refresh(refresh.True).Do(ctx)
+	return err
+}
+
 func (s *ElasticsearchStore) Clear() error {
+	if _, err := s.TClient.Indices.Delete(s.CalendarIndex).Do(ctx); err != nil {
+		return err
+	}
+
 	_, err := s.TClient.Indices.Delete(s.Index).Do(ctx)
 	return err
 }
@@ -1,8 +1,11 @@
 package stores
 
 import (
+	"encoding/json"
+	"fmt"
 	"path"
 	"strconv"
+	"sync"
 	"time"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -13,13 +16,27 @@ import (
 const (
 	ETCD_JOBS_PATH      = "/agscheduler/jobs"
 	ETCD_RUN_TIMES_PATH = "/agscheduler/run_times"
+	ETCD_CALENDARS_PATH = "/agscheduler/calendars"
+	ETCD_LEASES_PATH    = "/agscheduler/leases"
+	ETCD_RATE_PATH      = "/agscheduler/rate_limiters"
+	ETCD_WORKFLOW_PATH  = "/agscheduler/workflow_runs"
 )
 
 // Stores jobs in a etcd.
 type EtcdStore struct {
-	Cli          *clientv3.Client
-	JobsPath     string
-	RunTimesPath string
+	Cli           *clientv3.Client
+	JobsPath      string
+	RunTimesPath  string
+	CalendarsPath string
+	// The leases of each job are stored in `Job.MaxInstances` slots under this path,
+	// each bound to an etcd lease.
+	LeasesPath string
+	// The token bucket of each rate limiter is stored under this path.
+	RatePath     string
+	WorkflowPath string
+
+	// etcd lease id keyed by lease id.
+	leaseIds sync.Map
 }
 
 func (s *EtcdStore) Name() string {
@@ -33,6 +50,18 @@ func (s *EtcdStore) Init() error {
 	if s.RunTimesPath == "" {
 		s.RunTimesPath = ETCD_RUN_TIMES_PATH
 	}
+	if s.CalendarsPath == "" {
+		s.CalendarsPath = ETCD_CALENDARS_PATH
+	}
+	if s.LeasesPath == "" {
+		s.LeasesPath = ETCD_LEASES_PATH
+	}
+	if s.RatePath == "" {
+		s.RatePath = ETCD_RATE_PATH
+	}
+	if s.WorkflowPath == "" {
+		s.WorkflowPath = ETCD_WORKFLOW_PATH
+	}
 
 	return nil
 }
@@ -156,6 +185,233 @@ func (s *EtcdStore) GetNextRunTime() (time.Time, error) {
 	return nextRunTimeMin, nil
 }
 
+func (s *EtcdStore) AddCalendar(c agscheduler.Calendar) error {
+	bC, err := agscheduler.CalendarMarshal(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Cli.Put(ctx, path.Join(s.CalendarsPath, c.Name), string(bC))
+	return err
+}
+
+func (s *EtcdStore) GetCalendar(name string) (agscheduler.Calendar, error) {
+	resp, err := s.Cli.Get(ctx, path.Join(s.CalendarsPath, name))
+	if err != nil {
+		return agscheduler.Calendar{}, err
+	}
+	if len(resp.Kvs) == 0 {
+		return agscheduler.Calendar{}, agscheduler.CalendarNotFoundError(name)
+	}
+
+	return agscheduler.CalendarUnmarshal(resp.Kvs[0].Value)
+}
+
+func (s *EtcdStore) GetAllCalendars() ([]agscheduler.Calendar, error) {
+	resp, err := s.Cli.Get(ctx, s.CalendarsPath+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	calendarList := []agscheduler.Calendar{}
+	for _, kv := range resp.Kvs {
+		c, err := agscheduler.CalendarUnmarshal(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		calendarList = append(calendarList, c)
+	}
+
+	return calendarList, nil
+}
+
+func (s *EtcdStore) DeleteCalendar(name string) error {
+	_, err := s.Cli.Delete(ctx, path.Join(s.CalendarsPath, name))
+	return err
+}
+
+func (s *EtcdStore) AcquireLease(jId, leaseId string, limit int, ttl time.Duration) (bool, error) {
+	lResp, err := s.Cli.Grant(ctx, int64(max(1, ttl/time.Second)))
+	if err != nil {
+		return false, err
+	}
+
+	// The held leases are counted, and the lease is put only if none is put meanwhile.
+	jPath := path.Join(s.LeasesPath, jId) + "/"
+	for {
+		gResp, err := s.Cli.Get(ctx, jPath, clientv3.WithPrefix(), clientv3.WithCountOnly())
+		if err != nil {
+			_, _ = s.Cli.Revoke(ctx, lResp.ID)
+			return false, err
+		}
+		if gResp.Count >= int64(limit) {
+			_, err = s.Cli.Revoke(ctx, lResp.ID)
+			return false, err
+		}
+
+		tResp, err := s.Cli.Txn(ctx).If(
+			clientv3.Compare(clientv3.ModRevision(jPath), "<", gResp.Header.Revision+1).WithPrefix(),
+		).Then(
+			clientv3.OpPut(jPath+leaseId, leaseId, clientv3.WithLease(lResp.ID)),
+		).Commit()
+		if err != nil {
+			_, _ = s.Cli.Revoke(ctx, lResp.ID)
+			return false, err
+		}
+		if tResp.Succeeded {
+			s.leaseIds.Store(leaseId, lResp.ID)
+			return true, nil
+		}
+	}
+}
+
+// The etcd lease is extended by the TTL it was granted with.
+func (s *EtcdStore) RenewLease(jId, leaseId string, ttl time.Duration) error {
+	id, ok := s.leaseIds.Load(leaseId)
+	if !ok {
+		return fmt.Errorf("lease `%s` of jobId `%s` not found", leaseId, jId)
+	}
+
+	_, err := s.Cli.KeepAliveOnce(ctx, id.(clientv3.LeaseID))
+	return err
+}
+
+func (s *EtcdStore) ReleaseLease(jId, leaseId string) error {
+	id, ok := s.leaseIds.LoadAndDelete(leaseId)
+	if !ok {
+		return nil
+	}
+
+	_, err := s.Cli.Revoke(ctx, id.(clientv3.LeaseID))
+	return err
+}
+
+// The bucket is updated in a transaction, which is retried if the bucket is changed meanwhile.
+func (s *EtcdStore) TakeToken(name string, burst int, interval time.Duration) (time.Duration, error) {
+	rPath := path.Join(s.RatePath, name)
+
+	for {
+		resp, err := s.Cli.Get(ctx, rPath)
+		if err != nil {
+			return 0, err
+		}
+
+		var b agscheduler.RateBucket
+		var modRevision int64
+		if len(resp.Kvs) > 0 {
+			if err := json.Unmarshal(resp.Kvs[0].Value, &b); err != nil {
+				return 0, err
+			}
+			modRevision = resp.Kvs[0].ModRevision
+		}
+		wait := b.Take(burst, interval, time.Now().UTC())
+		bB, err := json.Marshal(b)
+		if err != nil {
+			return 0, err
+		}
+
+		tResp, err := s.Cli.Txn(ctx).If(clientv3.Compare(clientv3.ModRevision(rPath), "=", modRevision)).Then(
+			clientv3.OpPut(rPath, string(bB)),
+		).Commit()
+		if err != nil {
+			return 0, err
+		}
+		if tResp.Succeeded {
+			return wait, nil
+		}
+	}
+}
+
+func (s *EtcdStore) AddWorkflowRun(wr agscheduler.WorkflowRun) error {
+	bWr, err := agscheduler.WorkflowRunMarshal(wr)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Cli.Put(ctx, path.Join(s.WorkflowPath, wr.Id), string(bWr))
+	return err
+}
+
+func (s *EtcdStore) GetWorkflowRun(id string) (agscheduler.WorkflowRun, error) {
+	resp, err := s.Cli.Get(ctx, path.Join(s.WorkflowPath, id))
+	if err != nil {
+		return agscheduler.WorkflowRun{}, err
+	}
+	if len(resp.Kvs) == 0 {
+		return agscheduler.WorkflowRun{}, agscheduler.WorkflowRunNotFoundError(id)
+	}
+
+	return agscheduler.WorkflowRunUnmarshal(resp.Kvs[0].Value)
+}
+
+func (s *EtcdStore) GetAllWorkflowRuns() ([]agscheduler.WorkflowRun, error) {
+	resp, err := s.Cli.Get(ctx, s.WorkflowPath+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	workflowRunList := []agscheduler.WorkflowRun{}
+	for _, kv := range resp.Kvs {
+		wr, err := agscheduler.WorkflowRunUnmarshal(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		workflowRunList = append(workflowRunList, wr)
+	}
+
+	return workflowRunList, nil
+}
+
+// The version is compared in a transaction, which fails if the workflow run is changed meanwhile.
+func (s *EtcdStore) UpdateWorkflowRun(wr agscheduler.WorkflowRun) (bool, error) {
+	wPath := path.Join(s.WorkflowPath, wr.Id)
+	resp, err := s.Cli.Get(ctx, wPath)
+	if err != nil {
+		return false, err
+	}
+	if len(resp.Kvs) == 0 {
+		return false, agscheduler.WorkflowRunNotFoundError(wr.Id)
+	}
+	sWr, err := agscheduler.WorkflowRunUnmarshal(resp.Kvs[0].Value)
+	if err != nil {
+		return false, err
+	}
+	if sWr.Version != wr.Version-1 {
+		return false, nil
+	}
+
+	bWr, err := agscheduler.WorkflowRunMarshal(wr)
+	if err != nil {
+		return false, err
+	}
+	tResp, err := s.Cli.Txn(ctx).If(clientv3.Compare(clientv3.ModRevision(wPath), "=", resp.Kvs[0].ModRevision)).Then(
+		clientv3.OpPut(wPath, string(bWr)),
+	).Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return tResp.Succeeded, nil
+}
+
+func (s *EtcdStore) DeleteWorkflowRun(id string) error {
+	_, err := s.Cli.Delete(ctx, path.Join(s.WorkflowPath, id))
+	return err
+}
+
 func (s *EtcdStore) Clear() error {
+	if _, err := s.Cli.Delete(ctx, s.CalendarsPath+"/", clientv3.WithPrefix()); err != nil {
+		return err
+	}
+	if _, err := s.Cli.Delete(ctx, s.LeasesPath+"/", clientv3.WithPrefix()); err != nil {
+		return err
+	}
+	if _, err := s.Cli.Delete(ctx, s.RatePath+"/", clientv3.WithPrefix()); err != nil {
+		return err
+	}
+	if _, err := s.Cli.Delete(ctx, s.WorkflowPath+"/", clientv3.WithPrefix()); err != nil {
+		return err
+	}
+
 	return s.DeleteAllJobs()
 }
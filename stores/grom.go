@@ -5,11 +5,17 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/agscheduler/agscheduler"
 )
 
-const GORM_TABLE_NAME = "jobs"
+const (
+	GORM_TABLE_NAME          = "jobs"
+	GORM_CALENDAR_TABLE_NAME = "calendars"
+	GORM_LEASE_TABLE_NAME    = "leases"
+	GORM_WORKFLOW_TABLE_NAME = "workflow_runs"
+)
 
 // GORM table
 type Jobs struct {
@@ -18,11 +24,37 @@ type Jobs struct {
 	Data        []byte    `gorm:"type:bytes;not null"`
 }
 
+// GORM table
+type Calendars struct {
+	Name string `gorm:"size:255;primaryKey"`
+	Data []byte `gorm:"type:bytes;not null"`
+}
+
+// GORM table
+//
+// The leases of each job are stored in `Job.MaxInstances` slots.
+type Leases struct {
+	JobId    string    `gorm:"size:64;primaryKey"`
+	Slot     int       `gorm:"primaryKey;autoIncrement:false"`
+	LeaseId  string    `gorm:"size:64;uniqueIndex"`
+	ExpireAt time.Time `gorm:"index"`
+}
+
+// GORM table
+type WorkflowRuns struct {
+	ID      string `gorm:"size:64;primaryKey"`
+	Version int
+	Data    []byte `gorm:"type:bytes;not null"`
+}
+
 // Stores jobs in a database table using GORM.
 // The table will be created if it doesn't exist in the database.
 type GormStore struct {
-	DB        *gorm.DB
-	TableName string
+	DB                *gorm.DB
+	TableName         string
+	CalendarTableName string
+	LeaseTableName    string
+	WorkflowTableName string
 }
 
 func (s *GormStore) Name() string {
@@ -34,9 +66,30 @@ func (s *GormStore) Init() error {
 		s.TableName = GORM_TABLE_NAME
 	}
 
+	if s.CalendarTableName == "" {
+		s.CalendarTableName = GORM_CALENDAR_TABLE_NAME
+	}
+
+	if s.LeaseTableName == "" {
+		s.LeaseTableName = GORM_LEASE_TABLE_NAME
+	}
+
+	if s.WorkflowTableName == "" {
+		s.WorkflowTableName = GORM_WORKFLOW_TABLE_NAME
+	}
+
 	if err := s.DB.Table(s.TableName).AutoMigrate(&Jobs{}); err != nil {
 		return fmt.Errorf("failed to create table: %s", err)
 	}
+	if err := s.DB.Table(s.CalendarTableName).AutoMigrate(&Calendars{}); err != nil {
+		return fmt.Errorf("failed to create table: %s", err)
+	}
+	if err := s.DB.Table(s.LeaseTableName).AutoMigrate(&Leases{}); err != nil {
+		return fmt.Errorf("failed to create table: %s", err)
+	}
+	if err := s.DB.Table(s.WorkflowTableName).AutoMigrate(&WorkflowRuns{}); err != nil {
+		return fmt.Errorf("failed to create table: %s", err)
+	}
 
 	return nil
 }
@@ -119,6 +172,163 @@ func (s *GormStore) GetNextRunTime() (time.Time, error) {
 	return nextRunTimeMin, nil
 }
 
+func (s *GormStore) AddCalendar(c agscheduler.Calendar) error {
+	bC, err := agscheduler.CalendarMarshal(c)
+	if err != nil {
+		return err
+	}
+
+	cs := Calendars{Name: c.Name, Data: bC}
+
+	return s.DB.Table(s.CalendarTableName).Save(cs).Error
+}
+
+func (s *GormStore) GetCalendar(name string) (agscheduler.Calendar, error) {
+	var cs Calendars
+
+	result := s.DB.Table(s.CalendarTableName).Where("name = ?", name).Limit(1).Find(&cs)
+	if result.Error != nil {
+		return agscheduler.Calendar{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return agscheduler.Calendar{}, agscheduler.CalendarNotFoundError(name)
+	}
+
+	return agscheduler.CalendarUnmarshal(cs.Data)
+}
+
+func (s *GormStore) GetAllCalendars() ([]agscheduler.Calendar, error) {
+	var csList []*Calendars
+	err := s.DB.Table(s.CalendarTableName).Find(&csList).Error
+	if err != nil {
+		return nil, err
+	}
+
+	calendarList := []agscheduler.Calendar{}
+	for _, cs := range csList {
+		c, err := agscheduler.CalendarUnmarshal(cs.Data)
+		if err != nil {
+			return nil, err
+		}
+		calendarList = append(calendarList, c)
+	}
+
+	return calendarList, nil
+}
+
+func (s *GormStore) DeleteCalendar(name string) error {
+	return s.DB.Table(s.CalendarTableName).Where("name = ?", name).Delete(&Calendars{}).Error
+}
+
+func (s *GormStore) AcquireLease(jId, leaseId string, limit int, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	err := s.DB.Table(s.LeaseTableName).Where("job_id = ? AND expire_at < ?", jId, now).Delete(&Leases{}).Error
+	if err != nil {
+		return false, err
+	}
+
+	// The leases held in the slots beyond the limit count too, e.g. when `MaxInstances` is lowered.
+	var count int64
+	err = s.DB.Table(s.LeaseTableName).Where("job_id = ?", jId).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	if count >= int64(limit) {
+		return false, nil
+	}
+
+	// Inserting into a held slot does nothing.
+	for i := range limit {
+		ls := Leases{JobId: jId, Slot: i, LeaseId: leaseId, ExpireAt: now.Add(ttl)}
+		result := s.DB.Table(s.LeaseTableName).Clauses(clause.OnConflict{DoNothing: true}).Create(&ls)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *GormStore) RenewLease(jId, leaseId string, ttl time.Duration) error {
+	return s.DB.Table(s.LeaseTableName).Where("lease_id = ?", leaseId).Update("expire_at", time.Now().UTC().Add(ttl)).Error
+}
+
+func (s *GormStore) ReleaseLease(jId, leaseId string) error {
+	return s.DB.Table(s.LeaseTableName).Where("lease_id = ?", leaseId).Delete(&Leases{}).Error
+}
+
+func (s *GormStore) AddWorkflowRun(wr agscheduler.WorkflowRun) error {
+	bWr, err := agscheduler.WorkflowRunMarshal(wr)
+	if err != nil {
+		return err
+	}
+
+	wrs := WorkflowRuns{ID: wr.Id, Version: wr.Version, Data: bWr}
+
+	return s.DB.Table(s.WorkflowTableName).Create(wrs).Error
+}
+
+func (s *GormStore) GetWorkflowRun(id string) (agscheduler.WorkflowRun, error) {
+	var wrs WorkflowRuns
+
+	result := s.DB.Table(s.WorkflowTableName).Where("id = ?", id).Limit(1).Find(&wrs)
+	if result.Error != nil {
+		return agscheduler.WorkflowRun{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return agscheduler.WorkflowRun{}, agscheduler.WorkflowRunNotFoundError(id)
+	}
+
+	return agscheduler.WorkflowRunUnmarshal(wrs.Data)
+}
+
+func (s *GormStore) GetAllWorkflowRuns() ([]agscheduler.WorkflowRun, error) {
+	var wrsList []*WorkflowRuns
+	err := s.DB.Table(s.WorkflowTableName).Find(&wrsList).Error
+	if err != nil {
+		return nil, err
+	}
+
+	workflowRunList := []agscheduler.WorkflowRun{}
+	for _, wrs := range wrsList {
+		wr, err := agscheduler.WorkflowRunUnmarshal(wrs.Data)
+		if err != nil {
+			return nil, err
+		}
+		workflowRunList = append(workflowRunList, wr)
+	}
+
+	return workflowRunList, nil
+}
+
+func (s *GormStore) UpdateWorkflowRun(wr agscheduler.WorkflowRun) (bool, error) {
+	bWr, err := agscheduler.WorkflowRunMarshal(wr)
+	if err != nil {
+		return false, err
+	}
+
+	result := s.DB.Table(s.WorkflowTableName).Where("id = ? AND version = ?", wr.Id, wr.Version-1).
+		Updates(map[string]any{"version": wr.Version, "data": bWr})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+
+	if _, err := s.GetWorkflowRun(wr.Id); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+func (s *GormStore) DeleteWorkflowRun(id string) error {
+	return s.DB.Table(s.WorkflowTableName).Where("id = ?", id).Delete(&WorkflowRuns{}).Error
+}
+
 func (s *GormStore) Clear() error {
-	return s.DB.Migrator().DropTable(s.TableName)
+	return s.DB.Migrator().DropTable(s.TableName, s.CalendarTableName, s.LeaseTableName, s.WorkflowTableName)
 }
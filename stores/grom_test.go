@@ -16,4 +16,11 @@ func TestGormStore(t *testing.T) {
 	store := &GormStore{DB: db, TableName: "test_jobs"}
 
 	runTest(t, store)
+
+	err = store.Init()
+	assert.NoError(t, err)
+	runLeaseTest(t, store)
+	runWorkflowRunTest(t, store)
+	err = store.Clear()
+	assert.NoError(t, err)
 }
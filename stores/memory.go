@@ -11,7 +11,9 @@ import (
 // Provides no persistence support.
 // Cluster HA mode is not supported.
 type MemoryStore struct {
-	jobs []agscheduler.Job
+	jobs         []agscheduler.Job
+	calendars    []agscheduler.Calendar
+	workflowRuns []agscheduler.WorkflowRun
 }
 
 func (s *MemoryStore) Name() string {
@@ -88,6 +90,105 @@ func (s *MemoryStore) GetNextRunTime() (time.Time, error) {
 	return nextRunTimeMin, nil
 }
 
+func (s *MemoryStore) AddCalendar(c agscheduler.Calendar) error {
+	for i, sC := range s.calendars {
+		if sC.Name == c.Name {
+			s.calendars[i] = c
+			return nil
+		}
+	}
+
+	s.calendars = append(s.calendars, c)
+	return nil
+}
+
+func (s *MemoryStore) GetCalendar(name string) (agscheduler.Calendar, error) {
+	for _, c := range s.calendars {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return agscheduler.Calendar{}, agscheduler.CalendarNotFoundError(name)
+}
+
+func (s *MemoryStore) GetAllCalendars() ([]agscheduler.Calendar, error) {
+	cs := make([]agscheduler.Calendar, len(s.calendars))
+	copy(cs, s.calendars)
+
+	return cs, nil
+}
+
+func (s *MemoryStore) DeleteCalendar(name string) error {
+	for i, c := range s.calendars {
+		if c.Name == name {
+			s.calendars = append(s.calendars[:i], s.calendars[i+1:]...)
+			return nil
+		}
+	}
+	return agscheduler.CalendarNotFoundError(name)
+}
+
+func (s *MemoryStore) AddWorkflowRun(wr agscheduler.WorkflowRun) error {
+	s.workflowRuns = append(s.workflowRuns, wr)
+	return nil
+}
+
+func (s *MemoryStore) GetWorkflowRun(id string) (agscheduler.WorkflowRun, error) {
+	for _, wr := range s.workflowRuns {
+		if wr.Id == id {
+			return copyWorkflowRun(wr)
+		}
+	}
+	return agscheduler.WorkflowRun{}, agscheduler.WorkflowRunNotFoundError(id)
+}
+
+func (s *MemoryStore) GetAllWorkflowRuns() ([]agscheduler.WorkflowRun, error) {
+	wrs := []agscheduler.WorkflowRun{}
+	for _, wr := range s.workflowRuns {
+		cWr, err := copyWorkflowRun(wr)
+		if err != nil {
+			return nil, err
+		}
+		wrs = append(wrs, cWr)
+	}
+
+	return wrs, nil
+}
+
+func (s *MemoryStore) UpdateWorkflowRun(wr agscheduler.WorkflowRun) (bool, error) {
+	for i, sWr := range s.workflowRuns {
+		if sWr.Id == wr.Id {
+			if sWr.Version != wr.Version-1 {
+				return false, nil
+			}
+			s.workflowRuns[i] = wr
+			return true, nil
+		}
+	}
+
+	return false, agscheduler.WorkflowRunNotFoundError(wr.Id)
+}
+
+func (s *MemoryStore) DeleteWorkflowRun(id string) error {
+	for i, wr := range s.workflowRuns {
+		if wr.Id == id {
+			s.workflowRuns = append(s.workflowRuns[:i], s.workflowRuns[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func copyWorkflowRun(wr agscheduler.WorkflowRun) (agscheduler.WorkflowRun, error) {
+	bWr, err := agscheduler.WorkflowRunMarshal(wr)
+	if err != nil {
+		return agscheduler.WorkflowRun{}, err
+	}
+	return agscheduler.WorkflowRunUnmarshal(bWr)
+}
+
 func (s *MemoryStore) Clear() error {
+	s.calendars = nil
+	s.workflowRuns = nil
 	return s.DeleteAllJobs()
 }
@@ -6,4 +6,5 @@ func TestMemoryStore(t *testing.T) {
 	store := &MemoryStore{}
 
 	runTest(t, store)
+	runWorkflowRunTest(t, store)
 }
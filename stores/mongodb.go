@@ -13,8 +13,9 @@ import (
 )
 
 const (
-	MONGODB_DATABASE   = "agscheduler"
-	MONGODB_COLLECTION = "jobs"
+	MONGODB_DATABASE            = "agscheduler"
+	MONGODB_COLLECTION          = "jobs"
+	MONGODB_CALENDAR_COLLECTION = "calendars"
 )
 
 // Stores jobs in a MongoDB database.
@@ -23,6 +24,9 @@ type MongoDBStore struct {
 	Database   string
 	Collection string
 	coll       *mongo.Collection
+
+	CalendarCollection string
+	calendarColl       *mongo.Collection
 }
 
 func (s *MongoDBStore) Name() string {
@@ -37,7 +41,12 @@ func (s *MongoDBStore) Init() error {
 		s.Collection = MONGODB_COLLECTION
 	}
 
+	if s.CalendarCollection == "" {
+		s.CalendarCollection = MONGODB_CALENDAR_COLLECTION
+	}
+
 	s.coll = s.Client.Database(s.Database).Collection(s.Collection)
+	s.calendarColl = s.Client.Database(s.Database).Collection(s.CalendarCollection)
 
 	indexModel := mongo.IndexModel{
 		Keys: bson.M{
@@ -150,6 +159,68 @@ func (s *MongoDBStore) GetNextRunTime() (time.Time, error) {
 	return nextRunTimeMin, nil
 }
 
+func (s *MongoDBStore) AddCalendar(c agscheduler.Calendar) error {
+	bC, err := agscheduler.CalendarMarshal(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.calendarColl.ReplaceOne(ctx,
+		bson.M{"_id": c.Name},
+		bson.M{"data": bC},
+		options.Replace().SetUpsert(true),
+	)
+
+	return err
+}
+
+func (s *MongoDBStore) GetCalendar(name string) (agscheduler.Calendar, error) {
+	var result bson.M
+	err := s.calendarColl.FindOne(ctx, bson.M{"_id": name}).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return agscheduler.Calendar{}, agscheduler.CalendarNotFoundError(name)
+	}
+	if err != nil {
+		return agscheduler.Calendar{}, err
+	}
+
+	bC := result["data"].(primitive.Binary).Data
+	return agscheduler.CalendarUnmarshal(bC)
+}
+
+func (s *MongoDBStore) GetAllCalendars() ([]agscheduler.Calendar, error) {
+	cursor, err := s.calendarColl.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	calendarList := []agscheduler.Calendar{}
+	for cursor.Next(ctx) {
+		var result bson.M
+		err := cursor.Decode(&result)
+		if err != nil {
+			return nil, err
+		}
+		bC := result["data"].(primitive.Binary).Data
+		c, err := agscheduler.CalendarUnmarshal(bC)
+		if err != nil {
+			return nil, err
+		}
+		calendarList = append(calendarList, c)
+	}
+
+	return calendarList, nil
+}
+
+func (s *MongoDBStore) DeleteCalendar(name string) error {
+	_, err := s.calendarColl.DeleteOne(ctx, bson.M{"_id": name})
+	return err
+}
+
 func (s *MongoDBStore) Clear() error {
+	if err := s.calendarColl.Drop(ctx); err != nil {
+		return err
+	}
+
 	return s.Client.Database(s.Database).Collection(s.Collection).Drop(ctx)
 }
@@ -1,6 +1,7 @@
 package stores
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -11,13 +12,74 @@ import (
 const (
 	REDIS_JOBS_KEY      = "agscheduler.jobs"
 	REDIS_RUN_TIMES_KEY = "agscheduler.run_times"
+	REDIS_CALENDARS_KEY = "agscheduler.calendars"
+	REDIS_LEASES_KEY    = "agscheduler.leases"
+	REDIS_RATE_KEY      = "agscheduler.rate_limiters"
+	REDIS_WORKFLOW_KEY  = "agscheduler.workflow_runs"
 )
 
+// Remove the expired leases of the job, then add the lease if fewer than `limit` leases are held.
+var acquireLeaseScript = redis.NewScript(`
+local now = tonumber(ARGV[2])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], now + tonumber(ARGV[4]), ARGV[1])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return 1
+`)
+
+// The same as `agscheduler.RateBucket.Take`, the times are in milliseconds.
+var takeTokenScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local b = redis.call("HMGET", KEYS[1], "tokens", "updated_at")
+local tokens = tonumber(b[1])
+local updatedAt = tonumber(b[2])
+if tokens == nil then
+	tokens = burst
+	updatedAt = now
+elseif now > updatedAt then
+	tokens = math.min(burst, tokens + (now - updatedAt) / interval)
+	updatedAt = now
+end
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * interval)
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated_at", tostring(updatedAt))
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * interval) + 1000)
+return wait
+`)
+
+// Replace the workflow run if its version is `ARGV[2] - 1`.
+var updateWorkflowRunScript = redis.NewScript(`
+local v = redis.call("HGET", KEYS[1], ARGV[1])
+if not v then
+	return -1
+end
+if cjson.decode(v)["version"] ~= tonumber(ARGV[2]) - 1 then
+	return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[3])
+return 1
+`)
+
 // Stores jobs in a Redis database.
 type RedisStore struct {
-	RDB         *redis.Client
-	JobsKey     string
-	RunTimesKey string
+	RDB          *redis.Client
+	JobsKey      string
+	RunTimesKey  string
+	CalendarsKey string
+	// The leases of each job are stored in a sorted set with this prefix.
+	LeasesKey string
+	// The token bucket of each rate limiter is stored in a hash with this prefix.
+	RateKey     string
+	WorkflowKey string
 }
 
 func (s *RedisStore) Name() string {
@@ -31,6 +93,18 @@ func (s *RedisStore) Init() error {
 	if s.RunTimesKey == "" {
 		s.RunTimesKey = REDIS_RUN_TIMES_KEY
 	}
+	if s.CalendarsKey == "" {
+		s.CalendarsKey = REDIS_CALENDARS_KEY
+	}
+	if s.LeasesKey == "" {
+		s.LeasesKey = REDIS_LEASES_KEY
+	}
+	if s.RateKey == "" {
+		s.RateKey = REDIS_RATE_KEY
+	}
+	if s.WorkflowKey == "" {
+		s.WorkflowKey = REDIS_WORKFLOW_KEY
+	}
 
 	return nil
 }
@@ -137,6 +211,167 @@ func (s *RedisStore) GetNextRunTime() (time.Time, error) {
 	return nextRunTimeMin, nil
 }
 
+func (s *RedisStore) AddCalendar(c agscheduler.Calendar) error {
+	bC, err := agscheduler.CalendarMarshal(c)
+	if err != nil {
+		return err
+	}
+
+	return s.RDB.HSet(ctx, s.CalendarsKey, c.Name, bC).Err()
+}
+
+func (s *RedisStore) GetCalendar(name string) (agscheduler.Calendar, error) {
+	bC, err := s.RDB.HGet(ctx, s.CalendarsKey, name).Bytes()
+	if err == redis.Nil {
+		return agscheduler.Calendar{}, agscheduler.CalendarNotFoundError(name)
+	}
+	if err != nil {
+		return agscheduler.Calendar{}, err
+	}
+
+	return agscheduler.CalendarUnmarshal(bC)
+}
+
+func (s *RedisStore) GetAllCalendars() ([]agscheduler.Calendar, error) {
+	mapBCs, err := s.RDB.HGetAll(ctx, s.CalendarsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	calendarList := []agscheduler.Calendar{}
+	for _, v := range mapBCs {
+		c, err := agscheduler.CalendarUnmarshal([]byte(v))
+		if err != nil {
+			return nil, err
+		}
+		calendarList = append(calendarList, c)
+	}
+
+	return calendarList, nil
+}
+
+func (s *RedisStore) DeleteCalendar(name string) error {
+	return s.RDB.HDel(ctx, s.CalendarsKey, name).Err()
+}
+
+func (s *RedisStore) leasesKey(jId string) string {
+	return fmt.Sprintf("%s:%s", s.LeasesKey, jId)
+}
+
+func (s *RedisStore) AcquireLease(jId, leaseId string, limit int, ttl time.Duration) (bool, error) {
+	now := time.Now().UnixMilli()
+	ok, err := acquireLeaseScript.Run(ctx, s.RDB, []string{s.leasesKey(jId)}, leaseId, now, limit, ttl.Milliseconds()).Bool()
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (s *RedisStore) RenewLease(jId, leaseId string, ttl time.Duration) error {
+	key := s.leasesKey(jId)
+	_, err := s.RDB.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(time.Now().Add(ttl).UnixMilli()), Member: leaseId})
+		pipe.PExpire(ctx, key, ttl)
+		return nil
+	})
+
+	return err
+}
+
+func (s *RedisStore) ReleaseLease(jId, leaseId string) error {
+	return s.RDB.ZRem(ctx, s.leasesKey(jId), leaseId).Err()
+}
+
+func (s *RedisStore) TakeToken(name string, burst int, interval time.Duration) (time.Duration, error) {
+	key := fmt.Sprintf("%s:%s", s.RateKey, name)
+	now := time.Now().UnixMilli()
+	wait, err := takeTokenScript.Run(ctx, s.RDB, []string{key}, burst, max(1, interval.Milliseconds()), now).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+func (s *RedisStore) AddWorkflowRun(wr agscheduler.WorkflowRun) error {
+	bWr, err := agscheduler.WorkflowRunMarshal(wr)
+	if err != nil {
+		return err
+	}
+
+	return s.RDB.HSet(ctx, s.WorkflowKey, wr.Id, bWr).Err()
+}
+
+func (s *RedisStore) GetWorkflowRun(id string) (agscheduler.WorkflowRun, error) {
+	bWr, err := s.RDB.HGet(ctx, s.WorkflowKey, id).Bytes()
+	if err == redis.Nil {
+		return agscheduler.WorkflowRun{}, agscheduler.WorkflowRunNotFoundError(id)
+	}
+	if err != nil {
+		return agscheduler.WorkflowRun{}, err
+	}
+
+	return agscheduler.WorkflowRunUnmarshal(bWr)
+}
+
+func (s *RedisStore) GetAllWorkflowRuns() ([]agscheduler.WorkflowRun, error) {
+	mapBWrs, err := s.RDB.HGetAll(ctx, s.WorkflowKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	workflowRunList := []agscheduler.WorkflowRun{}
+	for _, v := range mapBWrs {
+		wr, err := agscheduler.WorkflowRunUnmarshal([]byte(v))
+		if err != nil {
+			return nil, err
+		}
+		workflowRunList = append(workflowRunList, wr)
+	}
+
+	return workflowRunList, nil
+}
+
+func (s *RedisStore) UpdateWorkflowRun(wr agscheduler.WorkflowRun) (bool, error) {
+	bWr, err := agscheduler.WorkflowRunMarshal(wr)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := updateWorkflowRunScript.Run(ctx, s.RDB, []string{s.WorkflowKey}, wr.Id, wr.Version, bWr).Int()
+	if err != nil {
+		return false, err
+	}
+	if result == -1 {
+		return false, agscheduler.WorkflowRunNotFoundError(wr.Id)
+	}
+
+	return result == 1, nil
+}
+
+func (s *RedisStore) DeleteWorkflowRun(id string) error {
+	return s.RDB.HDel(ctx, s.WorkflowKey, id).Err()
+}
+
 func (s *RedisStore) Clear() error {
+	if err := s.RDB.Del(ctx, s.CalendarsKey, s.WorkflowKey).Err(); err != nil {
+		return err
+	}
+	leasesKeys, err := s.RDB.Keys(ctx, s.leasesKey("*")).Result()
+	if err != nil {
+		return err
+	}
+	rateKeys, err := s.RDB.Keys(ctx, s.RateKey+":*").Result()
+	if err != nil {
+		return err
+	}
+	keys := append(leasesKeys, rateKeys...)
+	if len(keys) > 0 {
+		if err := s.RDB.Del(ctx, keys...).Err(); err != nil {
+			return err
+		}
+	}
+
 	return s.DeleteAllJobs()
 }
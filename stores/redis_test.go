@@ -26,4 +26,12 @@ func TestRedisStore(t *testing.T) {
 	}
 
 	runTest(t, store)
+
+	err = store.Init()
+	assert.NoError(t, err)
+	runLeaseTest(t, store)
+	runWorkflowRunTest(t, store)
+	runRateLimitTest(t, store)
+	err = store.Clear()
+	assert.NoError(t, err)
 }
@@ -0,0 +1,189 @@
+package agscheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/agscheduler/agscheduler/services/proto"
+)
+
+// constant indicating how a combined job's triggers are combined
+const (
+	TRIGGER_MODE_OR  = "or"
+	TRIGGER_MODE_AND = "and"
+)
+
+// Maximum number of candidate run times checked when the triggers are combined by `TRIGGER_MODE_AND`.
+const triggerAndSearchMax = 10000
+
+// A sub-trigger of the job whose Type is `JOB_TYPE_COMBINED`,
+// it is evaluated in the `Timezone` of the job.
+type Trigger struct {
+	// Optional: `JOB_TYPE_DATETIME` | `JOB_TYPE_INTERVAL` | `JOB_TYPE_CRON` | `JOB_TYPE_RRULE`
+	Type string `json:"type"`
+	// It can be used when Type is `JOB_TYPE_DATETIME` | `JOB_TYPE_INTERVAL` | `JOB_TYPE_RRULE`.
+	// When Type is `JOB_TYPE_INTERVAL`, the runs are anchored to it,
+	// if empty, the runs are anchored to `LastRunTime` of the job, or to the time of the calculation before the first run.
+	// e.g. `2023-09-22 07:30:08`
+	StartAt string `json:"start_at"`
+	// It can be used when Type is `JOB_TYPE_INTERVAL`.
+	// e.g. `2s`
+	Interval string `json:"interval"`
+	// It can be used when Type is `JOB_TYPE_CRON`.
+	// e.g. `*/1 * * * *`
+	CronExpr string `json:"cron_expr"`
+	// It can be used when Type is `JOB_TYPE_RRULE`.
+	// e.g. `RRULE:FREQ=MONTHLY;BYDAY=2TU`
+	RRule string `json:"rrule"`
+}
+
+// The job used to calculate the run times of the trigger,
+// `LastRunTime` keeps the interval trigger without `StartAt` on a stable cadence.
+func (tr Trigger) job(j Job) Job {
+	return Job{
+		Id:           j.Id,
+		Name:         j.Name,
+		Type:         tr.Type,
+		StartAt:      tr.StartAt,
+		Interval:     tr.Interval,
+		IntervalMode: j.IntervalMode,
+		CronExpr:     tr.CronExpr,
+		RRule:        tr.RRule,
+		Timezone:     j.Timezone,
+		LastRunTime:  j.LastRunTime,
+	}
+}
+
+// Called by `check` when the job Type is `JOB_TYPE_COMBINED`.
+func (j *Job) checkTriggers() error {
+	if len(j.Triggers) == 0 {
+		return fmt.Errorf("job `%s` Triggers cannot be empty", j.FullName())
+	}
+
+	mode := strings.ToLower(j.TriggerMode)
+	if mode != "" && mode != TRIGGER_MODE_OR && mode != TRIGGER_MODE_AND {
+		return fmt.Errorf("job `%s` TriggerMode `%s` unknown", j.FullName(), j.TriggerMode)
+	}
+
+	// The fixed-delay runs are only completed for the jobs whose Type is `JOB_TYPE_INTERVAL`.
+	if j.IntervalMode == INTERVAL_MODE_FIXED_DELAY {
+		return fmt.Errorf("job `%s` IntervalMode cannot be `%s` when Type is `%s`",
+			j.FullName(), INTERVAL_MODE_FIXED_DELAY, JOB_TYPE_COMBINED)
+	}
+
+	for i, tr := range j.Triggers {
+		switch strings.ToLower(tr.Type) {
+		case JOB_TYPE_DATETIME, JOB_TYPE_CRON, JOB_TYPE_RRULE:
+		case JOB_TYPE_INTERVAL:
+			// Without an anchor, the interval trigger never fires at the same time as the others.
+			if mode == TRIGGER_MODE_AND && tr.StartAt == "" {
+				return fmt.Errorf("job `%s` Triggers[%d] StartAt cannot be empty when TriggerMode is `%s`",
+					j.FullName(), i, TRIGGER_MODE_AND)
+			}
+		default:
+			return fmt.Errorf("job `%s` Triggers[%d] Type `%s` unknown", j.FullName(), i, tr.Type)
+		}
+	}
+
+	return nil
+}
+
+// The next run time of the job whose Type is `JOB_TYPE_COMBINED`.
+// A datetime trigger whose `StartAt` is not after `t` no longer fires,
+// if no trigger will fire again, `JobEndedError` is returned.
+func calcCombinedNextRunTime(j Job, t time.Time) (time.Time, error) {
+	if len(j.Triggers) == 0 {
+		return time.Time{}, fmt.Errorf("job `%s` Triggers cannot be empty", j.FullName())
+	}
+
+	switch strings.ToLower(j.TriggerMode) {
+	case "", TRIGGER_MODE_OR:
+		var nextRunTime time.Time
+		for _, tr := range j.Triggers {
+			trNextRunTime, err := calcTriggerNextRunTime(j, tr, t)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if !trNextRunTime.After(t) {
+				continue
+			}
+			if nextRunTime.IsZero() || trNextRunTime.Before(nextRunTime) {
+				nextRunTime = trNextRunTime
+			}
+		}
+		if nextRunTime.IsZero() {
+			return time.Time{}, JobEndedError(j.FullName())
+		}
+		return nextRunTime, nil
+	case TRIGGER_MODE_AND:
+		// Move to the latest run time of the triggers until they all agree,
+		// run times are in seconds, so the triggers are evaluated just before the candidate.
+		after := t
+		for range triggerAndSearchMax {
+			var earliest, latest time.Time
+			for _, tr := range j.Triggers {
+				trNextRunTime, err := calcTriggerNextRunTime(j, tr, after)
+				if err != nil {
+					return time.Time{}, err
+				}
+				if !trNextRunTime.After(after) {
+					return time.Time{}, JobEndedError(j.FullName())
+				}
+				if earliest.IsZero() || trNextRunTime.Before(earliest) {
+					earliest = trNextRunTime
+				}
+				if trNextRunTime.After(latest) {
+					latest = trNextRunTime
+				}
+			}
+			if earliest.Equal(latest) {
+				return latest, nil
+			}
+			after = latest.Add(-time.Second)
+		}
+		return time.Time{}, fmt.Errorf("job `%s` Triggers have no common run time", j.FullName())
+	default:
+		return time.Time{}, fmt.Errorf("job `%s` TriggerMode `%s` unknown", j.FullName(), j.TriggerMode)
+	}
+}
+
+func calcTriggerNextRunTime(j Job, tr Trigger, t time.Time) (time.Time, error) {
+	if strings.ToLower(tr.Type) == JOB_TYPE_COMBINED {
+		return time.Time{}, fmt.Errorf("job `%s` Trigger Type cannot be `%s`", j.FullName(), JOB_TYPE_COMBINED)
+	}
+
+	return calcNextRunTime(tr.job(j), t)
+}
+
+// Used to gRPC Protobuf
+func TriggersToPbTriggersPtr(trs []Trigger) []*pb.Trigger {
+	pbTrs := make([]*pb.Trigger, 0, len(trs))
+	for _, tr := range trs {
+		pbTrs = append(pbTrs, &pb.Trigger{
+			Type:     tr.Type,
+			StartAt:  tr.StartAt,
+			Interval: tr.Interval,
+			CronExpr: tr.CronExpr,
+			Rrule:    tr.RRule,
+		})
+	}
+
+	return pbTrs
+}
+
+// Used to gRPC Protobuf
+func PbTriggersPtrToTriggers(pbTrs []*pb.Trigger) []Trigger {
+	trs := make([]Trigger, 0, len(pbTrs))
+	for _, pbTr := range pbTrs {
+		trs = append(trs, Trigger{
+			Type:     pbTr.GetType(),
+			StartAt:  pbTr.GetStartAt(),
+			Interval: pbTr.GetInterval(),
+			CronExpr: pbTr.GetCronExpr(),
+			RRule:    pbTr.GetRrule(),
+		})
+	}
+
+	return trs
+}
@@ -0,0 +1,50 @@
+package agscheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcCombinedNextRunTimeInterval(t *testing.T) {
+	j := Job{
+		Name:     "Job",
+		Type:     JOB_TYPE_COMBINED,
+		Timezone: "UTC",
+		Triggers: []Trigger{
+			{Type: JOB_TYPE_INTERVAL, Interval: "10m"},
+			{Type: JOB_TYPE_CRON, CronExpr: "0 0 1 1 *"},
+		},
+		IntervalMode: INTERVAL_MODE_FIXED_RATE,
+		Status:       JOB_STATUS_RUNNING,
+	}
+	now := time.Date(2026, 10, 18, 7, 30, 0, 0, time.UTC)
+
+	nextRunTime, err := calcNextRunTime(j, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(10*time.Minute), nextRunTime)
+
+	// The consecutive run times are anchored to the last run, however late they are calculated.
+	for range 3 {
+		j.LastRunTime = nextRunTime
+		for _, d := range []time.Duration{time.Second, 4 * time.Minute} {
+			trNextRunTime, err := calcNextRunTime(j, j.LastRunTime.Add(d))
+			assert.NoError(t, err)
+			assert.Equal(t, j.LastRunTime.Add(10*time.Minute), trNextRunTime)
+		}
+		nextRunTime, _ = calcNextRunTime(j, j.LastRunTime.Add(time.Second))
+	}
+	assert.Equal(t, now.Add(40*time.Minute), nextRunTime)
+}
+
+func TestCheckTriggersFixedDelay(t *testing.T) {
+	j := Job{
+		Name:         "Job",
+		Type:         JOB_TYPE_COMBINED,
+		Triggers:     []Trigger{{Type: JOB_TYPE_INTERVAL, Interval: "10m"}},
+		IntervalMode: INTERVAL_MODE_FIXED_DELAY,
+	}
+
+	assert.Error(t, j.checkTriggers())
+}
@@ -0,0 +1,147 @@
+package agscheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// The keys of `Args` used by `RunWebhook`.
+const (
+	// Optional, the HTTP method, default: `GET`.
+	// def: string
+	WEBHOOK_ARG_METHOD = "method"
+	// Required, the URL to call.
+	// def: string
+	WEBHOOK_ARG_URL = "url"
+	// Optional, the request headers.
+	// def: map[string]string
+	WEBHOOK_ARG_HEADERS = "headers"
+	// Optional, the request body.
+	// def: string
+	WEBHOOK_ARG_BODY = "body"
+	// Optional, the status codes regarded as success, default: any `2xx`.
+	// def: []int
+	WEBHOOK_ARG_EXPECTED_CODES = "expected_codes"
+)
+
+// Maximum number of bytes of the response body kept in the result, the rest is dropped.
+const webhookBodyMax = 4 << 10
+
+// The result of `RunWebhook`, it is saved as JSON in `Record.Result`.
+type WebhookResult struct {
+	StatusCode int    `json:"status_code"`
+	Body       string `json:"body"`
+	// Set when the request fails or the status code is not expected.
+	Error string `json:"error,omitempty"`
+}
+
+var webhookFuncPkg = FuncPkg{
+	TypedFunc: RunWebhook,
+	Info:      "Call the URL of Args `url`, with optional `method`, `headers`, `body` and `expected_codes`.",
+}
+
+func init() {
+	RegisterFuncs(webhookFuncPkg)
+}
+
+// Built-in `TypedFunc` that calls an HTTP URL, registered as `github.com/agscheduler/agscheduler.RunWebhook`
+// out of the box, call `UnregisterBuiltinFuncs` to opt out.
+// The request is cancelled when the job times out or the run is cancelled.
+// The status code and the truncated response body are returned as `WebhookResult`,
+// when the request fails or the status code is not expected, it returns a `WebhookError` with the result,
+// so the run is recorded as `RECORD_STATUS_ERROR`.
+func RunWebhook(ctx context.Context, j Job) (any, error) {
+	url, _ := j.Args[WEBHOOK_ARG_URL].(string)
+	if url == "" {
+		return nil, &NonRetryableError{Err: fmt.Errorf("job `%s` Args `%s` cannot be empty", j.FullName(), WEBHOOK_ARG_URL)}
+	}
+	method, _ := j.Args[WEBHOOK_ARG_METHOD].(string)
+	if method == "" {
+		method = http.MethodGet
+	}
+	headers, err := argStringMap(j.Args[WEBHOOK_ARG_HEADERS])
+	if err != nil {
+		return nil, &NonRetryableError{Err: fmt.Errorf("job `%s` Args `%s` error: %s", j.FullName(), WEBHOOK_ARG_HEADERS, err)}
+	}
+	expectedCodes, err := webhookArgCodes(j.Args[WEBHOOK_ARG_EXPECTED_CODES])
+	if err != nil {
+		return nil, &NonRetryableError{Err: fmt.Errorf("job `%s` Args `%s` error: %s", j.FullName(), WEBHOOK_ARG_EXPECTED_CODES, err)}
+	}
+	body, _ := j.Args[WEBHOOK_ARG_BODY].(string)
+
+	r := doWebhook(ctx, strings.ToUpper(method), url, headers, body, expectedCodes)
+	if r.Error != "" {
+		return r, &WebhookError{Result: r}
+	}
+
+	return r, nil
+}
+
+// Send the request of `RunWebhook`, `WebhookResult.Error` is set when it fails.
+func doWebhook(ctx context.Context, method, url string, headers map[string]string, body string, expectedCodes []int) (r WebhookResult) {
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	r.StatusCode = resp.StatusCode
+	bBody, err := io.ReadAll(io.LimitReader(resp.Body, webhookBodyMax+1))
+	if len(bBody) > webhookBodyMax {
+		r.Body = string(bBody[:webhookBodyMax]) + "...(truncated)"
+	} else {
+		r.Body = string(bBody)
+	}
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+
+	if len(expectedCodes) == 0 && (r.StatusCode < 200 || r.StatusCode > 299) ||
+		len(expectedCodes) > 0 && !slices.Contains(expectedCodes, r.StatusCode) {
+		r.Error = fmt.Sprintf("unexpected status code `%d`", r.StatusCode)
+	}
+
+	return
+}
+
+// Values of `Args` decoded from JSON are `[]any` of `float64`.
+func webhookArgCodes(v any) ([]int, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case []int:
+		return v, nil
+	case []any:
+		codes := make([]int, 0, len(v))
+		for _, e := range v {
+			switch e := e.(type) {
+			case int:
+				codes = append(codes, e)
+			case float64:
+				codes = append(codes, int(e))
+			default:
+				return nil, fmt.Errorf("`%v` is not a status code", e)
+			}
+		}
+		return codes, nil
+	default:
+		return nil, fmt.Errorf("`%v` is not a list", v)
+	}
+}
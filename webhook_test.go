@@ -0,0 +1,93 @@
+package agscheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runWebhookErrorResult(t *testing.T, ctx context.Context, j Job) WebhookResult {
+	_, err := RunWebhook(ctx, j)
+	var webhookErr *WebhookError
+	assert.ErrorAs(t, err, &webhookErr)
+
+	return webhookErr.Result
+}
+
+func TestRunWebhook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, "%s %s %s", r.Method, r.Header.Get("X-Token"), body)
+	}))
+	defer srv.Close()
+
+	j := Job{Args: map[string]any{
+		WEBHOOK_ARG_METHOD:  "post",
+		WEBHOOK_ARG_URL:     srv.URL,
+		WEBHOOK_ARG_HEADERS: map[string]any{"X-Token": "token"},
+		WEBHOOK_ARG_BODY:    "body",
+	}}
+
+	result, err := RunWebhook(context.Background(), j)
+	assert.NoError(t, err)
+	r := result.(WebhookResult)
+	assert.Equal(t, http.StatusAccepted, r.StatusCode)
+	assert.Equal(t, "POST token body", r.Body)
+	assert.Empty(t, r.Error)
+}
+
+func TestRunWebhookExpectedCodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(strings.Repeat("a", webhookBodyMax+1)))
+	}))
+	defer srv.Close()
+
+	j := Job{Args: map[string]any{WEBHOOK_ARG_URL: srv.URL}}
+	r := runWebhookErrorResult(t, context.Background(), j)
+	assert.Equal(t, http.StatusNotFound, r.StatusCode)
+	assert.Equal(t, "unexpected status code `404`", r.Error)
+	assert.Equal(t, strings.Repeat("a", webhookBodyMax)+"...(truncated)", r.Body)
+
+	j.Args[WEBHOOK_ARG_EXPECTED_CODES] = []any{float64(200), float64(404)}
+	result, err := RunWebhook(context.Background(), j)
+	assert.NoError(t, err)
+	rOk := result.(WebhookResult)
+	assert.Equal(t, http.StatusNotFound, rOk.StatusCode)
+	assert.Empty(t, rOk.Error)
+}
+
+func TestRunWebhookTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	r := runWebhookErrorResult(t, ctx, Job{Args: map[string]any{WEBHOOK_ARG_URL: srv.URL}})
+	assert.Contains(t, r.Error, context.DeadlineExceeded.Error())
+}
+
+func TestRunWebhookError(t *testing.T) {
+	for _, args := range []map[string]any{
+		{},
+		{WEBHOOK_ARG_URL: "http://127.0.0.1", WEBHOOK_ARG_HEADERS: "headers"},
+		{WEBHOOK_ARG_URL: "http://127.0.0.1", WEBHOOK_ARG_EXPECTED_CODES: []any{"200"}},
+	} {
+		_, err := RunWebhook(context.Background(), Job{Args: args})
+		var nonRetryableErr *NonRetryableError
+		assert.ErrorAs(t, err, &nonRetryableErr)
+	}
+}
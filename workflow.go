@@ -0,0 +1,553 @@
+package agscheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/agscheduler/agscheduler/services/proto"
+)
+
+// constant indicating the status of the workflow run
+const (
+	WORKFLOW_RUN_STATUS_RUNNING   = "running"
+	WORKFLOW_RUN_STATUS_COMPLETED = "completed"
+	WORKFLOW_RUN_STATUS_FAILED    = "failed"
+)
+
+// The key of `Args` for the results of upstream jobs.
+// def: map[<upstream job id>]<result>
+const WORKFLOW_ARG_UPSTREAM_RESULTS = "upstream_results"
+
+// Maximum number of workflow runs kept, the earliest ended ones are deleted first.
+const workflowRunsMax = 100
+
+// A job in the workflow.
+type WorkflowStep struct {
+	JobId     string   `json:"job_id"`
+	JobName   string   `json:"job_name"`
+	Upstreams []string `json:"upstreams"`
+}
+
+// Workflow definition, made up of the jobs connected to each other through `Upstreams`.
+type Workflow struct {
+	// The jobs without upstream jobs, their runs start or join a workflow run.
+	RootJobIds []string       `json:"root_job_ids"`
+	Steps      []WorkflowStep `json:"steps"`
+}
+
+// The run state of a job in the workflow run.
+type WorkflowRunStep struct {
+	JobId   string `json:"job_id"`
+	JobName string `json:"job_name"`
+	// Optional: `RECORD_STATUS_RUNNING` | `RECORD_STATUS_COMPLETED` | `RECORD_STATUS_SKIPPED` |
+	// `RECORD_STATUS_ERROR` | `RECORD_STATUS_TIMEOUT` | `RECORD_STATUS_CANCELLED`
+	Status string `json:"status"`
+	Result string `json:"result"`
+}
+
+// Carry the run state of the workflow.
+// Workflow runs are persisted in the store if it implements `WorkflowRunStore`,
+// otherwise they are kept in memory of the scheduler, in cluster mode, it is the main node.
+type WorkflowRun struct {
+	// Shared by all job runs in this workflow run.
+	Id string `json:"id"`
+	// The root jobs of the workflow when the run starts,
+	// the run of a root job joins the earliest running workflow run that it has not joined.
+	RootJobIds []string `json:"root_job_ids"`
+	// Optional: `WORKFLOW_RUN_STATUS_RUNNING` | `WORKFLOW_RUN_STATUS_COMPLETED` | `WORKFLOW_RUN_STATUS_FAILED`
+	Status string `json:"status"`
+	// def: map[<job id>]WorkflowRunStep
+	Steps   map[string]WorkflowRunStep `json:"steps"`
+	StartAt time.Time                  `json:"start_at"`
+	EndAt   time.Time                  `json:"end_at"`
+	// Increased by each update, used to detect the concurrent updates.
+	Version int `json:"version"`
+}
+
+func (wr *WorkflowRun) copy() WorkflowRun {
+	cWr := *wr
+	cWr.RootJobIds = slices.Clone(wr.RootJobIds)
+	cWr.Steps = make(map[string]WorkflowRunStep, len(wr.Steps))
+	for k, v := range wr.Steps {
+		cWr.Steps[k] = v
+	}
+	return cWr
+}
+
+// `sort.Interface`, sorted by 'StartAt', descend.
+type WorkflowRunSlice []WorkflowRun
+
+func (ws WorkflowRunSlice) Len() int           { return len(ws) }
+func (ws WorkflowRunSlice) Less(i, j int) bool { return ws[i].StartAt.After(ws[j].StartAt) }
+func (ws WorkflowRunSlice) Swap(i, j int)      { ws[i], ws[j] = ws[j], ws[i] }
+
+// Serialize WorkflowRun and convert to Bytes
+func WorkflowRunMarshal(wr WorkflowRun) ([]byte, error) {
+	return json.Marshal(wr)
+}
+
+// Deserialize Bytes and convert to WorkflowRun
+func WorkflowRunUnmarshal(bWr []byte) (WorkflowRun, error) {
+	var wr WorkflowRun
+	err := json.Unmarshal(bWr, &wr)
+	if err != nil {
+		return WorkflowRun{}, err
+	}
+	return wr, nil
+}
+
+// Used to gRPC Protobuf
+func WorkflowsToPbWorkflowsPtr(ws []Workflow) []*pb.Workflow {
+	pbWs := []*pb.Workflow{}
+
+	for _, w := range ws {
+		pbSteps := []*pb.WorkflowStep{}
+		for _, step := range w.Steps {
+			pbSteps = append(pbSteps, &pb.WorkflowStep{
+				JobId:     step.JobId,
+				JobName:   step.JobName,
+				Upstreams: step.Upstreams,
+			})
+		}
+		pbWs = append(pbWs, &pb.Workflow{RootJobIds: w.RootJobIds, Steps: pbSteps})
+	}
+
+	return pbWs
+}
+
+// Used to gRPC Protobuf
+func WorkflowRunToPbWorkflowRunPtr(wr WorkflowRun) *pb.WorkflowRun {
+	pbSteps := make(map[string]*pb.WorkflowRunStep)
+	for k, v := range wr.Steps {
+		pbSteps[k] = &pb.WorkflowRunStep{
+			JobId:   v.JobId,
+			JobName: v.JobName,
+			Status:  v.Status,
+			Result:  v.Result,
+		}
+	}
+
+	return &pb.WorkflowRun{
+		Id:         wr.Id,
+		RootJobIds: wr.RootJobIds,
+		Status:     wr.Status,
+		Steps:      pbSteps,
+		StartAt:    timestamppb.New(wr.StartAt),
+		EndAt:      timestamppb.New(wr.EndAt),
+	}
+}
+
+// Used to gRPC Protobuf
+func WorkflowRunsToPbWorkflowRunsPtr(wrs []WorkflowRun) []*pb.WorkflowRun {
+	pbWrs := []*pb.WorkflowRun{}
+
+	for _, wr := range wrs {
+		pbWrs = append(pbWrs, WorkflowRunToPbWorkflowRunPtr(wr))
+	}
+
+	return pbWrs
+}
+
+// Get the jobs that have `id` in their `Upstreams`.
+func getDownstreams(id string, js []Job) []Job {
+	downstreams := []Job{}
+
+	for _, j := range js {
+		if slices.Contains(j.Upstreams, id) {
+			downstreams = append(downstreams, j)
+		}
+	}
+
+	return downstreams
+}
+
+// Get the jobs of the workflow that the job belongs to,
+// which are connected to it through `Upstreams` in either direction, itself included.
+func getWorkflowJobs(j Job, js []Job) []Job {
+	jobs := make(map[string]Job, len(js))
+	for _, j := range js {
+		jobs[j.Id] = j
+	}
+
+	wJs := []Job{j}
+	visited := map[string]bool{j.Id: true}
+	for i := 0; i < len(wJs); i++ {
+		cJ := wJs[i]
+		neighbours := getDownstreams(cJ.Id, js)
+		for _, uId := range cJ.Upstreams {
+			if uJ, ok := jobs[uId]; ok {
+				neighbours = append(neighbours, uJ)
+			}
+		}
+		for _, nJ := range neighbours {
+			if !visited[nJ.Id] {
+				visited[nJ.Id] = true
+				wJs = append(wJs, nJ)
+			}
+		}
+	}
+
+	return wJs
+}
+
+// Check that the upstream jobs of the job exist and do not lead back to the job.
+func checkUpstreams(j Job, js []Job) error {
+	jobs := make(map[string]Job, len(js)+1)
+	for _, j := range js {
+		jobs[j.Id] = j
+	}
+	jobs[j.Id] = j
+
+	for _, uId := range j.Upstreams {
+		if _, ok := jobs[uId]; !ok {
+			return fmt.Errorf("job `%s` Upstreams jobId `%s` not found", j.FullName(), uId)
+		}
+	}
+
+	visited := map[string]bool{}
+	stack := slices.Clone(j.Upstreams)
+	for len(stack) != 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == j.Id {
+			return fmt.Errorf("job `%s` Upstreams form a cycle", j.FullName())
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		stack = append(stack, jobs[id].Upstreams...)
+	}
+
+	return nil
+}
+
+// When the job is a root job of the workflow, join the earliest running workflow run that it has not joined,
+// or start a new one, and return the job with `WorkflowRunId`.
+func (s *Scheduler) _startWorkflowRun(j Job, js []Job) (Job, error) {
+	if len(j.Upstreams) != 0 || len(getDownstreams(j.Id, js)) == 0 {
+		return j, nil
+	}
+
+	s.workflowM.Lock()
+	defer s.workflowM.Unlock()
+
+	for {
+		wrs, err := s.getAllWorkflowRuns()
+		if err != nil {
+			return Job{}, err
+		}
+		sort.Sort(sort.Reverse(WorkflowRunSlice(wrs)))
+
+		idx := slices.IndexFunc(wrs, func(wr WorkflowRun) bool {
+			_, ok := wr.Steps[j.Id]
+			return wr.Status == WORKFLOW_RUN_STATUS_RUNNING && !ok && slices.Contains(wr.RootJobIds, j.Id)
+		})
+		if idx == -1 {
+			break
+		}
+
+		wr := wrs[idx]
+		wr.Steps[j.Id] = WorkflowRunStep{JobId: j.Id, JobName: j.Name, Status: RECORD_STATUS_RUNNING}
+		wr.Version++
+		ok, err := s.updateWorkflowRun(wr)
+		if err != nil {
+			return Job{}, err
+		}
+		if ok {
+			slog.Info(fmt.Sprintf("Scheduler job `%s` joins workflow run `%s`.", j.FullName(), wr.Id))
+
+			j.WorkflowRunId = wr.Id
+			return j, nil
+		}
+	}
+
+	rootJobIds := []string{}
+	for _, wJ := range getWorkflowJobs(j, js) {
+		if len(wJ.Upstreams) == 0 && (wJ.Id == j.Id || wJ.Status != JOB_STATUS_PAUSED) {
+			rootJobIds = append(rootJobIds, wJ.Id)
+		}
+	}
+	wr := WorkflowRun{
+		Id:         strings.ReplaceAll(uuid.New().String(), "-", "")[:16],
+		RootJobIds: rootJobIds,
+		Status:     WORKFLOW_RUN_STATUS_RUNNING,
+		Steps: map[string]WorkflowRunStep{
+			j.Id: {JobId: j.Id, JobName: j.Name, Status: RECORD_STATUS_RUNNING},
+		},
+		StartAt: time.Now().UTC(),
+	}
+	if err := s.addWorkflowRun(wr); err != nil {
+		return Job{}, err
+	}
+
+	slog.Info(fmt.Sprintf("Scheduler start workflow run `%s` by job `%s`.", wr.Id, j.FullName()))
+
+	j.WorkflowRunId = wr.Id
+	return j, nil
+}
+
+// Record the result of the job in the workflow run,
+// and run the downstream jobs whose upstream jobs have all completed or been skipped.
+func (s *Scheduler) _completeWorkflowStep(r JobResult) error {
+	s.workflowM.Lock()
+	defer s.workflowM.Unlock()
+
+	js, err := s.store.GetAllJobs()
+	if err != nil {
+		return err
+	}
+
+	for {
+		wJs, err := s._updateWorkflowStep(r, js)
+		if err != nil {
+			return err
+		}
+
+		if len(wJs) == 0 {
+			return nil
+		}
+		r = JobResult{}
+		for _, wJ := range wJs {
+			if err := s._scheduleJob(JobRun{Job: wJ}); err != nil {
+				slog.Error(fmt.Sprintf("Scheduler schedule job `%s` error: %s", wJ.FullName(), err))
+				r = JobResult{Job: wJ, Status: RECORD_STATUS_ERROR, Result: err.Error()}
+				break
+			}
+		}
+		if r.Job.Id == "" {
+			return nil
+		}
+	}
+}
+
+// Update the workflow run with the result of the job,
+// and return the downstream jobs to run, which are added to the workflow run as running.
+func (s *Scheduler) _updateWorkflowStep(r JobResult, js []Job) ([]Job, error) {
+	for {
+		wr, err := s.getWorkflowRun(r.Job.WorkflowRunId)
+		if err != nil {
+			return nil, err
+		}
+
+		wr.Steps[r.Job.Id] = WorkflowRunStep{
+			JobId: r.Job.Id, JobName: r.Job.Name, Status: r.Status, Result: r.Result,
+		}
+
+		if r.Status != RECORD_STATUS_COMPLETED && r.Status != RECORD_STATUS_SKIPPED &&
+			wr.Status == WORKFLOW_RUN_STATUS_RUNNING {
+			slog.Warn(fmt.Sprintf("Workflow run `%s` failed at job `%s`.", wr.Id, r.Job.FullName()))
+			wr.Status = WORKFLOW_RUN_STATUS_FAILED
+			wr.EndAt = time.Now().UTC()
+		}
+
+		wJs := []Job{}
+		if wr.Status == WORKFLOW_RUN_STATUS_RUNNING {
+			for _, dJ := range getDownstreams(r.Job.Id, js) {
+				if _, ok := wr.Steps[dJ.Id]; ok || dJ.Status == JOB_STATUS_PAUSED {
+					continue
+				}
+
+				isReady := true
+				results := map[string]any{}
+				for _, uId := range dJ.Upstreams {
+					step, ok := wr.Steps[uId]
+					if !ok || (step.Status != RECORD_STATUS_COMPLETED && step.Status != RECORD_STATUS_SKIPPED) {
+						isReady = false
+						break
+					}
+					if step.Status == RECORD_STATUS_COMPLETED {
+						results[uId] = step.Result
+					}
+				}
+				if !isReady {
+					continue
+				}
+
+				wJ, err := dJ.DeepCopy()
+				if err != nil {
+					return nil, err
+				}
+				wJ.WorkflowRunId = wr.Id
+				if wJ.Args == nil {
+					wJ.Args = map[string]any{}
+				}
+				wJ.Args[WORKFLOW_ARG_UPSTREAM_RESULTS] = results
+				wJs = append(wJs, wJ)
+
+				wr.Steps[dJ.Id] = WorkflowRunStep{JobId: dJ.Id, JobName: dJ.Name, Status: RECORD_STATUS_RUNNING}
+			}
+		}
+
+		if wr.Status == WORKFLOW_RUN_STATUS_RUNNING {
+			isRunning := false
+			for _, step := range wr.Steps {
+				if step.Status == RECORD_STATUS_RUNNING {
+					isRunning = true
+					break
+				}
+			}
+			for _, rId := range wr.RootJobIds {
+				if _, ok := wr.Steps[rId]; !ok {
+					isRunning = true
+					break
+				}
+			}
+			if !isRunning {
+				slog.Info(fmt.Sprintf("Workflow run `%s` completed.", wr.Id))
+				wr.Status = WORKFLOW_RUN_STATUS_COMPLETED
+				wr.EndAt = time.Now().UTC()
+			}
+		}
+
+		wr.Version++
+		ok, err := s.updateWorkflowRun(wr)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return wJs, nil
+		}
+	}
+}
+
+// Get the workflow definitions, each of them is made up of the jobs connected to each other.
+func (s *Scheduler) GetAllWorkflows() ([]Workflow, error) {
+	s.storeM.RLock()
+	defer s.storeM.RUnlock()
+
+	js, err := s.store.GetAllJobs()
+	if err != nil {
+		return []Workflow{}, err
+	}
+
+	ws := []Workflow{}
+	visited := map[string]bool{}
+	for _, j := range js {
+		if visited[j.Id] {
+			continue
+		}
+		wJs := getWorkflowJobs(j, js)
+		if len(wJs) == 1 {
+			continue
+		}
+
+		w := Workflow{RootJobIds: []string{}, Steps: []WorkflowStep{}}
+		for _, wJ := range wJs {
+			visited[wJ.Id] = true
+			if len(wJ.Upstreams) == 0 {
+				w.RootJobIds = append(w.RootJobIds, wJ.Id)
+			}
+			w.Steps = append(w.Steps, WorkflowStep{JobId: wJ.Id, JobName: wJ.Name, Upstreams: wJ.Upstreams})
+		}
+		ws = append(ws, w)
+	}
+
+	return ws, nil
+}
+
+func (s *Scheduler) GetWorkflowRun(id string) (WorkflowRun, error) {
+	s.workflowM.RLock()
+	defer s.workflowM.RUnlock()
+
+	return s.getWorkflowRun(id)
+}
+
+func (s *Scheduler) GetAllWorkflowRuns() ([]WorkflowRun, error) {
+	s.workflowM.RLock()
+	defer s.workflowM.RUnlock()
+
+	wrs, err := s.getAllWorkflowRuns()
+	if err != nil {
+		return []WorkflowRun{}, err
+	}
+	sort.Sort(WorkflowRunSlice(wrs))
+
+	return wrs, nil
+}
+
+// Add the workflow run to the store if it implements `WorkflowRunStore`, otherwise to the scheduler,
+// and delete the earliest ended ones beyond `workflowRunsMax`.
+func (s *Scheduler) addWorkflowRun(wr WorkflowRun) error {
+	if ws, ok := s.store.(WorkflowRunStore); ok {
+		if err := ws.AddWorkflowRun(wr); err != nil {
+			return err
+		}
+	} else {
+		s.workflowRuns[wr.Id] = wr.copy()
+	}
+
+	wrs, err := s.getAllWorkflowRuns()
+	if err != nil {
+		return err
+	}
+	sort.Sort(sort.Reverse(WorkflowRunSlice(wrs)))
+	for i := 0; i < len(wrs) && len(wrs)-i > workflowRunsMax; i++ {
+		if wrs[i].Status == WORKFLOW_RUN_STATUS_RUNNING {
+			continue
+		}
+		if err := s.deleteWorkflowRun(wrs[i].Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) getWorkflowRun(id string) (WorkflowRun, error) {
+	if ws, ok := s.store.(WorkflowRunStore); ok {
+		return ws.GetWorkflowRun(id)
+	}
+
+	wr, ok := s.workflowRuns[id]
+	if !ok {
+		return WorkflowRun{}, WorkflowRunNotFoundError(id)
+	}
+	return wr.copy(), nil
+}
+
+func (s *Scheduler) getAllWorkflowRuns() ([]WorkflowRun, error) {
+	if ws, ok := s.store.(WorkflowRunStore); ok {
+		return ws.GetAllWorkflowRuns()
+	}
+
+	wrs := []WorkflowRun{}
+	for _, wr := range s.workflowRuns {
+		wrs = append(wrs, wr.copy())
+	}
+	return wrs, nil
+}
+
+// The same as `WorkflowRunStore.UpdateWorkflowRun`.
+func (s *Scheduler) updateWorkflowRun(wr WorkflowRun) (bool, error) {
+	if ws, ok := s.store.(WorkflowRunStore); ok {
+		return ws.UpdateWorkflowRun(wr)
+	}
+
+	sWr, ok := s.workflowRuns[wr.Id]
+	if !ok {
+		return false, WorkflowRunNotFoundError(wr.Id)
+	}
+	if sWr.Version != wr.Version-1 {
+		return false, nil
+	}
+	s.workflowRuns[wr.Id] = wr.copy()
+	return true, nil
+}
+
+func (s *Scheduler) deleteWorkflowRun(id string) error {
+	if ws, ok := s.store.(WorkflowRunStore); ok {
+		return ws.DeleteWorkflowRun(id)
+	}
+
+	delete(s.workflowRuns, id)
+	return nil
+}